@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// ConvertTo converts this Alias to the Hub version (v1beta1)
+func (src *Alias) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Alias)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1beta1.AliasSpec{
+		URL:         src.Spec.URL,
+		SecretRef:   v1beta1.SecretReference(src.Spec.SecretRef),
+		Credentials: convertCredentialsTo(src.Spec.Credentials),
+		TLS:         (*v1beta1.TLSConfig)(src.Spec.TLS),
+		HealthCheck: (*v1beta1.AliasHealthCheck)(src.Spec.HealthCheck),
+		Region:      src.Spec.Region,
+		PathStyle:   src.Spec.PathStyle,
+		Description: src.Spec.Description,
+		Tags:        src.Spec.Tags,
+	}
+	dst.Status = v1beta1.AliasStatus(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *Alias) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Alias)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = AliasSpec{
+		URL:         src.Spec.URL,
+		SecretRef:   SecretReference(src.Spec.SecretRef),
+		Credentials: convertCredentialsFrom(src.Spec.Credentials),
+		TLS:         (*TLSConfig)(src.Spec.TLS),
+		HealthCheck: (*AliasHealthCheck)(src.Spec.HealthCheck),
+		Region:      src.Spec.Region,
+		PathStyle:   src.Spec.PathStyle,
+		Description: src.Spec.Description,
+		Tags:        src.Spec.Tags,
+	}
+	dst.Status = AliasStatus(src.Status)
+	return nil
+}
+
+// convertCredentialsTo converts alias credentials to the Hub version (v1beta1)
+func convertCredentialsTo(src *AliasCredentials) *v1beta1.AliasCredentials {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.AliasCredentials{
+		Source:          v1beta1.CredentialSource(src.Source),
+		TokenPath:       src.TokenPath,
+		RoleARN:         src.RoleARN,
+		DurationSeconds: src.DurationSeconds,
+		STSEndpoint:     src.STSEndpoint,
+	}
+}
+
+// convertCredentialsFrom converts alias credentials from the Hub version (v1beta1)
+func convertCredentialsFrom(src *v1beta1.AliasCredentials) *AliasCredentials {
+	if src == nil {
+		return nil
+	}
+	return &AliasCredentials{
+		Source:          CredentialSource(src.Source),
+		TokenPath:       src.TokenPath,
+		RoleARN:         src.RoleARN,
+		DurationSeconds: src.DurationSeconds,
+		STSEndpoint:     src.STSEndpoint,
+	}
+}
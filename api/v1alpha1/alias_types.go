@@ -30,8 +30,13 @@ type AliasSpec struct {
 	// URL is the MinIO server URL
 	URL string `json:"url"`
 
-	// SecretRef contains credentials for connecting to MinIO
-	SecretRef SecretReference `json:"secretRef"`
+	// SecretRef contains credentials for connecting to MinIO. It is required unless credentials come from a web
+	// identity token or the AWS credential chain.
+	// +optional
+	SecretRef SecretReference `json:"secretRef,omitempty"`
+
+	// Credentials selects how the alias obtains its credentials, defaults to the static keys of SecretRef
+	Credentials *AliasCredentials `json:"credentials,omitempty"`
 
 	// TLS configuration
 	TLS *TLSConfig `json:"tls,omitempty"`
@@ -73,7 +78,9 @@ type AliasHealthCheck struct {
 // AliasStatus defines the observed state of Alias
 type AliasStatus struct {
 	// Conditions represent the latest available observations of the alias's state
-	Conditions []Condition `json:"conditions,omitempty"`
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Ready indicates if the alias is ready
 	Ready bool `json:"ready"`
@@ -121,6 +128,16 @@ type Alias struct {
 	Status AliasStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the Alias
+func (in *Alias) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the Alias
+func (in *Alias) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // AliasList contains a list of Alias
@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// ConvertTo converts this Bucket to the Hub version (v1beta1)
+func (src *Bucket) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Bucket)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1beta1.BucketSpec{
+		BucketName:       src.Spec.BucketName,
+		Region:           src.Spec.Region,
+		ObjectLocking:    src.Spec.ObjectLocking,
+		Versioning:       src.Spec.Versioning,
+		Retention:        (*v1beta1.BucketRetention)(src.Spec.Retention),
+		Notification:     convertNotificationTo(src.Spec.Notification),
+		Tags:             src.Spec.Tags,
+		BucketPolicy:     (*v1beta1.BucketPolicy)(src.Spec.BucketPolicy),
+		Encryption:       (*v1beta1.BucketEncryption)(src.Spec.Encryption),
+		Adopt:            src.Spec.Adopt,
+		ManagementPolicy: v1beta1.ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      v1beta1.DriftPolicy(src.Spec.DriftPolicy),
+		Quota:            (*v1beta1.BucketQuota)(src.Spec.Quota),
+	}
+	convertConnectionTo(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	dst.Status = v1beta1.BucketStatus{
+		Conditions:          src.Status.Conditions,
+		Ready:               src.Status.Ready,
+		BucketName:          src.Status.BucketName,
+		Region:              src.Status.Region,
+		Versioning:          src.Status.Versioning,
+		ObjectLocking:       src.Status.ObjectLocking,
+		Tags:                src.Status.Tags,
+		BucketPolicyHash:    src.Status.BucketPolicyHash,
+		EncryptionAlgorithm: src.Status.EncryptionAlgorithm,
+		KMSKeyID:            src.Status.KMSKeyID,
+		CreationDate:        src.Status.CreationDate,
+		Drift:               convertDriftTo(src.Status.Drift),
+		PlannedChanges:      src.Status.PlannedChanges,
+		LastSyncTime:        src.Status.LastSyncTime,
+		ObservedGeneration:  src.Status.ObservedGeneration,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *Bucket) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Bucket)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = BucketSpec{
+		BucketName:       src.Spec.BucketName,
+		Region:           src.Spec.Region,
+		ObjectLocking:    src.Spec.ObjectLocking,
+		Versioning:       src.Spec.Versioning,
+		Retention:        (*BucketRetention)(src.Spec.Retention),
+		Notification:     convertNotificationFrom(src.Spec.Notification),
+		Tags:             src.Spec.Tags,
+		BucketPolicy:     (*BucketPolicy)(src.Spec.BucketPolicy),
+		Encryption:       (*BucketEncryption)(src.Spec.Encryption),
+		Adopt:            src.Spec.Adopt,
+		ManagementPolicy: ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      DriftPolicy(src.Spec.DriftPolicy),
+		Quota:            (*BucketQuota)(src.Spec.Quota),
+	}
+	convertConnectionFrom(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	dst.Status = BucketStatus{
+		Conditions:          src.Status.Conditions,
+		Ready:               src.Status.Ready,
+		BucketName:          src.Status.BucketName,
+		Region:              src.Status.Region,
+		Versioning:          src.Status.Versioning,
+		ObjectLocking:       src.Status.ObjectLocking,
+		Tags:                src.Status.Tags,
+		BucketPolicyHash:    src.Status.BucketPolicyHash,
+		EncryptionAlgorithm: src.Status.EncryptionAlgorithm,
+		KMSKeyID:            src.Status.KMSKeyID,
+		CreationDate:        src.Status.CreationDate,
+		Drift:               convertDriftFrom(src.Status.Drift),
+		PlannedChanges:      src.Status.PlannedChanges,
+		LastSyncTime:        src.Status.LastSyncTime,
+		ObservedGeneration:  src.Status.ObservedGeneration,
+	}
+	return nil
+}
+
+// convertNotificationTo converts a bucket notification to v1beta1
+func convertNotificationTo(src *BucketNotification) *v1beta1.BucketNotification {
+	if src == nil {
+		return nil
+	}
+	return &v1beta1.BucketNotification{
+		Events:         src.Events,
+		FilterPrefix:   src.FilterPrefix,
+		FilterSuffix:   src.FilterSuffix,
+		Topic:          src.Topic,
+		Queue:          src.Queue,
+		LambdaFunction: src.LambdaFunction,
+		TargetRef:      (*v1beta1.NotificationTargetReference)(src.TargetRef),
+	}
+}
+
+// convertNotificationFrom converts a bucket notification from v1beta1
+func convertNotificationFrom(src *v1beta1.BucketNotification) *BucketNotification {
+	if src == nil {
+		return nil
+	}
+	return &BucketNotification{
+		Events:         src.Events,
+		FilterPrefix:   src.FilterPrefix,
+		FilterSuffix:   src.FilterSuffix,
+		Topic:          src.Topic,
+		Queue:          src.Queue,
+		LambdaFunction: src.LambdaFunction,
+		TargetRef:      (*NotificationTargetReference)(src.TargetRef),
+	}
+}
@@ -51,6 +51,23 @@ type BucketSpec struct {
 	// Tags are bucket tags
 	Tags map[string]string `json:"tags,omitempty"`
 
+	// Adopt takes over an existing bucket that is not owned by another resource
+	Adopt bool `json:"adopt,omitempty"`
+
+	// ManagementPolicy defines which changes the controller makes to the bucket in MinIO. Defaults to Full.
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// DriftPolicy defines whether drift of the bucket in MinIO is corrected or only reported. Defaults to Correct.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// BucketPolicy defines the bucket policy granting anonymous access. The bucket policy is not
+	// managed if it is not set.
+	BucketPolicy *BucketPolicy `json:"bucketPolicy,omitempty"`
+
+	// Encryption defines the default server-side encryption of the bucket. The encryption is not
+	// managed if it is not set.
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
 	// Quota defines storage quota for the bucket
 	Quota *BucketQuota `json:"quota,omitempty"`
 }
@@ -81,6 +98,56 @@ type BucketNotification struct {
 	Queue *string `json:"queue,omitempty"`
 	// LambdaFunction is the notification target lambda function ARN
 	LambdaFunction *string `json:"lambdaFunction,omitempty"`
+	// TargetRef references a NotificationTarget in the namespace of the bucket whose ARN events are published to
+	TargetRef *NotificationTargetReference `json:"targetRef,omitempty"`
+}
+
+// NotificationTargetReference references a NotificationTarget resource
+type NotificationTargetReference struct {
+	// Name is the name of the NotificationTarget resource
+	Name string `json:"name"`
+}
+
+// Bucket policy presets for anonymous access, matching those of "mc anonymous set"
+const (
+	// BucketPolicyPresetNone removes the bucket policy
+	BucketPolicyPresetNone = "none"
+	// BucketPolicyPresetDownload allows anonymous downloads
+	BucketPolicyPresetDownload = "download"
+	// BucketPolicyPresetUpload allows anonymous uploads
+	BucketPolicyPresetUpload = "upload"
+	// BucketPolicyPresetPublic allows anonymous downloads and uploads
+	BucketPolicyPresetPublic = "public"
+)
+
+// BucketPolicy defines a bucket policy, either as a preset or as a raw policy document
+type BucketPolicy struct {
+	// Preset is a predefined anonymous access policy
+	//+kubebuilder:validation:Enum=none;download;upload;public
+	Preset string `json:"preset,omitempty"`
+	// Prefix limits the anonymous access of the preset to objects with the key prefix
+	Prefix string `json:"prefix,omitempty"`
+	// Policy is a raw bucket policy document in JSON format (alternative to Preset)
+	Policy string `json:"policy,omitempty"`
+}
+
+// Bucket encryption types
+const (
+	// BucketEncryptionNone removes the default encryption
+	BucketEncryptionNone = "None"
+	// BucketEncryptionSSES3 encrypts objects with keys managed by the server
+	BucketEncryptionSSES3 = "SSE-S3"
+	// BucketEncryptionSSEKMS encrypts objects with a key of the KMS
+	BucketEncryptionSSEKMS = "SSE-KMS"
+)
+
+// BucketEncryption defines the default server-side encryption of a bucket
+type BucketEncryption struct {
+	// Type is the encryption type
+	//+kubebuilder:validation:Enum=None;SSE-S3;SSE-KMS
+	Type string `json:"type"`
+	// KMSKeyID is the KMS key used for SSE-KMS. The default key of the KMS is used if it is not set.
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
 }
 
 // BucketQuota defines bucket storage quota
@@ -92,7 +159,9 @@ type BucketQuota struct {
 // BucketStatus defines the observed state of Bucket
 type BucketStatus struct {
 	// Conditions represent the latest available observations of the bucket's state
-	Conditions []Condition `json:"conditions,omitempty"`
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Ready indicates if the bucket is ready
 	Ready bool `json:"ready"`
@@ -103,9 +172,33 @@ type BucketStatus struct {
 	// Region is the bucket region
 	Region string `json:"region,omitempty"`
 
+	// Versioning is the live versioning status of the bucket (Enabled or Suspended)
+	Versioning string `json:"versioning,omitempty"`
+
+	// ObjectLocking indicates if object locking is enabled on the bucket
+	ObjectLocking bool `json:"objectLocking,omitempty"`
+
+	// Tags are the live bucket tags, without the owner tag
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// BucketPolicyHash is the hash of the live bucket policy, empty if the bucket has no policy
+	BucketPolicyHash string `json:"bucketPolicyHash,omitempty"`
+
+	// EncryptionAlgorithm is the live default encryption algorithm, e.g. AES256 or aws:kms
+	EncryptionAlgorithm string `json:"encryptionAlgorithm,omitempty"`
+
+	// KMSKeyID is the live KMS key of the default encryption
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+
 	// CreationDate is when the bucket was created
 	CreationDate *metav1.Time `json:"creationDate,omitempty"`
 
+	// Drift lists the differences between the desired and the live state that were not corrected
+	Drift []DriftEntry `json:"drift,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
 	// LastSyncTime is the last time the resource was synchronized
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
@@ -130,6 +223,16 @@ type Bucket struct {
 	Status BucketStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the Bucket
+func (in *Bucket) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the Bucket
+func (in *Bucket) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // BucketList contains a list of Bucket
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"maps"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// convertConnectionTo converts a connection to v1beta1, keeping a deprecated endpointRef in an annotation
+func convertConnectionTo(src MinIOConnection, dst *v1beta1.MinIOConnection, dstMeta *metav1.ObjectMeta) {
+	dst.AliasRef = (*v1beta1.AliasReference)(src.AliasRef)
+	dst.ClusterAliasRef = (*v1beta1.ClusterAliasReference)(src.ClusterAliasRef)
+	dst.URL = src.URL
+	dst.SecretRef = (*v1beta1.SecretReference)(src.SecretRef)
+	dst.TLS = (*v1beta1.TLSConfig)(src.TLS)
+
+	if src.EndpointRef != nil {
+		value := src.EndpointRef.Name
+		if src.EndpointRef.Namespace != nil {
+			value = *src.EndpointRef.Namespace + "/" + value
+		}
+		setAnnotation(dstMeta, v1beta1.LegacyEndpointRefAnnotation, value)
+	}
+}
+
+// convertConnectionFrom converts a connection from v1beta1, restoring a deprecated endpointRef from its annotation
+func convertConnectionFrom(src v1beta1.MinIOConnection, dst *MinIOConnection, dstMeta *metav1.ObjectMeta) {
+	dst.AliasRef = (*AliasReference)(src.AliasRef)
+	dst.ClusterAliasRef = (*ClusterAliasReference)(src.ClusterAliasRef)
+	dst.URL = src.URL
+	dst.SecretRef = (*SecretReference)(src.SecretRef)
+	dst.TLS = (*TLSConfig)(src.TLS)
+
+	if value, ok := popAnnotation(dstMeta, v1beta1.LegacyEndpointRefAnnotation); ok {
+		dst.EndpointRef = ParseEndpointReference(value)
+	}
+}
+
+// convertDriftTo converts drift entries to v1beta1
+func convertDriftTo(src []DriftEntry) []v1beta1.DriftEntry {
+	if src == nil {
+		return nil
+	}
+	dst := make([]v1beta1.DriftEntry, len(src))
+	for i, entry := range src {
+		dst[i] = v1beta1.DriftEntry(entry)
+	}
+	return dst
+}
+
+// convertDriftFrom converts drift entries from v1beta1
+func convertDriftFrom(src []v1beta1.DriftEntry) []DriftEntry {
+	if src == nil {
+		return nil
+	}
+	dst := make([]DriftEntry, len(src))
+	for i, entry := range src {
+		dst[i] = DriftEntry(entry)
+	}
+	return dst
+}
+
+// ParseEndpointReference parses an endpoint reference in the "namespace/name" or "name" format
+func ParseEndpointReference(value string) *EndpointReference {
+	if namespace, name, ok := strings.Cut(value, "/"); ok {
+		return &EndpointReference{Name: name, Namespace: &namespace}
+	}
+	return &EndpointReference{Name: value}
+}
+
+// setAnnotation sets an annotation without modifying a map shared with the conversion source
+func setAnnotation(meta *metav1.ObjectMeta, key, value string) {
+	annotations := maps.Clone(meta.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	meta.Annotations = annotations
+}
+
+// popAnnotation removes an annotation without modifying a map shared with the conversion source
+func popAnnotation(meta *metav1.ObjectMeta, key string) (string, bool) {
+	value, ok := meta.Annotations[key]
+	if !ok {
+		return "", false
+	}
+
+	annotations := maps.Clone(meta.Annotations)
+	delete(annotations, key)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	meta.Annotations = annotations
+	return value, true
+}
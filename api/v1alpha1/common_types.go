@@ -16,14 +16,12 @@ limitations under the License.
 
 package v1alpha1
 
-import (
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-)
-
 // MinIOConnection defines connection details to a MinIO instance
 type MinIOConnection struct {
 	// AliasRef references an Alias resource for connection details
 	AliasRef *AliasReference `json:"aliasRef,omitempty"`
+	// ClusterAliasRef references a cluster-scoped ClusterAlias resource for connection details
+	ClusterAliasRef *ClusterAliasReference `json:"clusterAliasRef,omitempty"`
 	// EndpointRef references an Endpoint resource for connection details (deprecated, use aliasRef)
 	EndpointRef *EndpointReference `json:"endpointRef,omitempty"`
 	// URL is the MinIO server URL (alternative to AliasRef/EndpointRef)
@@ -42,6 +40,12 @@ type AliasReference struct {
 	Namespace *string `json:"namespace,omitempty"`
 }
 
+// ClusterAliasReference references a ClusterAlias resource
+type ClusterAliasReference struct {
+	// Name is the name of the ClusterAlias resource
+	Name string `json:"name"`
+}
+
 // EndpointReference references an Endpoint resource (deprecated)
 type EndpointReference struct {
 	// Name is the name of the Endpoint resource
@@ -62,6 +66,16 @@ type SecretReference struct {
 	SecretAccessKeyKey string `json:"secretAccessKeyKey,omitempty"`
 }
 
+// Default keys of the values in credential secrets
+const (
+	// DefaultAccessKeyIDKey is the default secret key containing the access key ID
+	DefaultAccessKeyIDKey = "accessKeyID"
+	// DefaultSecretAccessKeyKey is the default secret key containing the secret access key
+	DefaultSecretAccessKeyKey = "secretAccessKey"
+	// DefaultPasswordKey is the default secret key containing a user's password
+	DefaultPasswordKey = "password"
+)
+
 // TLSConfig defines TLS configuration for MinIO connection
 type TLSConfig struct {
 	// Insecure allows connections to MinIO using TLS without certs validation
@@ -70,69 +84,101 @@ type TLSConfig struct {
 	CABundle []byte `json:"caBundle,omitempty"`
 }
 
-// ConditionType represents the type of condition
-type ConditionType string
+// CredentialSource selects how an alias obtains the credentials it authenticates with
+// +kubebuilder:validation:Enum=Secret;WebIdentity;AssumeRole;AWS
+type CredentialSource string
 
 const (
-	// ConditionReady indicates the resource is ready
-	ConditionReady ConditionType = "Ready"
-	// ConditionProgressing indicates the resource is being processed
-	ConditionProgressing ConditionType = "Progressing"
-	// ConditionDegraded indicates the resource is in a degraded state
-	ConditionDegraded ConditionType = "Degraded"
-	// ConditionError indicates the resource encountered an error
-	ConditionError ConditionType = "Error"
+	// CredentialSourceSecret authenticates with the static keys of the secret reference
+	CredentialSourceSecret CredentialSource = "Secret"
+	// CredentialSourceWebIdentity exchanges the controller's projected service account token for temporary
+	// credentials with AssumeRoleWithWebIdentity
+	CredentialSourceWebIdentity CredentialSource = "WebIdentity"
+	// CredentialSourceAssumeRole exchanges the (less privileged) keys of the secret reference for temporary
+	// credentials with AssumeRole
+	CredentialSourceAssumeRole CredentialSource = "AssumeRole"
+	// CredentialSourceAWS uses the AWS credential chain: environment, shared credentials file and IAM, which
+	// includes IRSA web identity tokens and instance metadata
+	CredentialSourceAWS CredentialSource = "AWS"
 )
 
-// Condition represents the condition of a resource
-type Condition struct {
-	// Type is the type of the condition
-	Type ConditionType `json:"type"`
-	// Status is the status of the condition
-	Status metav1.ConditionStatus `json:"status"`
-	// LastTransitionTime is the last time the condition transitioned
-	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
-	// Reason is a unique, one-word, CamelCase reason for the condition's last transition
-	Reason string `json:"reason,omitempty"`
-	// Message is a human-readable message indicating details about the transition
-	Message string `json:"message,omitempty"`
-}
+// DefaultWebIdentityTokenPath is where the controller expects its projected service account token
+const DefaultWebIdentityTokenPath = "/var/run/secrets/mc-controller/sts/token"
+
+// AliasCredentials configures how an alias obtains its credentials. Temporary credentials are refreshed
+// before they expire.
+type AliasCredentials struct {
+	// Source selects where the credentials come from. WebIdentity and AWS authenticate as the operator pod and
+	// are only permitted for namespaced Aliases if the operator allows it.
+	// +kubebuilder:default=Secret
+	Source CredentialSource `json:"source,omitempty"`
+
+	// TokenPath is the file holding the web identity token, used with the WebIdentity source.
+	// Defaults to /var/run/secrets/mc-controller/sts/token. Only ClusterAliases may set another path.
+	TokenPath string `json:"tokenPath,omitempty"`
+
+	// RoleARN is the role to assume with the WebIdentity and AssumeRole sources
+	RoleARN string `json:"roleArn,omitempty"`
 
-// SetCondition sets a condition on a list of conditions
-func SetCondition(conditions *[]Condition, conditionType ConditionType, status metav1.ConditionStatus, reason, message string) {
-	now := metav1.Now()
-
-	for i, condition := range *conditions {
-		if condition.Type == conditionType {
-			if condition.Status != status {
-				condition.Status = status
-				condition.LastTransitionTime = now
-				condition.Reason = reason
-				condition.Message = message
-			} else {
-				condition.Reason = reason
-				condition.Message = message
-			}
-			(*conditions)[i] = condition
-			return
-		}
-	}
-
-	*conditions = append(*conditions, Condition{
-		Type:               conditionType,
-		Status:             status,
-		LastTransitionTime: now,
-		Reason:             reason,
-		Message:            message,
-	})
+	// DurationSeconds is the requested lifetime of temporary credentials
+	// +kubebuilder:validation:Minimum=900
+	// +kubebuilder:validation:Maximum=43200
+	DurationSeconds *int32 `json:"durationSeconds,omitempty"`
+
+	// STSEndpoint is the URL of the STS service, defaults to the alias URL. Namespaced Aliases only send the web
+	// identity token to the host of their URL or to hosts allowed by the operator.
+	STSEndpoint *string `json:"stsEndpoint,omitempty"`
 }
 
-// GetCondition returns a condition by type
-func GetCondition(conditions []Condition, conditionType ConditionType) *Condition {
-	for i, condition := range conditions {
-		if condition.Type == conditionType {
-			return &conditions[i]
-		}
-	}
-	return nil
+// Condition types follow the kstatus conventions so that tools like Argo CD and Flux
+// can assess the health of the resources.
+const (
+	// ConditionReady indicates the resource is ready
+	ConditionReady = "Ready"
+	// ConditionReconciling indicates the controller is working towards the desired state
+	ConditionReconciling = "Reconciling"
+	// ConditionStalled indicates the controller hit an error and cannot make progress
+	ConditionStalled = "Stalled"
+	// ConditionConflict indicates the MinIO resource is owned by another resource or by no resource at all
+	ConditionConflict = "Conflict"
+	// ConditionDrifted indicates the live state in MinIO differs from the desired state
+	ConditionDrifted = "Drifted"
+	// ConditionPaused indicates reconciliation is suspended by the paused annotation
+	ConditionPaused = "Paused"
+)
+
+// DriftPolicy defines how differences between the desired and the live state in MinIO are handled
+// +kubebuilder:validation:Enum=Correct;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyCorrect restores the desired state in MinIO
+	DriftPolicyCorrect DriftPolicy = "Correct"
+	// DriftPolicyReport only reports drift in the status
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// DriftEntry is a difference between the desired state of a resource and the live state in MinIO
+type DriftEntry struct {
+	// Field is the drifted setting, e.g. "versioning" or "tags.team"
+	Field string `json:"field"`
+	// Desired is the value defined by the spec
+	Desired string `json:"desired,omitempty"`
+	// Actual is the live value in MinIO
+	Actual string `json:"actual,omitempty"`
 }
+
+// ManagementPolicy defines which changes the controller makes to the MinIO resource of a resource
+// +kubebuilder:validation:Enum=Observe;CreateOnly;Full;FullNoDelete
+type ManagementPolicy string
+
+const (
+	// ManagementPolicyObserve only reports the live state of an existing MinIO resource
+	ManagementPolicyObserve ManagementPolicy = "Observe"
+	// ManagementPolicyCreateOnly creates a missing MinIO resource but never updates or deletes it
+	ManagementPolicyCreateOnly ManagementPolicy = "CreateOnly"
+	// ManagementPolicyFull creates, updates and deletes the MinIO resource
+	ManagementPolicyFull ManagementPolicy = "Full"
+	// ManagementPolicyFullNoDelete creates and updates the MinIO resource but keeps it on deletion
+	ManagementPolicyFullNoDelete ManagementPolicy = "FullNoDelete"
+)
@@ -47,6 +47,19 @@ type EndpointSpec struct {
 
 	// Tags are endpoint tags
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// Migration configures the migration of this Endpoint to an Alias
+	Migration *EndpointMigration `json:"migration,omitempty"`
+}
+
+// EndpointMigration configures the migration of an Endpoint to an Alias
+type EndpointMigration struct {
+	// Enabled creates an equivalent Alias and rewrites the endpointRef of dependent resources to reference it
+	Enabled bool `json:"enabled"`
+
+	// AliasName is the name of the Alias to migrate to, defaults to the name of the Endpoint.
+	// An existing Alias with this name is used as is.
+	AliasName *string `json:"aliasName,omitempty"`
 }
 
 // EndpointHealthCheck defines health check configuration
@@ -70,7 +83,9 @@ type EndpointHealthCheck struct {
 // EndpointStatus defines the observed state of Endpoint
 type EndpointStatus struct {
 	// Conditions represent the latest available observations of the endpoint's state
-	Conditions []Condition `json:"conditions,omitempty"`
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Ready indicates if the endpoint is ready
 	Ready bool `json:"ready"`
@@ -93,6 +108,12 @@ type EndpointStatus struct {
 	// ConnectedAt is when the connection was established
 	ConnectedAt *metav1.Time `json:"connectedAt,omitempty"`
 
+	// Dependents is the number of resources still connecting through this Endpoint
+	Dependents int32 `json:"dependents"`
+
+	// Migration records the migration of this Endpoint to an Alias
+	Migration *EndpointMigrationStatus `json:"migration,omitempty"`
+
 	// LastSyncTime is the last time the resource was synchronized
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
@@ -100,6 +121,18 @@ type EndpointStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// EndpointMigrationStatus records the migration of an Endpoint to an Alias
+type EndpointMigrationStatus struct {
+	// AliasName is the name of the Alias the Endpoint is migrated to
+	AliasName string `json:"aliasName"`
+
+	// MigratedAt is when the migration to the Alias started
+	MigratedAt *metav1.Time `json:"migratedAt,omitempty"`
+
+	// MigratedDependents is the number of dependent resources rewritten to reference the Alias
+	MigratedDependents int32 `json:"migratedDependents,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:shortName=minioendpoint
@@ -107,6 +140,8 @@ type EndpointStatus struct {
 //+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
 //+kubebuilder:printcolumn:name="Healthy",type="boolean",JSONPath=".status.healthy"
 //+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+//+kubebuilder:printcolumn:name="Dependents",type="integer",JSONPath=".status.dependents"
+//+kubebuilder:printcolumn:name="Alias",type="string",JSONPath=".status.migration.aliasName",priority=1
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Endpoint is the Schema for the endpoints API
@@ -118,6 +153,16 @@ type Endpoint struct {
 	Status EndpointStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the Endpoint
+func (in *Endpoint) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the Endpoint
+func (in *Endpoint) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // EndpointList contains a list of Endpoint
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// ConvertTo converts this LifecyclePolicy to the Hub version (v1beta1)
+func (src *LifecyclePolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.LifecyclePolicy)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1beta1.LifecyclePolicySpec{
+		BucketName: src.Spec.BucketName,
+	}
+	convertConnectionTo(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	for _, rule := range src.Spec.Rules {
+		dst.Spec.Rules = append(dst.Spec.Rules, convertLifecycleRuleTo(rule))
+	}
+	dst.Status = v1beta1.LifecyclePolicyStatus(src.Status)
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *LifecyclePolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.LifecyclePolicy)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = LifecyclePolicySpec{
+		BucketName: src.Spec.BucketName,
+	}
+	convertConnectionFrom(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	for _, rule := range src.Spec.Rules {
+		dst.Spec.Rules = append(dst.Spec.Rules, convertLifecycleRuleFrom(rule))
+	}
+	dst.Status = LifecyclePolicyStatus(src.Status)
+	return nil
+}
+
+// convertLifecycleRuleTo converts a lifecycle rule to v1beta1
+func convertLifecycleRuleTo(src LifecycleRule) v1beta1.LifecycleRule {
+	dst := v1beta1.LifecycleRule{
+		ID:                             src.ID,
+		Status:                         v1beta1.LifecycleRuleStatus(src.Status),
+		Expiration:                     (*v1beta1.LifecycleExpiration)(src.Expiration),
+		NoncurrentVersionExpiration:    (*v1beta1.NoncurrentVersionExpiration)(src.NoncurrentVersionExpiration),
+		AbortIncompleteMultipartUpload: (*v1beta1.AbortIncompleteMultipartUpload)(src.AbortIncompleteMultipartUpload),
+	}
+	if src.Filter != nil {
+		dst.Filter = &v1beta1.LifecycleFilter{
+			Prefix: src.Filter.Prefix,
+			Tags:   src.Filter.Tags,
+			And:    (*v1beta1.LifecycleFilterAnd)(src.Filter.And),
+		}
+	}
+	for _, transition := range src.Transitions {
+		dst.Transitions = append(dst.Transitions, v1beta1.LifecycleTransition(transition))
+	}
+	for _, transition := range src.NoncurrentVersionTransitions {
+		dst.NoncurrentVersionTransitions = append(dst.NoncurrentVersionTransitions, v1beta1.NoncurrentVersionTransition(transition))
+	}
+	return dst
+}
+
+// convertLifecycleRuleFrom converts a lifecycle rule from v1beta1
+func convertLifecycleRuleFrom(src v1beta1.LifecycleRule) LifecycleRule {
+	dst := LifecycleRule{
+		ID:                             src.ID,
+		Status:                         LifecycleRuleStatus(src.Status),
+		Expiration:                     (*LifecycleExpiration)(src.Expiration),
+		NoncurrentVersionExpiration:    (*NoncurrentVersionExpiration)(src.NoncurrentVersionExpiration),
+		AbortIncompleteMultipartUpload: (*AbortIncompleteMultipartUpload)(src.AbortIncompleteMultipartUpload),
+	}
+	if src.Filter != nil {
+		dst.Filter = &LifecycleFilter{
+			Prefix: src.Filter.Prefix,
+			Tags:   src.Filter.Tags,
+			And:    (*LifecycleFilterAnd)(src.Filter.And),
+		}
+	}
+	for _, transition := range src.Transitions {
+		dst.Transitions = append(dst.Transitions, LifecycleTransition(transition))
+	}
+	for _, transition := range src.NoncurrentVersionTransitions {
+		dst.NoncurrentVersionTransitions = append(dst.NoncurrentVersionTransitions, NoncurrentVersionTransition(transition))
+	}
+	return dst
+}
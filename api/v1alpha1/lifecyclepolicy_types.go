@@ -143,7 +143,9 @@ type NoncurrentVersionTransition struct {
 // LifecyclePolicyStatus defines the observed state of LifecyclePolicy
 type LifecyclePolicyStatus struct {
 	// Conditions represent the latest available observations of the lifecycle policy's state
-	Conditions []Condition `json:"conditions,omitempty"`
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Ready indicates if the lifecycle policy is ready
 	Ready bool `json:"ready"`
@@ -162,6 +164,9 @@ type LifecyclePolicyStatus struct {
 
 	// ObservedGeneration is the most recent generation observed by the controller
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -180,6 +185,16 @@ type LifecyclePolicy struct {
 	Status LifecyclePolicyStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the LifecyclePolicy
+func (in *LifecyclePolicy) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the LifecyclePolicy
+func (in *LifecyclePolicy) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // LifecyclePolicyList contains a list of LifecyclePolicy
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// ConvertTo converts this Policy to the Hub version (v1beta1)
+func (src *Policy) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.Policy)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1beta1.PolicySpec{
+		PolicyName:       src.Spec.PolicyName,
+		Policy:           src.Spec.Policy,
+		Description:      src.Spec.Description,
+		Tags:             src.Spec.Tags,
+		Adopt:            src.Spec.Adopt,
+		ManagementPolicy: v1beta1.ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      v1beta1.DriftPolicy(src.Spec.DriftPolicy),
+	}
+	convertConnectionTo(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	dst.Status = v1beta1.PolicyStatus{
+		Conditions:         src.Status.Conditions,
+		Ready:              src.Status.Ready,
+		PolicyName:         src.Status.PolicyName,
+		PolicyHash:         src.Status.PolicyHash,
+		CreationDate:       src.Status.CreationDate,
+		Drift:              convertDriftTo(src.Status.Drift),
+		PlannedChanges:     src.Status.PlannedChanges,
+		LastSyncTime:       src.Status.LastSyncTime,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *Policy) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.Policy)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = PolicySpec{
+		PolicyName:       src.Spec.PolicyName,
+		Policy:           src.Spec.Policy,
+		Description:      src.Spec.Description,
+		Tags:             src.Spec.Tags,
+		Adopt:            src.Spec.Adopt,
+		ManagementPolicy: ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      DriftPolicy(src.Spec.DriftPolicy),
+	}
+	convertConnectionFrom(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	dst.Status = PolicyStatus{
+		Conditions:         src.Status.Conditions,
+		Ready:              src.Status.Ready,
+		PolicyName:         src.Status.PolicyName,
+		PolicyHash:         src.Status.PolicyHash,
+		CreationDate:       src.Status.CreationDate,
+		Drift:              convertDriftFrom(src.Status.Drift),
+		PlannedChanges:     src.Status.PlannedChanges,
+		LastSyncTime:       src.Status.LastSyncTime,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+	return nil
+}
@@ -41,12 +41,23 @@ type PolicySpec struct {
 
 	// Tags are policy tags
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// Adopt takes over an existing canned policy that is not owned by another resource
+	Adopt bool `json:"adopt,omitempty"`
+
+	// ManagementPolicy defines which changes the controller makes to the canned policy in MinIO. Defaults to Full.
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// DriftPolicy defines whether drift of the canned policy in MinIO is corrected or only reported. Defaults to Correct.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // PolicyStatus defines the observed state of Policy
 type PolicyStatus struct {
 	// Conditions represent the latest available observations of the policy's state
-	Conditions []Condition `json:"conditions,omitempty"`
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Ready indicates if the policy is ready
 	Ready bool `json:"ready"`
@@ -60,6 +71,12 @@ type PolicyStatus struct {
 	// CreationDate is when the policy was created
 	CreationDate *metav1.Time `json:"creationDate,omitempty"`
 
+	// Drift lists the differences between the desired and the live state that were not corrected
+	Drift []DriftEntry `json:"drift,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
 	// LastSyncTime is the last time the resource was synchronized
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
@@ -83,6 +100,16 @@ type Policy struct {
 	Status PolicyStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the Policy
+func (in *Policy) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the Policy
+func (in *Policy) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // PolicyList contains a list of Policy
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// ConvertTo converts this PolicyAttachment to the Hub version (v1beta1)
+func (src *PolicyAttachment) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.PolicyAttachment)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1beta1.PolicyAttachmentSpec{
+		PolicyName:       src.Spec.PolicyName,
+		Target:           convertTargetTo(src.Spec.Target),
+		ManagementPolicy: v1beta1.ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      v1beta1.DriftPolicy(src.Spec.DriftPolicy),
+	}
+	convertConnectionTo(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	dst.Status = v1beta1.PolicyAttachmentStatus{
+		Conditions:         src.Status.Conditions,
+		Ready:              src.Status.Ready,
+		PolicyName:         src.Status.PolicyName,
+		Target:             src.Status.Target,
+		AttachedAt:         src.Status.AttachedAt,
+		Drift:              convertDriftTo(src.Status.Drift),
+		PlannedChanges:     src.Status.PlannedChanges,
+		LastSyncTime:       src.Status.LastSyncTime,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *PolicyAttachment) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.PolicyAttachment)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = PolicyAttachmentSpec{
+		PolicyName:       src.Spec.PolicyName,
+		Target:           convertTargetFrom(src.Spec.Target),
+		ManagementPolicy: ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      DriftPolicy(src.Spec.DriftPolicy),
+	}
+	convertConnectionFrom(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	dst.Status = PolicyAttachmentStatus{
+		Conditions:         src.Status.Conditions,
+		Ready:              src.Status.Ready,
+		PolicyName:         src.Status.PolicyName,
+		Target:             src.Status.Target,
+		AttachedAt:         src.Status.AttachedAt,
+		Drift:              convertDriftFrom(src.Status.Drift),
+		PlannedChanges:     src.Status.PlannedChanges,
+		LastSyncTime:       src.Status.LastSyncTime,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+	return nil
+}
+
+// convertTargetTo converts a policy attachment target to v1beta1
+func convertTargetTo(src PolicyAttachmentTarget) v1beta1.PolicyAttachmentTarget {
+	dst := v1beta1.PolicyAttachmentTarget{
+		User:           src.User,
+		Group:          src.Group,
+		ServiceAccount: src.ServiceAccount,
+		LDAPUser:       src.LDAPUser,
+		LDAPGroup:      src.LDAPGroup,
+	}
+	if src.OpenIDClaim != nil {
+		dst.OpenIDClaim = &v1beta1.OpenIDClaimTarget{
+			IdentityProviderRef: v1beta1.IdentityProviderReference(src.OpenIDClaim.IdentityProviderRef),
+			ClaimValue:          src.OpenIDClaim.ClaimValue,
+		}
+	}
+	return dst
+}
+
+// convertTargetFrom converts a policy attachment target from v1beta1
+func convertTargetFrom(src v1beta1.PolicyAttachmentTarget) PolicyAttachmentTarget {
+	dst := PolicyAttachmentTarget{
+		User:           src.User,
+		Group:          src.Group,
+		ServiceAccount: src.ServiceAccount,
+		LDAPUser:       src.LDAPUser,
+		LDAPGroup:      src.LDAPGroup,
+	}
+	if src.OpenIDClaim != nil {
+		dst.OpenIDClaim = &OpenIDClaimTarget{
+			IdentityProviderRef: IdentityProviderReference(src.OpenIDClaim.IdentityProviderRef),
+			ClaimValue:          src.OpenIDClaim.ClaimValue,
+		}
+	}
+	return dst
+}
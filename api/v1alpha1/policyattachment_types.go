@@ -35,6 +35,12 @@ type PolicyAttachmentSpec struct {
 
 	// Target defines what the policy should be attached to
 	Target PolicyAttachmentTarget `json:"target"`
+
+	// ManagementPolicy defines which changes the controller makes to the policy attachment in MinIO. Defaults to Full.
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// DriftPolicy defines whether drift of the policy attachment in MinIO is corrected or only reported. Defaults to Correct.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // PolicyAttachmentTarget defines the target for policy attachment
@@ -47,12 +53,41 @@ type PolicyAttachmentTarget struct {
 
 	// ServiceAccount is the service account to attach the policy to
 	ServiceAccount *string `json:"serviceAccount,omitempty"`
+
+	// LDAPUser is the distinguished name of an LDAP user to attach the policy to
+	LDAPUser *string `json:"ldapUser,omitempty"`
+
+	// LDAPGroup is the distinguished name of an LDAP group to attach the policy to
+	LDAPGroup *string `json:"ldapGroup,omitempty"`
+
+	// OpenIDClaim maps a value of the policy claim of an OpenID identity provider to the policy
+	OpenIDClaim *OpenIDClaimTarget `json:"openIDClaim,omitempty"`
+}
+
+// OpenIDClaimTarget maps a value of the policy claim in tokens of an OpenID identity provider to a policy.
+// MinIO grants the canned policies named by the claim values, so the policy is published under the claim
+// value unless both are equal.
+type OpenIDClaimTarget struct {
+	// IdentityProviderRef references the OpenID IdentityProvider in the namespace of the attachment
+	IdentityProviderRef IdentityProviderReference `json:"identityProviderRef"`
+
+	// ClaimValue is the value of the policy claim granting the policy. Defaults to the policy name.
+	//+kubebuilder:validation:Pattern=`^[A-Za-z0-9_.@+=,-]+$`
+	ClaimValue string `json:"claimValue,omitempty"`
+}
+
+// IdentityProviderReference references an IdentityProvider resource
+type IdentityProviderReference struct {
+	// Name is the name of the IdentityProvider resource
+	Name string `json:"name"`
 }
 
 // PolicyAttachmentStatus defines the observed state of PolicyAttachment
 type PolicyAttachmentStatus struct {
 	// Conditions represent the latest available observations of the policy attachment's state
-	Conditions []Condition `json:"conditions,omitempty"`
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Ready indicates if the policy attachment is ready
 	Ready bool `json:"ready"`
@@ -66,6 +101,12 @@ type PolicyAttachmentStatus struct {
 	// AttachedAt is when the policy was attached
 	AttachedAt *metav1.Time `json:"attachedAt,omitempty"`
 
+	// Drift lists the differences between the desired and the live state that were not corrected
+	Drift []DriftEntry `json:"drift,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
 	// LastSyncTime is the last time the resource was synchronized
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
@@ -90,6 +131,16 @@ type PolicyAttachment struct {
 	Status PolicyAttachmentStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the PolicyAttachment
+func (in *PolicyAttachment) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the PolicyAttachment
+func (in *PolicyAttachment) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // PolicyAttachmentList contains a list of PolicyAttachment
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// ConvertTo converts this User to the Hub version (v1beta1). A plaintext password is kept in an
+// annotation until the controller has moved it into a secret.
+func (src *User) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.User)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1beta1.UserSpec{
+		Username:         src.Spec.Username,
+		Status:           v1beta1.UserStatusType(src.Spec.Status),
+		Groups:           src.Spec.Groups,
+		Policies:         src.Spec.Policies,
+		Tags:             src.Spec.Tags,
+		Adopt:            src.Spec.Adopt,
+		ManagementPolicy: v1beta1.ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      v1beta1.DriftPolicy(src.Spec.DriftPolicy),
+	}
+	convertConnectionTo(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	if src.Spec.SecretRef != nil {
+		dst.Spec.SecretRef = &v1beta1.UserSecretReference{
+			Name:        src.Spec.SecretRef.Name,
+			Namespace:   src.Spec.SecretRef.Namespace,
+			PasswordKey: src.Spec.SecretRef.SecretAccessKeyKey,
+		}
+	}
+	if src.Spec.Password != nil {
+		setAnnotation(&dst.ObjectMeta, v1beta1.LegacyPasswordAnnotation, *src.Spec.Password)
+	}
+
+	dst.Status = v1beta1.UserStatus{
+		Conditions:         src.Status.Conditions,
+		Ready:              src.Status.Ready,
+		Username:           src.Status.Username,
+		Status:             v1beta1.UserStatusType(src.Status.Status),
+		Groups:             src.Status.Groups,
+		Policies:           src.Status.Policies,
+		CreationDate:       src.Status.CreationDate,
+		Drift:              convertDriftTo(src.Status.Drift),
+		PlannedChanges:     src.Status.PlannedChanges,
+		LastSyncTime:       src.Status.LastSyncTime,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1beta1) to this version
+func (dst *User) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.User)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = UserSpec{
+		Username:         src.Spec.Username,
+		Status:           UserStatusType(src.Spec.Status),
+		Groups:           src.Spec.Groups,
+		Policies:         src.Spec.Policies,
+		Tags:             src.Spec.Tags,
+		Adopt:            src.Spec.Adopt,
+		ManagementPolicy: ManagementPolicy(src.Spec.ManagementPolicy),
+		DriftPolicy:      DriftPolicy(src.Spec.DriftPolicy),
+	}
+	convertConnectionFrom(src.Spec.Connection, &dst.Spec.Connection, &dst.ObjectMeta)
+	if src.Spec.SecretRef != nil {
+		dst.Spec.SecretRef = &SecretReference{
+			Name:               src.Spec.SecretRef.Name,
+			Namespace:          src.Spec.SecretRef.Namespace,
+			SecretAccessKeyKey: src.Spec.SecretRef.PasswordKey,
+		}
+	}
+	if password, ok := popAnnotation(&dst.ObjectMeta, v1beta1.LegacyPasswordAnnotation); ok {
+		dst.Spec.Password = &password
+	}
+
+	dst.Status = UserStatus{
+		Conditions:         src.Status.Conditions,
+		Ready:              src.Status.Ready,
+		Username:           src.Status.Username,
+		Status:             UserStatusType(src.Status.Status),
+		Groups:             src.Status.Groups,
+		Policies:           src.Status.Policies,
+		CreationDate:       src.Status.CreationDate,
+		Drift:              convertDriftFrom(src.Status.Drift),
+		PlannedChanges:     src.Status.PlannedChanges,
+		LastSyncTime:       src.Status.LastSyncTime,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+	return nil
+}
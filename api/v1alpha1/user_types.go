@@ -50,6 +50,15 @@ type UserSpec struct {
 
 	// Tags are user tags
 	Tags map[string]string `json:"tags,omitempty"`
+
+	// Adopt takes over an existing user that is not owned by another resource
+	Adopt bool `json:"adopt,omitempty"`
+
+	// ManagementPolicy defines which changes the controller makes to the user in MinIO. Defaults to Full.
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// DriftPolicy defines whether drift of the user in MinIO is corrected or only reported. Defaults to Correct.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
 // UserStatusType defines the status of a user
@@ -65,7 +74,9 @@ const (
 // UserStatus defines the observed state of User
 type UserStatus struct {
 	// Conditions represent the latest available observations of the user's state
-	Conditions []Condition `json:"conditions,omitempty"`
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
 	// Ready indicates if the user is ready
 	Ready bool `json:"ready"`
@@ -85,6 +96,12 @@ type UserStatus struct {
 	// CreationDate is when the user was created
 	CreationDate *metav1.Time `json:"creationDate,omitempty"`
 
+	// Drift lists the differences between the desired and the live state that were not corrected
+	Drift []DriftEntry `json:"drift,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
 	// LastSyncTime is the last time the resource was synchronized
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
@@ -109,6 +126,16 @@ type User struct {
 	Status UserStatus `json:"status,omitempty"`
 }
 
+// GetConditions returns the status conditions of the User
+func (in *User) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the User
+func (in *User) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
 //+kubebuilder:object:root=true
 
 // UserList contains a list of User
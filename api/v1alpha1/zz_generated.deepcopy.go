@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -66,6 +67,31 @@ func (in *Alias) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasCredentials) DeepCopyInto(out *AliasCredentials) {
+	*out = *in
+	if in.DurationSeconds != nil {
+		in, out := &in.DurationSeconds, &out.DurationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.STSEndpoint != nil {
+		in, out := &in.STSEndpoint, &out.STSEndpoint
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasCredentials.
+func (in *AliasCredentials) DeepCopy() *AliasCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AliasHealthCheck) DeepCopyInto(out *AliasHealthCheck) {
 	*out = *in
@@ -157,6 +183,11 @@ func (in *AliasReference) DeepCopy() *AliasReference {
 func (in *AliasSpec) DeepCopyInto(out *AliasSpec) {
 	*out = *in
 	in.SecretRef.DeepCopyInto(&out.SecretRef)
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(AliasCredentials)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TLSConfig)
@@ -201,7 +232,7 @@ func (in *AliasStatus) DeepCopyInto(out *AliasStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -257,6 +288,21 @@ func (in *Bucket) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketEncryption) DeepCopyInto(out *BucketEncryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketEncryption.
+func (in *BucketEncryption) DeepCopy() *BucketEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketList) DeepCopyInto(out *BucketList) {
 	*out = *in
@@ -322,6 +368,11 @@ func (in *BucketNotification) DeepCopyInto(out *BucketNotification) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(NotificationTargetReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketNotification.
@@ -334,6 +385,21 @@ func (in *BucketNotification) DeepCopy() *BucketNotification {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicy) DeepCopyInto(out *BucketPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicy.
+func (in *BucketPolicy) DeepCopy() *BucketPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketQuota) DeepCopyInto(out *BucketQuota) {
 	*out = *in
@@ -409,6 +475,16 @@ func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.BucketPolicy != nil {
+		in, out := &in.BucketPolicy, &out.BucketPolicy
+		*out = new(BucketPolicy)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BucketEncryption)
+		**out = **in
+	}
 	if in.Quota != nil {
 		in, out := &in.Quota, &out.Quota
 		*out = new(BucketQuota)
@@ -431,15 +507,32 @@ func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.CreationDate != nil {
 		in, out := &in.CreationDate, &out.CreationDate
 		*out = (*in).DeepCopy()
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]DriftEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
@@ -457,17 +550,31 @@ func (in *BucketStatus) DeepCopy() *BucketStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Condition) DeepCopyInto(out *Condition) {
+func (in *ClusterAliasReference) DeepCopyInto(out *ClusterAliasReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAliasReference.
+func (in *ClusterAliasReference) DeepCopy() *ClusterAliasReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAliasReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftEntry) DeepCopyInto(out *DriftEntry) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftEntry.
+func (in *DriftEntry) DeepCopy() *DriftEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(Condition)
+	out := new(DriftEntry)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -566,6 +673,45 @@ func (in *EndpointList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointMigration) DeepCopyInto(out *EndpointMigration) {
+	*out = *in
+	if in.AliasName != nil {
+		in, out := &in.AliasName, &out.AliasName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointMigration.
+func (in *EndpointMigration) DeepCopy() *EndpointMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointMigrationStatus) DeepCopyInto(out *EndpointMigrationStatus) {
+	*out = *in
+	if in.MigratedAt != nil {
+		in, out := &in.MigratedAt, &out.MigratedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointMigrationStatus.
+func (in *EndpointMigrationStatus) DeepCopy() *EndpointMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EndpointReference) DeepCopyInto(out *EndpointReference) {
 	*out = *in
@@ -612,6 +758,11 @@ func (in *EndpointSpec) DeepCopyInto(out *EndpointSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(EndpointMigration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSpec.
@@ -629,7 +780,7 @@ func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -642,6 +793,11 @@ func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
 		in, out := &in.ConnectedAt, &out.ConnectedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(EndpointMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
@@ -658,6 +814,21 @@ func (in *EndpointStatus) DeepCopy() *EndpointStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentityProviderReference) DeepCopyInto(out *IdentityProviderReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderReference.
+func (in *IdentityProviderReference) DeepCopy() *IdentityProviderReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentityProviderReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LifecycleExpiration) DeepCopyInto(out *LifecycleExpiration) {
 	*out = *in
@@ -833,7 +1004,7 @@ func (in *LifecyclePolicyStatus) DeepCopyInto(out *LifecyclePolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -846,6 +1017,11 @@ func (in *LifecyclePolicyStatus) DeepCopyInto(out *LifecyclePolicyStatus) {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecyclePolicyStatus.
@@ -937,6 +1113,11 @@ func (in *MinIOConnection) DeepCopyInto(out *MinIOConnection) {
 		*out = new(AliasReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClusterAliasRef != nil {
+		in, out := &in.ClusterAliasRef, &out.ClusterAliasRef
+		*out = new(ClusterAliasReference)
+		**out = **in
+	}
 	if in.EndpointRef != nil {
 		in, out := &in.EndpointRef, &out.EndpointRef
 		*out = new(EndpointReference)
@@ -999,6 +1180,37 @@ func (in *NoncurrentVersionTransition) DeepCopy() *NoncurrentVersionTransition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationTargetReference) DeepCopyInto(out *NotificationTargetReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTargetReference.
+func (in *NotificationTargetReference) DeepCopy() *NotificationTargetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationTargetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenIDClaimTarget) DeepCopyInto(out *OpenIDClaimTarget) {
+	*out = *in
+	out.IdentityProviderRef = in.IdentityProviderRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenIDClaimTarget.
+func (in *OpenIDClaimTarget) DeepCopy() *OpenIDClaimTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenIDClaimTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Policy) DeepCopyInto(out *Policy) {
 	*out = *in
@@ -1107,7 +1319,7 @@ func (in *PolicyAttachmentStatus) DeepCopyInto(out *PolicyAttachmentStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1116,6 +1328,16 @@ func (in *PolicyAttachmentStatus) DeepCopyInto(out *PolicyAttachmentStatus) {
 		in, out := &in.AttachedAt, &out.AttachedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]DriftEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
@@ -1150,6 +1372,21 @@ func (in *PolicyAttachmentTarget) DeepCopyInto(out *PolicyAttachmentTarget) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.LDAPUser != nil {
+		in, out := &in.LDAPUser, &out.LDAPUser
+		*out = new(string)
+		**out = **in
+	}
+	if in.LDAPGroup != nil {
+		in, out := &in.LDAPGroup, &out.LDAPGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.OpenIDClaim != nil {
+		in, out := &in.OpenIDClaim, &out.OpenIDClaim
+		*out = new(OpenIDClaimTarget)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyAttachmentTarget.
@@ -1232,7 +1469,7 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1241,6 +1478,16 @@ func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
 		in, out := &in.CreationDate, &out.CreationDate
 		*out = (*in).DeepCopy()
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]DriftEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
@@ -1404,7 +1651,7 @@ func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1423,6 +1670,16 @@ func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 		in, out := &in.CreationDate, &out.CreationDate
 		*out = (*in).DeepCopy()
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]DriftEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastSyncTime != nil {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds that can be referenced across namespaces with an AliasGrant
+const (
+	// AliasGrantKindAlias grants references to Alias resources
+	AliasGrantKindAlias = "Alias"
+	// AliasGrantKindSecret grants references to credential secrets
+	AliasGrantKindSecret = "Secret"
+	// AliasGrantKindEndpoint grants references to deprecated Endpoint resources
+	AliasGrantKindEndpoint = "Endpoint"
+)
+
+// AliasGrantSpec defines which namespaces may reference resources in the namespace of the AliasGrant
+type AliasGrantSpec struct {
+	// From lists the namespaces whose resources are allowed to reference the resources in To
+	//+kubebuilder:validation:MinItems=1
+	From []AliasGrantFrom `json:"from"`
+
+	// To lists the resources in this namespace that may be referenced
+	//+kubebuilder:validation:MinItems=1
+	To []AliasGrantTo `json:"to"`
+}
+
+// AliasGrantFrom describes a namespace allowed to reference resources in the namespace of the AliasGrant
+type AliasGrantFrom struct {
+	// Namespace is the namespace of the referencing resources
+	//+kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+}
+
+// AliasGrantTo describes resources that may be referenced from other namespaces
+type AliasGrantTo struct {
+	// Kind is the kind of the referenced resource
+	//+kubebuilder:validation:Enum=Alias;Secret;Endpoint
+	Kind string `json:"kind"`
+
+	// Name restricts the grant to a single resource. All resources of the kind may be referenced if it is not set.
+	Name *string `json:"name,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=minioaliasgrant
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// AliasGrant allows resources in other namespaces to reference Aliases and credential secrets in its namespace
+type AliasGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AliasGrantSpec `json:"spec,omitempty"`
+}
+
+// Permits reports whether the grant allows resources in namespace fromNamespace to reference the named resource of kind
+func (in *AliasGrant) Permits(fromNamespace, kind, name string) bool {
+	from := false
+	for _, f := range in.Spec.From {
+		if f.Namespace == fromNamespace {
+			from = true
+			break
+		}
+	}
+	if !from {
+		return false
+	}
+
+	for _, to := range in.Spec.To {
+		if to.Kind == kind && (to.Name == nil || *to.Name == name) {
+			return true
+		}
+	}
+	return false
+}
+
+//+kubebuilder:object:root=true
+
+// AliasGrantList contains a list of AliasGrant
+type AliasGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AliasGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AliasGrant{}, &AliasGrantList{})
+}
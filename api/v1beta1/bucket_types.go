@@ -0,0 +1,248 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BucketFinalizer is the finalizer for Bucket resources
+	BucketFinalizer = "bucket.mc-controller.mxcd.de/finalizer"
+)
+
+// BucketSpec defines the desired state of Bucket
+type BucketSpec struct {
+	// Connection defines connection details to MinIO
+	Connection MinIOConnection `json:"connection"`
+
+	// BucketName is the name of the bucket to create in MinIO
+	BucketName string `json:"bucketName"`
+
+	// Region is the bucket region (optional)
+	Region *string `json:"region,omitempty"`
+
+	// ObjectLocking enables object locking on the bucket
+	ObjectLocking bool `json:"objectLocking,omitempty"`
+
+	// Versioning enables versioning on the bucket
+	Versioning bool `json:"versioning,omitempty"`
+
+	// Retention defines the default retention settings
+	Retention *BucketRetention `json:"retention,omitempty"`
+
+	// Notification defines event notification configuration
+	Notification *BucketNotification `json:"notification,omitempty"`
+
+	// Tags are bucket tags
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Adopt takes over an existing bucket that is not owned by another resource
+	Adopt bool `json:"adopt,omitempty"`
+
+	// ManagementPolicy defines which changes the controller makes to the bucket in MinIO. Defaults to Full.
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// DriftPolicy defines whether drift of the bucket in MinIO is corrected or only reported. Defaults to Correct.
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// BucketPolicy defines the bucket policy granting anonymous access. The bucket policy is not
+	// managed if it is not set.
+	BucketPolicy *BucketPolicy `json:"bucketPolicy,omitempty"`
+
+	// Encryption defines the default server-side encryption of the bucket. The encryption is not
+	// managed if it is not set.
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// Quota defines storage quota for the bucket
+	Quota *BucketQuota `json:"quota,omitempty"`
+}
+
+// BucketRetention defines bucket retention settings
+type BucketRetention struct {
+	// Mode is the retention mode (GOVERNANCE or COMPLIANCE)
+	Mode string `json:"mode"`
+	// RetainUntilDate is the retention date
+	RetainUntilDate *metav1.Time `json:"retainUntilDate,omitempty"`
+	// Years is the retention period in years
+	Years *int `json:"years,omitempty"`
+	// Days is the retention period in days
+	Days *int `json:"days,omitempty"`
+}
+
+// BucketNotification defines bucket notification configuration
+type BucketNotification struct {
+	// Events is a list of events to notify on
+	Events []string `json:"events"`
+	// FilterPrefix is the object key name prefix
+	FilterPrefix *string `json:"filterPrefix,omitempty"`
+	// FilterSuffix is the object key name suffix
+	FilterSuffix *string `json:"filterSuffix,omitempty"`
+	// Topic is the notification target topic ARN
+	Topic *string `json:"topic,omitempty"`
+	// Queue is the notification target queue ARN
+	Queue *string `json:"queue,omitempty"`
+	// LambdaFunction is the notification target lambda function ARN
+	LambdaFunction *string `json:"lambdaFunction,omitempty"`
+	// TargetRef references a NotificationTarget in the namespace of the bucket whose ARN events are published to
+	TargetRef *NotificationTargetReference `json:"targetRef,omitempty"`
+}
+
+// NotificationTargetReference references a NotificationTarget resource
+type NotificationTargetReference struct {
+	// Name is the name of the NotificationTarget resource
+	Name string `json:"name"`
+}
+
+// Bucket policy presets for anonymous access, matching those of "mc anonymous set"
+const (
+	// BucketPolicyPresetNone removes the bucket policy
+	BucketPolicyPresetNone = "none"
+	// BucketPolicyPresetDownload allows anonymous downloads
+	BucketPolicyPresetDownload = "download"
+	// BucketPolicyPresetUpload allows anonymous uploads
+	BucketPolicyPresetUpload = "upload"
+	// BucketPolicyPresetPublic allows anonymous downloads and uploads
+	BucketPolicyPresetPublic = "public"
+)
+
+// BucketPolicy defines a bucket policy, either as a preset or as a raw policy document
+type BucketPolicy struct {
+	// Preset is a predefined anonymous access policy
+	//+kubebuilder:validation:Enum=none;download;upload;public
+	Preset string `json:"preset,omitempty"`
+	// Prefix limits the anonymous access of the preset to objects with the key prefix
+	Prefix string `json:"prefix,omitempty"`
+	// Policy is a raw bucket policy document in JSON format (alternative to Preset)
+	Policy string `json:"policy,omitempty"`
+}
+
+// Bucket encryption types
+const (
+	// BucketEncryptionNone removes the default encryption
+	BucketEncryptionNone = "None"
+	// BucketEncryptionSSES3 encrypts objects with keys managed by the server
+	BucketEncryptionSSES3 = "SSE-S3"
+	// BucketEncryptionSSEKMS encrypts objects with a key of the KMS
+	BucketEncryptionSSEKMS = "SSE-KMS"
+)
+
+// BucketEncryption defines the default server-side encryption of a bucket
+type BucketEncryption struct {
+	// Type is the encryption type
+	//+kubebuilder:validation:Enum=None;SSE-S3;SSE-KMS
+	Type string `json:"type"`
+	// KMSKeyID is the KMS key used for SSE-KMS. The default key of the KMS is used if it is not set.
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+}
+
+// BucketQuota defines bucket storage quota
+type BucketQuota struct {
+	// Hard is the hard quota limit in bytes
+	Hard *int64 `json:"hard,omitempty"`
+}
+
+// BucketStatus defines the observed state of Bucket
+type BucketStatus struct {
+	// Conditions represent the latest available observations of the bucket's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates if the bucket is ready
+	Ready bool `json:"ready"`
+
+	// BucketName is the actual bucket name in MinIO
+	BucketName string `json:"bucketName,omitempty"`
+
+	// Region is the bucket region
+	Region string `json:"region,omitempty"`
+
+	// Versioning is the live versioning status of the bucket (Enabled or Suspended)
+	Versioning string `json:"versioning,omitempty"`
+
+	// ObjectLocking indicates if object locking is enabled on the bucket
+	ObjectLocking bool `json:"objectLocking,omitempty"`
+
+	// Tags are the live bucket tags, without the owner tag
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// BucketPolicyHash is the hash of the live bucket policy, empty if the bucket has no policy
+	BucketPolicyHash string `json:"bucketPolicyHash,omitempty"`
+
+	// EncryptionAlgorithm is the live default encryption algorithm, e.g. AES256 or aws:kms
+	EncryptionAlgorithm string `json:"encryptionAlgorithm,omitempty"`
+
+	// KMSKeyID is the live KMS key of the default encryption
+	KMSKeyID string `json:"kmsKeyID,omitempty"`
+
+	// CreationDate is when the bucket was created
+	CreationDate *metav1.Time `json:"creationDate,omitempty"`
+
+	// Drift lists the differences between the desired and the live state that were not corrected
+	Drift []DriftEntry `json:"drift,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=bucket
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Bucket Name",type="string",JSONPath=".status.bucketName"
+//+kubebuilder:printcolumn:name="Region",type="string",JSONPath=".status.region"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Bucket is the Schema for the buckets API
+type Bucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketSpec   `json:"spec,omitempty"`
+	Status BucketStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the Bucket
+func (in *Bucket) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the Bucket
+func (in *Bucket) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// BucketList contains a list of Bucket
+type BucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Bucket `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Bucket{}, &BucketList{})
+}
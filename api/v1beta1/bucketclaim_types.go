@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BucketAccess defines the access the credentials of a BucketClaim get to its bucket
+// +kubebuilder:validation:Enum=ReadOnly;ReadWrite;Admin
+type BucketAccess string
+
+const (
+	// BucketAccessReadOnly allows listing the bucket and reading its objects
+	BucketAccessReadOnly BucketAccess = "ReadOnly"
+	// BucketAccessReadWrite additionally allows writing and deleting objects
+	BucketAccessReadWrite BucketAccess = "ReadWrite"
+	// BucketAccessAdmin allows all S3 actions on the bucket and its objects
+	BucketAccessAdmin BucketAccess = "Admin"
+)
+
+// BucketClaimDeletionPolicy defines what happens to the bucket in MinIO when its BucketClaim is deleted
+// +kubebuilder:validation:Enum=Delete;Retain
+type BucketClaimDeletionPolicy string
+
+const (
+	// BucketClaimDeletionPolicyDelete removes the bucket from MinIO with the claim
+	BucketClaimDeletionPolicyDelete BucketClaimDeletionPolicy = "Delete"
+	// BucketClaimDeletionPolicyRetain keeps the bucket in MinIO when the claim is deleted
+	BucketClaimDeletionPolicyRetain BucketClaimDeletionPolicy = "Retain"
+)
+
+// Keys of the connection secret of a BucketClaim. The access keys use DefaultAccessKeyIDKey and
+// DefaultSecretAccessKeyKey, so the secret can be referenced by connections and injected into pods.
+const (
+	// ConnectionSecretEndpointKey holds the URL of the MinIO server
+	ConnectionSecretEndpointKey = "endpoint"
+	// ConnectionSecretRegionKey holds the region of the MinIO server
+	ConnectionSecretRegionKey = "region"
+	// ConnectionSecretBucketKey holds the name of the bucket
+	ConnectionSecretBucketKey = "bucketName"
+	// ConnectionSecretCABundleKey holds the CA bundle of the MinIO server, if the connection has one
+	ConnectionSecretCABundleKey = "ca.crt"
+)
+
+// BucketClaimSpec defines the desired state of BucketClaim
+type BucketClaimSpec struct {
+	// Connection defines connection details to MinIO
+	Connection MinIOConnection `json:"connection"`
+
+	// BucketName is the name of the bucket to create in MinIO. Defaults to <namespace>-<name>.
+	BucketName string `json:"bucketName,omitempty"`
+
+	// Access is the access the credentials get to the bucket. Defaults to ReadWrite.
+	Access BucketAccess `json:"access,omitempty"`
+
+	// Username is the MinIO user holding the credentials. Defaults to the bucket name.
+	Username string `json:"username,omitempty"`
+
+	// Versioning enables versioning on the bucket
+	Versioning bool `json:"versioning,omitempty"`
+
+	// ConnectionSecretName is the secret the endpoint, region, bucket name, keys and CA bundle are
+	// written to. Defaults to <name>-connection.
+	ConnectionSecretName string `json:"connectionSecretName,omitempty"`
+
+	// DeletionPolicy defines whether the bucket is removed from MinIO when the claim is deleted. The user
+	// and policy are always removed. Defaults to Delete.
+	DeletionPolicy BucketClaimDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// BucketClaimStatus defines the observed state of BucketClaim
+type BucketClaimStatus struct {
+	// Conditions represent the latest available observations of the claim's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates if the bucket, policy and user of the claim are ready
+	Ready bool `json:"ready"`
+
+	// BucketName is the name of the bucket in MinIO
+	BucketName string `json:"bucketName,omitempty"`
+
+	// PolicyName is the name of the canned policy granting access to the bucket
+	PolicyName string `json:"policyName,omitempty"`
+
+	// Username is the name of the MinIO user holding the credentials
+	Username string `json:"username,omitempty"`
+
+	// ConnectionSecretName is the name of the secret holding the connection details
+	ConnectionSecretName string `json:"connectionSecretName,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=bc
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Bucket",type="string",JSONPath=".status.bucketName"
+//+kubebuilder:printcolumn:name="Access",type="string",JSONPath=".spec.access"
+//+kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".status.connectionSecretName",priority=1
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BucketClaim is the Schema for the bucketclaims API. It creates a Bucket, a Policy granting access to it,
+// a User with that policy and a connection secret, all owned by the claim.
+type BucketClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketClaimSpec   `json:"spec,omitempty"`
+	Status BucketClaimStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the BucketClaim
+func (in *BucketClaim) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the BucketClaim
+func (in *BucketClaim) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// BucketClaimList contains a list of BucketClaim
+type BucketClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BucketClaim{}, &BucketClaimList{})
+}
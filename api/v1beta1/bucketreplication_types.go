@@ -0,0 +1,195 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BucketReplicationFinalizer is the finalizer for BucketReplication resources
+	BucketReplicationFinalizer = "bucketreplication.mc-controller.mxcd.de/finalizer"
+)
+
+// BucketReplicationSpec defines the desired state of BucketReplication
+type BucketReplicationSpec struct {
+	// BucketRef references the source Bucket in the namespace of the BucketReplication. The source
+	// bucket is reached through the connection of the Bucket.
+	BucketRef BucketReference `json:"bucketRef"`
+
+	// Destination defines the MinIO server and bucket the objects are replicated to
+	Destination ReplicationDestination `json:"destination"`
+
+	// Rules define which objects are replicated
+	//+kubebuilder:validation:MinItems=1
+	Rules []ReplicationRule `json:"rules"`
+}
+
+// BucketReference references a Bucket resource in the same namespace
+type BucketReference struct {
+	// Name is the name of the Bucket resource
+	Name string `json:"name"`
+}
+
+// ReplicationDestination defines the target of a bucket replication
+type ReplicationDestination struct {
+	// Connection defines connection details to the destination MinIO. Its credentials are
+	// used by the source server to replicate objects.
+	Connection MinIOConnection `json:"connection"`
+
+	// BucketName is the name of the destination bucket, which must exist and have versioning enabled
+	BucketName string `json:"bucketName"`
+
+	// Region is the region of the destination bucket
+	Region string `json:"region,omitempty"`
+
+	// BandwidthLimit limits the replication bandwidth in bytes per second
+	BandwidthLimit *int64 `json:"bandwidthLimit,omitempty"`
+
+	// Synchronous replicates objects before the upload to the source bucket completes
+	Synchronous bool `json:"synchronous,omitempty"`
+}
+
+// ReplicationRuleStatus defines the status of a replication rule
+type ReplicationRuleStatus string
+
+const (
+	// ReplicationRuleStatusEnabled indicates the rule is enabled
+	ReplicationRuleStatusEnabled ReplicationRuleStatus = "Enabled"
+	// ReplicationRuleStatusDisabled indicates the rule is disabled
+	ReplicationRuleStatusDisabled ReplicationRuleStatus = "Disabled"
+)
+
+// ReplicationRule defines a single replication rule
+type ReplicationRule struct {
+	// ID is the unique identifier for the rule within the source bucket
+	//+kubebuilder:validation:MaxLength=255
+	ID string `json:"id"`
+
+	// Status indicates whether the rule is enabled or disabled
+	//+kubebuilder:validation:Enum=Enabled;Disabled
+	//+kubebuilder:default=Enabled
+	Status ReplicationRuleStatus `json:"status,omitempty"`
+
+	// Priority decides between overlapping rules, higher priorities win
+	//+kubebuilder:validation:Minimum=0
+	Priority int `json:"priority,omitempty"`
+
+	// Prefix limits the rule to objects with the key prefix
+	Prefix string `json:"prefix,omitempty"`
+
+	// Tags limits the rule to objects with all of the tags
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// DeleteMarkerReplication replicates delete markers
+	DeleteMarkerReplication bool `json:"deleteMarkerReplication,omitempty"`
+
+	// DeleteReplication replicates versioned deletes
+	DeleteReplication bool `json:"deleteReplication,omitempty"`
+
+	// ExistingObjectReplication replicates objects created before the rule
+	ExistingObjectReplication bool `json:"existingObjectReplication,omitempty"`
+
+	// StorageClass is the storage class of the replicated objects
+	StorageClass string `json:"storageClass,omitempty"`
+}
+
+// BucketReplicationStatus defines the observed state of BucketReplication
+type BucketReplicationStatus struct {
+	// Conditions represent the latest available observations of the bucket replication's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates if the bucket replication is ready
+	Ready bool `json:"ready"`
+
+	// SourceBucket is the name of the source bucket in MinIO
+	SourceBucket string `json:"sourceBucket,omitempty"`
+
+	// DestinationBucket is the name of the destination bucket in MinIO
+	DestinationBucket string `json:"destinationBucket,omitempty"`
+
+	// RemoteTargetARN is the ARN of the remote target on the source server
+	RemoteTargetARN string `json:"remoteTargetARN,omitempty"`
+
+	// TargetOnline indicates whether the source server reaches the destination
+	TargetOnline bool `json:"targetOnline,omitempty"`
+
+	// ReplicatedBytes is the size of the objects replicated to the destination
+	ReplicatedBytes int64 `json:"replicatedBytes,omitempty"`
+
+	// PendingObjects is the number of objects waiting for replication
+	PendingObjects int64 `json:"pendingObjects,omitempty"`
+
+	// PendingBytes is the size of the objects waiting for replication
+	PendingBytes int64 `json:"pendingBytes,omitempty"`
+
+	// FailedObjects is the number of objects that failed to replicate
+	FailedObjects int64 `json:"failedObjects,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=replication
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Source",type="string",JSONPath=".status.sourceBucket"
+//+kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".status.destinationBucket"
+//+kubebuilder:printcolumn:name="Pending",type="integer",JSONPath=".status.pendingObjects"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BucketReplication is the Schema for the bucketreplications API
+type BucketReplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketReplicationSpec   `json:"spec,omitempty"`
+	Status BucketReplicationStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the BucketReplication
+func (in *BucketReplication) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the BucketReplication
+func (in *BucketReplication) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// BucketReplicationList contains a list of BucketReplication
+type BucketReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketReplication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BucketReplication{}, &BucketReplicationList{})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterAliasFinalizer is the finalizer for ClusterAlias resources
+	ClusterAliasFinalizer = "clusteralias.mc-controller.mxcd.de/finalizer"
+)
+
+// ClusterAliasSpec defines the desired state of ClusterAlias
+type ClusterAliasSpec struct {
+	// URL is the MinIO server URL
+	URL string `json:"url"`
+
+	// SecretRef contains credentials for connecting to MinIO. The secret is read from the operator namespace.
+	// It is required unless credentials come from a web identity token or the AWS credential chain.
+	// +optional
+	SecretRef ClusterSecretReference `json:"secretRef,omitempty"`
+
+	// Credentials selects how the alias obtains its credentials, defaults to the static keys of SecretRef
+	Credentials *AliasCredentials `json:"credentials,omitempty"`
+
+	// TLS configuration
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// HealthCheck defines health check settings
+	HealthCheck *AliasHealthCheck `json:"healthCheck,omitempty"`
+
+	// Region is the default region for this alias
+	Region *string `json:"region,omitempty"`
+
+	// PathStyle forces the use of path-style addressing
+	PathStyle bool `json:"pathStyle,omitempty"`
+
+	// Description is a human-readable description of the alias
+	Description *string `json:"description,omitempty"`
+
+	// Tags are alias tags
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// NamespaceSelector selects the namespaces whose resources may use this alias.
+	// No namespace may use the alias if it is not set, an empty selector selects all namespaces.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// ClusterSecretReference references a secret in the operator namespace containing MinIO credentials
+type ClusterSecretReference struct {
+	// Name is the name of the secret
+	Name string `json:"name"`
+	// AccessKeyIDKey is the key in the secret containing the access key ID
+	AccessKeyIDKey string `json:"accessKeyIDKey,omitempty"`
+	// SecretAccessKeyKey is the key in the secret containing the secret access key
+	SecretAccessKeyKey string `json:"secretAccessKeyKey,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:scope=Cluster,shortName=minioclusteralias
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+//+kubebuilder:printcolumn:name="Healthy",type="boolean",JSONPath=".status.healthy"
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterAlias is the Schema for the clusteraliases API
+type ClusterAlias struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterAliasSpec `json:"spec,omitempty"`
+	Status AliasStatus      `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the ClusterAlias
+func (in *ClusterAlias) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the ClusterAlias
+func (in *ClusterAlias) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterAliasList contains a list of ClusterAlias
+type ClusterAliasList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterAlias `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterAlias{}, &ClusterAliasList{})
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// MinIOConnection defines connection details to a MinIO instance
+type MinIOConnection struct {
+	// AliasRef references an Alias resource for connection details
+	AliasRef *AliasReference `json:"aliasRef,omitempty"`
+	// ClusterAliasRef references a cluster-scoped ClusterAlias resource for connection details
+	ClusterAliasRef *ClusterAliasReference `json:"clusterAliasRef,omitempty"`
+	// URL is the MinIO server URL (alternative to AliasRef/ClusterAliasRef)
+	URL *string `json:"url,omitempty"`
+	// SecretRef contains credentials for connecting to MinIO (only used with URL)
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+	// TLS configuration (only used with URL)
+	TLS *TLSConfig `json:"tls,omitempty"`
+}
+
+// AliasReference references an Alias resource
+type AliasReference struct {
+	// Name is the name of the Alias resource
+	Name string `json:"name"`
+	// Namespace is the namespace of the Alias resource
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// ClusterAliasReference references a ClusterAlias resource
+type ClusterAliasReference struct {
+	// Name is the name of the ClusterAlias resource
+	Name string `json:"name"`
+}
+
+// SecretReference contains the reference to a secret containing MinIO credentials
+type SecretReference struct {
+	// Name is the name of the secret
+	Name string `json:"name"`
+	// Namespace is the namespace of the secret
+	Namespace *string `json:"namespace,omitempty"`
+	// AccessKeyIDKey is the key in the secret containing the access key ID
+	AccessKeyIDKey string `json:"accessKeyIDKey,omitempty"`
+	// SecretAccessKeyKey is the key in the secret containing the secret access key
+	SecretAccessKeyKey string `json:"secretAccessKeyKey,omitempty"`
+}
+
+// SecretKeySelector selects a single key of a secret
+type SecretKeySelector struct {
+	// Name is the name of the secret
+	Name string `json:"name"`
+	// Namespace is the namespace of the secret
+	Namespace *string `json:"namespace,omitempty"`
+	// Key is the key in the secret containing the value
+	Key string `json:"key"`
+}
+
+// ConfigSecretValue sets a MinIO configuration key to a value read from a secret
+type ConfigSecretValue struct {
+	// Key is the MinIO configuration key, e.g. auth_token
+	Key string `json:"key"`
+	// SecretKeyRef selects the secret key holding the value
+	SecretKeyRef SecretKeySelector `json:"secretKeyRef"`
+}
+
+// Default keys of the values in credential secrets
+const (
+	// DefaultAccessKeyIDKey is the default secret key containing the access key ID
+	DefaultAccessKeyIDKey = "accessKeyID"
+	// DefaultSecretAccessKeyKey is the default secret key containing the secret access key
+	DefaultSecretAccessKeyKey = "secretAccessKey"
+	// DefaultPasswordKey is the default secret key containing a user's password
+	DefaultPasswordKey = "password"
+	// DefaultCredentialsKey is the default secret key containing the GCS credentials JSON of a tier
+	DefaultCredentialsKey = "credentials.json"
+)
+
+// TLSConfig defines TLS configuration for MinIO connection
+type TLSConfig struct {
+	// Insecure allows connections to MinIO using TLS without certs validation
+	Insecure bool `json:"insecure,omitempty"`
+	// CABundle is a PEM encoded CA bundle which will be used to validate the server certificate
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// CredentialSource selects how an alias obtains the credentials it authenticates with
+// +kubebuilder:validation:Enum=Secret;WebIdentity;AssumeRole;AWS
+type CredentialSource string
+
+const (
+	// CredentialSourceSecret authenticates with the static keys of the secret reference
+	CredentialSourceSecret CredentialSource = "Secret"
+	// CredentialSourceWebIdentity exchanges the controller's projected service account token for temporary
+	// credentials with AssumeRoleWithWebIdentity
+	CredentialSourceWebIdentity CredentialSource = "WebIdentity"
+	// CredentialSourceAssumeRole exchanges the (less privileged) keys of the secret reference for temporary
+	// credentials with AssumeRole
+	CredentialSourceAssumeRole CredentialSource = "AssumeRole"
+	// CredentialSourceAWS uses the AWS credential chain: environment, shared credentials file and IAM, which
+	// includes IRSA web identity tokens and instance metadata
+	CredentialSourceAWS CredentialSource = "AWS"
+)
+
+// DefaultWebIdentityTokenPath is where the controller expects its projected service account token
+const DefaultWebIdentityTokenPath = "/var/run/secrets/mc-controller/sts/token"
+
+// AliasCredentials configures how an alias obtains its credentials. Temporary credentials are refreshed
+// before they expire.
+type AliasCredentials struct {
+	// Source selects where the credentials come from. WebIdentity and AWS authenticate as the operator pod and
+	// are only permitted for namespaced Aliases if the operator allows it.
+	// +kubebuilder:default=Secret
+	Source CredentialSource `json:"source,omitempty"`
+
+	// TokenPath is the file holding the web identity token, used with the WebIdentity source.
+	// Defaults to /var/run/secrets/mc-controller/sts/token. Only ClusterAliases may set another path.
+	TokenPath string `json:"tokenPath,omitempty"`
+
+	// RoleARN is the role to assume with the WebIdentity and AssumeRole sources
+	RoleARN string `json:"roleArn,omitempty"`
+
+	// DurationSeconds is the requested lifetime of temporary credentials
+	// +kubebuilder:validation:Minimum=900
+	// +kubebuilder:validation:Maximum=43200
+	DurationSeconds *int32 `json:"durationSeconds,omitempty"`
+
+	// STSEndpoint is the URL of the STS service, defaults to the alias URL. Namespaced Aliases only send the web
+	// identity token to the host of their URL or to hosts allowed by the operator.
+	STSEndpoint *string `json:"stsEndpoint,omitempty"`
+}
+
+// Condition types follow the kstatus conventions so that tools like Argo CD and Flux
+// can assess the health of the resources.
+const (
+	// ConditionReady indicates the resource is ready
+	ConditionReady = "Ready"
+	// ConditionReconciling indicates the controller is working towards the desired state
+	ConditionReconciling = "Reconciling"
+	// ConditionStalled indicates the controller hit an error and cannot make progress
+	ConditionStalled = "Stalled"
+	// ConditionConflict indicates the MinIO resource is owned by another resource or by no resource at all
+	ConditionConflict = "Conflict"
+	// ConditionDrifted indicates the live state in MinIO differs from the desired state
+	ConditionDrifted = "Drifted"
+	// ConditionPaused indicates reconciliation is suspended by the paused annotation
+	ConditionPaused = "Paused"
+)
+
+// Markers stamped into MinIO to record the UID of the resource that manages a bucket, user or policy
+const (
+	// OwnerTag is the bucket tag holding the UID of the owning Bucket
+	OwnerTag = "mc-controller.mxcd.de/owner"
+	// OwnerGroupPrefix is the prefix of the group holding the UID of the owning User. MinIO users have no
+	// metadata, so the owner is recorded as membership in the group "<prefix><uid>".
+	OwnerGroupPrefix = "mc-controller-owner-"
+	// OwnerPolicyIDPrefix is the prefix of the ID of a canned policy document holding the UID of the owning Policy
+	OwnerPolicyIDPrefix = "mc-controller.mxcd.de/owner="
+)
+
+const (
+	// PausedAnnotation suspends reconciliation of a resource when set to "true". On an Alias or
+	// ClusterAlias it suspends all resources connecting through it.
+	PausedAnnotation = "mc-controller.mxcd.de/paused"
+	// DryRunAnnotation makes the controller only plan the MinIO changes of a resource when set to "true".
+	// The planned changes are reported in the status and as events.
+	DryRunAnnotation = "mc-controller.mxcd.de/dry-run"
+)
+
+// Label and annotations of pods that get the connection of a bucket injected by the pod webhook
+const (
+	// InjectLabel opts a pod into the pod webhook when set to "true". Pods without it are not sent to
+	// the webhook, neither are pods in the namespace of the controller.
+	InjectLabel = "mc-controller.mxcd.de/inject"
+	// InjectBucketAnnotation names a Bucket in the pod's namespace whose endpoint, region, bucket name and CA
+	// bundle are injected into the containers
+	InjectBucketAnnotation = "mc-controller.mxcd.de/inject-bucket"
+	// InjectCredentialsAnnotation names a secret in the pod's namespace whose accessKeyID and secretAccessKey
+	// keys are injected into the containers as secret references
+	InjectCredentialsAnnotation = "mc-controller.mxcd.de/inject-credentials"
+	// InjectAccessKeyIDKeyAnnotation overrides the key of the credentials secret injected as AWS_ACCESS_KEY_ID
+	InjectAccessKeyIDKeyAnnotation = "mc-controller.mxcd.de/inject-access-key-id-key"
+	// InjectSecretAccessKeyKeyAnnotation overrides the key of the credentials secret injected as AWS_SECRET_ACCESS_KEY
+	InjectSecretAccessKeyKeyAnnotation = "mc-controller.mxcd.de/inject-secret-access-key-key"
+	// InjectContainersAnnotation restricts the injection to a comma separated list of containers and init
+	// containers. All containers are injected by default.
+	InjectContainersAnnotation = "mc-controller.mxcd.de/inject-containers"
+	// InjectedCABundleAnnotation holds the CA bundle of the injected bucket's connection, which is mounted
+	// into the containers through the downward API
+	InjectedCABundleAnnotation = "mc-controller.mxcd.de/ca-bundle"
+)
+
+// DriftPolicy defines how differences between the desired and the live state in MinIO are handled
+// +kubebuilder:validation:Enum=Correct;Report
+type DriftPolicy string
+
+const (
+	// DriftPolicyCorrect restores the desired state in MinIO
+	DriftPolicyCorrect DriftPolicy = "Correct"
+	// DriftPolicyReport only reports drift in the status
+	DriftPolicyReport DriftPolicy = "Report"
+)
+
+// DriftEntry is a difference between the desired state of a resource and the live state in MinIO
+type DriftEntry struct {
+	// Field is the drifted setting, e.g. "versioning" or "tags.team"
+	Field string `json:"field"`
+	// Desired is the value defined by the spec
+	Desired string `json:"desired,omitempty"`
+	// Actual is the live value in MinIO
+	Actual string `json:"actual,omitempty"`
+}
+
+// ManagementPolicy defines which changes the controller makes to the MinIO resource of a resource
+// +kubebuilder:validation:Enum=Observe;CreateOnly;Full;FullNoDelete
+type ManagementPolicy string
+
+const (
+	// ManagementPolicyObserve only reports the live state of an existing MinIO resource
+	ManagementPolicyObserve ManagementPolicy = "Observe"
+	// ManagementPolicyCreateOnly creates a missing MinIO resource but never updates or deletes it
+	ManagementPolicyCreateOnly ManagementPolicy = "CreateOnly"
+	// ManagementPolicyFull creates, updates and deletes the MinIO resource
+	ManagementPolicyFull ManagementPolicy = "Full"
+	// ManagementPolicyFullNoDelete creates and updates the MinIO resource but keeps it on deletion
+	ManagementPolicyFullNoDelete ManagementPolicy = "FullNoDelete"
+)
+
+// Creates reports whether a missing MinIO resource is created. An empty policy is Full.
+func (p ManagementPolicy) Creates() bool {
+	return p != ManagementPolicyObserve
+}
+
+// Updates reports whether an existing MinIO resource is updated to the desired state
+func (p ManagementPolicy) Updates() bool {
+	return p == "" || p == ManagementPolicyFull || p == ManagementPolicyFullNoDelete
+}
+
+// Deletes reports whether the MinIO resource is deleted together with the resource
+func (p ManagementPolicy) Deletes() bool {
+	return p == "" || p == ManagementPolicyFull
+}
+
+// Annotations preserving v1alpha1 fields that have no v1beta1 equivalent. They are set by the
+// conversion from v1alpha1 and picked up by the controllers until the resources are migrated.
+const (
+	// LegacyEndpointRefAnnotation holds the deprecated endpointRef of a connection as "namespace/name" or "name"
+	LegacyEndpointRefAnnotation = "mc-controller.mxcd.de/v1alpha1-endpoint-ref"
+	// LegacyPasswordAnnotation holds the plaintext password of a v1alpha1 User until it is moved into a secret
+	LegacyPasswordAnnotation = "mc-controller.mxcd.de/v1alpha1-password"
+)
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IdentityProviderFinalizer is the finalizer for IdentityProvider resources
+	IdentityProviderFinalizer = "identityprovider.mc-controller.mxcd.de/finalizer"
+
+	// DefaultIdentityProviderConfigName is the name of the default configuration of an identity provider type
+	DefaultIdentityProviderConfigName = "_"
+)
+
+// IdentityProviderType defines the protocol of an external identity provider
+type IdentityProviderType string
+
+const (
+	// IdentityProviderTypeOpenID authenticates STS requests with tokens of an OpenID Connect provider
+	IdentityProviderTypeOpenID IdentityProviderType = "openid"
+	// IdentityProviderTypeLDAP authenticates STS requests with credentials of an LDAP directory
+	IdentityProviderTypeLDAP IdentityProviderType = "ldap"
+)
+
+// IdentityProviderSpec defines the desired state of IdentityProvider
+type IdentityProviderSpec struct {
+	// Connection defines connection details to MinIO
+	Connection MinIOConnection `json:"connection"`
+
+	// Type is the protocol of the identity provider
+	//+kubebuilder:validation:Enum=openid;ldap
+	Type IdentityProviderType `json:"type"`
+
+	// ConfigName is the name of the configuration in MinIO, like with "mc idp openid add <alias> <name>".
+	// Defaults to the default configuration. MinIO supports a single LDAP configuration only.
+	//+kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	ConfigName string `json:"configName,omitempty"`
+
+	// Config are the configuration keys of the identity provider as accepted by "mc idp <type> add", e.g.
+	// config_url, client_id and claim_name for OpenID or server_addr and lookup_bind_dn for LDAP
+	Config map[string]string `json:"config"`
+
+	// SecretConfig are configuration keys whose values are read from secrets, e.g. client_secret or
+	// lookup_bind_password
+	SecretConfig []ConfigSecretValue `json:"secretConfig,omitempty"`
+
+	// RestartPolicy defines whether the MinIO server is restarted when the configuration change requires it.
+	// Defaults to IfRequired.
+	//+kubebuilder:validation:Enum=IfRequired;Never
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// Adopt takes over an existing configuration with the same name that was not created by the controller
+	Adopt bool `json:"adopt,omitempty"`
+}
+
+// IdentityProviderStatus defines the observed state of IdentityProvider
+type IdentityProviderStatus struct {
+	// Conditions represent the latest available observations of the identity provider's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates if the identity provider is configured and active on the MinIO server
+	Ready bool `json:"ready"`
+
+	// ConfigName is the name of the configuration in MinIO
+	ConfigName string `json:"configName,omitempty"`
+
+	// RoleARN is the ARN of the role policy that clients pass to AssumeRoleWithWebIdentity, if the
+	// OpenID configuration sets role_policy
+	RoleARN string `json:"roleARN,omitempty"`
+
+	// CreationDate is when the configuration was created by the controller
+	CreationDate *metav1.Time `json:"creationDate,omitempty"`
+
+	// SecretsHash is the SHA-256 digest of the secret values last applied to the configuration
+	SecretsHash string `json:"secretsHash,omitempty"`
+
+	// RestartRequired indicates that the MinIO server must be restarted to activate the configuration
+	RestartRequired bool `json:"restartRequired,omitempty"`
+
+	// RestartRequiredSince is when the configuration change requiring a restart was written
+	RestartRequiredSince *metav1.Time `json:"restartRequiredSince,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=idp
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="Role ARN",type="string",JSONPath=".status.roleARN",priority=1
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// IdentityProvider is the Schema for the identityproviders API
+type IdentityProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IdentityProviderSpec   `json:"spec,omitempty"`
+	Status IdentityProviderStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the IdentityProvider
+func (in *IdentityProvider) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the IdentityProvider
+func (in *IdentityProvider) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// IdentityProviderList contains a list of IdentityProvider
+type IdentityProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IdentityProvider `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IdentityProvider{}, &IdentityProviderList{})
+}
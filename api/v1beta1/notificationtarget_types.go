@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NotificationTargetFinalizer is the finalizer for NotificationTarget resources
+	NotificationTargetFinalizer = "notificationtarget.mc-controller.mxcd.de/finalizer"
+)
+
+// NotificationTargetType defines the service bucket events are published to
+type NotificationTargetType string
+
+const (
+	// NotificationTargetTypeWebhook posts events to an HTTP endpoint
+	NotificationTargetTypeWebhook NotificationTargetType = "webhook"
+	// NotificationTargetTypeKafka publishes events to a Kafka topic
+	NotificationTargetTypeKafka NotificationTargetType = "kafka"
+	// NotificationTargetTypeNATS publishes events to a NATS subject
+	NotificationTargetTypeNATS NotificationTargetType = "nats"
+	// NotificationTargetTypeAMQP publishes events to an AMQP exchange
+	NotificationTargetTypeAMQP NotificationTargetType = "amqp"
+	// NotificationTargetTypeRedis stores events in a Redis key
+	NotificationTargetTypeRedis NotificationTargetType = "redis"
+	// NotificationTargetTypePostgres stores events in a PostgreSQL table
+	NotificationTargetTypePostgres NotificationTargetType = "postgres"
+)
+
+// RestartPolicy defines whether the MinIO server is restarted to activate configuration changes
+type RestartPolicy string
+
+const (
+	// RestartPolicyIfRequired restarts the MinIO server when a configuration change requires it
+	RestartPolicyIfRequired RestartPolicy = "IfRequired"
+	// RestartPolicyNever only reports that a restart is required
+	RestartPolicyNever RestartPolicy = "Never"
+)
+
+// NotificationTargetSpec defines the desired state of NotificationTarget
+type NotificationTargetSpec struct {
+	// Connection defines connection details to MinIO
+	Connection MinIOConnection `json:"connection"`
+
+	// Type is the service events are published to
+	//+kubebuilder:validation:Enum=webhook;kafka;nats;amqp;redis;postgres
+	Type NotificationTargetType `json:"type"`
+
+	// TargetName is the identifier of the target in the MinIO configuration and its ARN
+	//+kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	TargetName string `json:"targetName"`
+
+	// Config are the configuration keys of the target as accepted by "mc admin config set", e.g.
+	// endpoint for webhooks or brokers and topic for Kafka
+	Config map[string]string `json:"config,omitempty"`
+
+	// SecretConfig are configuration keys whose values are read from secrets, e.g. auth_token or password
+	SecretConfig []ConfigSecretValue `json:"secretConfig,omitempty"`
+
+	// RestartPolicy defines whether the MinIO server is restarted when the configuration change requires it.
+	// Defaults to IfRequired.
+	//+kubebuilder:validation:Enum=IfRequired;Never
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// Adopt takes over an existing target with the same name that was not created by the controller
+	Adopt bool `json:"adopt,omitempty"`
+}
+
+// NotificationTargetStatus defines the observed state of NotificationTarget
+type NotificationTargetStatus struct {
+	// Conditions represent the latest available observations of the target's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates if the target is active on the MinIO server
+	Ready bool `json:"ready"`
+
+	// ARN is the ARN Buckets publish their events to
+	ARN string `json:"arn,omitempty"`
+
+	// CreationDate is when the target was created by the controller
+	CreationDate *metav1.Time `json:"creationDate,omitempty"`
+
+	// SecretsHash is the SHA-256 digest of the secret values last applied to the target
+	SecretsHash string `json:"secretsHash,omitempty"`
+
+	// RestartRequired indicates that the MinIO server must be restarted to activate the target
+	RestartRequired bool `json:"restartRequired,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=notiftarget
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="ARN",type="string",JSONPath=".status.arn"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// NotificationTarget is the Schema for the notificationtargets API
+type NotificationTarget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NotificationTargetSpec   `json:"spec,omitempty"`
+	Status NotificationTargetStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the NotificationTarget
+func (in *NotificationTarget) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the NotificationTarget
+func (in *NotificationTarget) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// NotificationTargetList contains a list of NotificationTarget
+type NotificationTargetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationTarget `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NotificationTarget{}, &NotificationTargetList{})
+}
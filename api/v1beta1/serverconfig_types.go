@@ -0,0 +1,144 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ServerConfigFinalizer is the finalizer for ServerConfig resources
+	ServerConfigFinalizer = "serverconfig.mc-controller.mxcd.de/finalizer"
+)
+
+// ServerConfigSpec defines the desired state of ServerConfig
+type ServerConfigSpec struct {
+	// Connection defines connection details to MinIO
+	Connection MinIOConnection `json:"connection"`
+
+	// Config maps configuration subsystems, optionally qualified with a target like audit_webhook:splunk,
+	// to the keys and values set with "mc admin config set", e.g. api: {requests_max: "1600"}
+	//+kubebuilder:validation:MinProperties=1
+	Config map[string]map[string]string `json:"config"`
+
+	// SecretConfig maps configuration subsystems to keys whose values are read from secrets
+	SecretConfig map[string][]ConfigSecretValue `json:"secretConfig,omitempty"`
+
+	// RestartPolicy defines whether the MinIO server is restarted when applied settings require it.
+	// Defaults to Never, which only reports the settings waiting for a restart.
+	//+kubebuilder:validation:Enum=IfRequired;Never
+	RestartPolicy RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// MaintenanceWindow limits restarts to a recurring time window. Without it, the server is restarted right away.
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// ResetOnDelete resets the configured keys to their defaults when the ServerConfig is deleted.
+	// The settings are kept by default.
+	ResetOnDelete bool `json:"resetOnDelete,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring time window for disruptive operations
+type MaintenanceWindow struct {
+	// Days are the weekdays the window opens on, every day if empty
+	//+kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	Days []string `json:"days,omitempty"`
+
+	// Start is the time of day the window opens, in HH:MM
+	//+kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of the start time. Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ServerConfigStatus defines the observed state of ServerConfig
+type ServerConfigStatus struct {
+	// Conditions represent the latest available observations of the server config's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates if all settings are applied and active
+	Ready bool `json:"ready"`
+
+	// SecretsHash is the SHA-256 digest of the secret values last applied
+	SecretsHash string `json:"secretsHash,omitempty"`
+
+	// RestartRequired indicates that applied settings only take effect after a restart of the MinIO server
+	RestartRequired bool `json:"restartRequired,omitempty"`
+
+	// PendingRestart lists the settings waiting for a restart, as "subsystem key"
+	PendingRestart []string `json:"pendingRestart,omitempty"`
+
+	// RestartRequiredSince is when the first of the pending settings was applied
+	RestartRequiredSince *metav1.Time `json:"restartRequiredSince,omitempty"`
+
+	// LastRestartTime is the last time the controller restarted the MinIO server
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=minioconfig
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Restart Required",type="boolean",JSONPath=".status.restartRequired"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ServerConfig is the Schema for the serverconfigs API
+type ServerConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServerConfigSpec   `json:"spec,omitempty"`
+	Status ServerConfigStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the ServerConfig
+func (in *ServerConfig) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the ServerConfig
+func (in *ServerConfig) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// ServerConfigList contains a list of ServerConfig
+type ServerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServerConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServerConfig{}, &ServerConfigList{})
+}
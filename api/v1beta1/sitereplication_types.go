@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SiteReplicationFinalizer is the finalizer for SiteReplication resources
+	SiteReplicationFinalizer = "sitereplication.mc-controller.mxcd.de/finalizer"
+)
+
+// SiteReplicationSpec defines the desired state of SiteReplication
+type SiteReplicationSpec struct {
+	// Sites lists the MinIO deployments that replicate buckets, objects and IAM with each other.
+	// Each site is reached through an Alias or a ClusterAlias, whose credentials are shared with
+	// the other sites.
+	//+kubebuilder:validation:MinItems=2
+	Sites []ReplicationSite `json:"sites"`
+
+	// ReplicateILMExpiry replicates the expiry rules of lifecycle configurations between the sites
+	ReplicateILMExpiry bool `json:"replicateILMExpiry,omitempty"`
+}
+
+// ReplicationSite defines a MinIO deployment taking part in site replication
+type ReplicationSite struct {
+	// Name identifies the site within the site replication
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// AliasRef references the Alias of the site. The namespace must be set.
+	AliasRef *AliasReference `json:"aliasRef,omitempty"`
+
+	// ClusterAliasRef references the ClusterAlias of the site
+	ClusterAliasRef *ClusterAliasReference `json:"clusterAliasRef,omitempty"`
+
+	// Endpoint is the URL the other sites use to reach this site. Defaults to the URL of the alias.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// ReplicationSiteStatus defines the observed state of a site
+type ReplicationSiteStatus struct {
+	// Name is the name of the site
+	Name string `json:"name"`
+
+	// Endpoint is the URL the other sites use to reach this site
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// DeploymentID is the immutable identifier of the MinIO deployment
+	DeploymentID string `json:"deploymentID,omitempty"`
+
+	// SyncState is the replication mode of the site, enable for synchronous replication
+	SyncState string `json:"syncState,omitempty"`
+
+	// InSync indicates that the site knows all other sites and has no pending metadata
+	InSync bool `json:"inSync"`
+
+	// PendingIAM is the number of users, groups, policies and policy mappings not yet replicated to or from the site
+	PendingIAM int `json:"pendingIAM,omitempty"`
+
+	// PendingBucketMetadata is the number of buckets and bucket configurations not yet replicated to or from the site
+	PendingBucketMetadata int `json:"pendingBucketMetadata,omitempty"`
+}
+
+// SiteReplicationStatus defines the observed state of SiteReplication
+type SiteReplicationStatus struct {
+	// Conditions represent the latest available observations of the site replication's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates that all sites replicate with each other and agree on the replicated metadata
+	Ready bool `json:"ready"`
+
+	// Sites reports the state of each site
+	Sites []ReplicationSiteStatus `json:"sites,omitempty"`
+
+	// SitesInSync is the number of sites that agree with all other sites
+	SitesInSync int `json:"sitesInSync,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:scope=Cluster,shortName=siterepl
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="In Sync",type="integer",JSONPath=".status.sitesInSync"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SiteReplication is the Schema for the sitereplications API
+type SiteReplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SiteReplicationSpec   `json:"spec,omitempty"`
+	Status SiteReplicationStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the SiteReplication
+func (in *SiteReplication) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the SiteReplication
+func (in *SiteReplication) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// SiteReplicationList contains a list of SiteReplication
+type SiteReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SiteReplication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SiteReplication{}, &SiteReplicationList{})
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantNamespacePlaceholder is replaced with the namespace of a resource in the prefixes of a TenantPolicy
+const TenantNamespacePlaceholder = "{namespace}"
+
+// TenantPolicySpec defines the restrictions for the resources in the namespaces a TenantPolicy applies to
+type TenantPolicySpec struct {
+	// NamespaceSelector selects the namespaces the policy applies to. The policy applies to all namespaces if it is not set.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// BucketPrefix is the prefix bucket names must start with. "{namespace}" is replaced with the namespace of the Bucket.
+	// Policies may then only allow S3 actions on buckets starting with the prefix.
+	BucketPrefix string `json:"bucketPrefix,omitempty"`
+
+	// UsernamePrefix is the prefix usernames must start with. "{namespace}" is replaced with the namespace of the User.
+	// The groups Users join and PolicyAttachments target must start with the prefix as well.
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+
+	// AllowedAliases restricts the aliases connections may use. Connections are not restricted if it is empty,
+	// otherwise connections must reference one of the listed aliases.
+	AllowedAliases []TenantAliasReference `json:"allowedAliases,omitempty"`
+
+	// MaxBuckets is the maximum number of Buckets per namespace
+	//+kubebuilder:validation:Minimum=0
+	MaxBuckets *int32 `json:"maxBuckets,omitempty"`
+
+	// MaxUsers is the maximum number of Users per namespace
+	//+kubebuilder:validation:Minimum=0
+	MaxUsers *int32 `json:"maxUsers,omitempty"`
+
+	// MaxPolicies is the maximum number of Policies per namespace
+	//+kubebuilder:validation:Minimum=0
+	MaxPolicies *int32 `json:"maxPolicies,omitempty"`
+}
+
+// TenantAliasReference references an Alias or ClusterAlias that tenants may use
+type TenantAliasReference struct {
+	// Kind is the kind of the alias
+	//+kubebuilder:validation:Enum=Alias;ClusterAlias
+	//+kubebuilder:default=Alias
+	Kind string `json:"kind,omitempty"`
+
+	// Name is the name of the alias
+	Name string `json:"name"`
+
+	// Namespace is the namespace of an Alias. It defaults to the namespace of the referencing resource.
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:resource:scope=Cluster,shortName=miniotenantpolicy
+//+kubebuilder:printcolumn:name="Bucket Prefix",type="string",JSONPath=".spec.bucketPrefix"
+//+kubebuilder:printcolumn:name="Username Prefix",type="string",JSONPath=".spec.usernamePrefix"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// TenantPolicy restricts the MinIO resources that can be created in a set of namespaces
+type TenantPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec TenantPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TenantPolicyList contains a list of TenantPolicy
+type TenantPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantPolicy{}, &TenantPolicyList{})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TierFinalizer is the finalizer for Tier resources
+	TierFinalizer = "tier.mc-controller.mxcd.de/finalizer"
+)
+
+// TierType defines the backend of a remote tier
+type TierType string
+
+const (
+	// TierTypeMinIO stores transitioned objects on another MinIO server
+	TierTypeMinIO TierType = "minio"
+	// TierTypeS3 stores transitioned objects on AWS S3 or an S3 compatible service
+	TierTypeS3 TierType = "s3"
+	// TierTypeAzure stores transitioned objects in Azure Blob Storage
+	TierTypeAzure TierType = "azure"
+	// TierTypeGCS stores transitioned objects in Google Cloud Storage
+	TierTypeGCS TierType = "gcs"
+)
+
+// TierSpec defines the desired state of Tier
+type TierSpec struct {
+	// Connection defines connection details to MinIO
+	Connection MinIOConnection `json:"connection"`
+
+	// TierName is the name of the tier in MinIO. Lifecycle transitions reference it as storage class.
+	//+kubebuilder:validation:Pattern=`^[A-Z0-9_-]+$`
+	TierName string `json:"tierName"`
+
+	// Type is the backend of the remote tier
+	//+kubebuilder:validation:Enum=minio;s3;azure;gcs
+	Type TierType `json:"type"`
+
+	// Endpoint is the URL of the remote storage. It is required for MinIO and defaults to the
+	// public endpoint of the cloud provider otherwise.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Bucket is the remote bucket, or Azure container, objects are transitioned to
+	Bucket string `json:"bucket"`
+
+	// Prefix is the object prefix in the remote bucket
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region of the remote bucket
+	Region string `json:"region,omitempty"`
+
+	// StorageClass is the storage class of transitioned objects on S3, Azure and GCS
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// CredentialsSecretRef references the Secret holding the credentials of the remote storage.
+	// Changes of the credentials are applied to the tier.
+	CredentialsSecretRef TierCredentialsReference `json:"credentialsSecretRef"`
+
+	// Adopt takes over an existing tier with the same name that was not created by the controller
+	Adopt bool `json:"adopt,omitempty"`
+}
+
+// TierCredentialsReference references the Secret holding the credentials of a remote tier
+type TierCredentialsReference struct {
+	// Name is the name of the secret
+	Name string `json:"name"`
+	// Namespace is the namespace of the secret
+	Namespace *string `json:"namespace,omitempty"`
+	// AccessKeyIDKey is the key in the secret containing the access key ID, or the account name for Azure
+	AccessKeyIDKey string `json:"accessKeyIDKey,omitempty"`
+	// SecretAccessKeyKey is the key in the secret containing the secret access key, or the account key for Azure
+	SecretAccessKeyKey string `json:"secretAccessKeyKey,omitempty"`
+	// CredentialsKey is the key in the secret containing the service account credentials JSON for GCS
+	CredentialsKey string `json:"credentialsKey,omitempty"`
+}
+
+// TierStatus defines the observed state of Tier
+type TierStatus struct {
+	// Conditions represent the latest available observations of the tier's state
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Ready indicates if the tier is ready
+	Ready bool `json:"ready"`
+
+	// TierName is the name of the tier in MinIO
+	TierName string `json:"tierName,omitempty"`
+
+	// CreationDate is when the tier was created by the controller
+	CreationDate *metav1.Time `json:"creationDate,omitempty"`
+
+	// CredentialsHash is the SHA-256 digest of the credentials last applied to the tier
+	CredentialsHash string `json:"credentialsHash,omitempty"`
+
+	// Objects is the number of objects transitioned to the tier
+	Objects int64 `json:"objects,omitempty"`
+
+	// Versions is the number of object versions transitioned to the tier
+	Versions int64 `json:"versions,omitempty"`
+
+	// Size is the size of the objects transitioned to the tier in bytes
+	Size int64 `json:"size,omitempty"`
+
+	// PlannedChanges lists the MinIO changes a dry run would make
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+
+	// LastSyncTime is the last time the resource was synchronized
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the most recent generation observed by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=miniotier
+//+kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+//+kubebuilder:printcolumn:name="Tier",type="string",JSONPath=".spec.tierName"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="Objects",type="integer",JSONPath=".status.objects"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Tier is the Schema for the tiers API
+type Tier struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TierSpec   `json:"spec,omitempty"`
+	Status TierStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the status conditions of the Tier
+func (in *Tier) GetConditions() []metav1.Condition {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the status conditions of the Tier
+func (in *Tier) SetConditions(conditions []metav1.Condition) {
+	in.Status.Conditions = conditions
+}
+
+//+kubebuilder:object:root=true
+
+// TierList contains a list of Tier
+type TierList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tier `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Tier{}, &TierList{})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command cosi-driver serves the COSI driver of mc-controller next to the COSI provisioner sidecar.
+package main
+
+import (
+	"flag"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-entrypoint and run can make use of them.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/cosi"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(miniov1beta1.AddToScheme(scheme))
+}
+
+func main() {
+	var driverName string
+	var endpoint string
+	var operatorNamespace string
+	flag.StringVar(&driverName, "driver-name", cosi.DefaultDriverName,
+		"The name BucketClasses and BucketAccessClasses reference the driver with.")
+	flag.StringVar(&endpoint, "cosi-endpoint", cosi.DefaultEndpoint,
+		"The unix:// socket or tcp:// address the provisioner sidecar connects to.")
+	flag.StringVar(&operatorNamespace, "operator-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace the operator runs in. Secrets referenced by ClusterAliases are read from it. "+
+			"Defaults to the POD_NAMESPACE environment variable.")
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if operatorNamespace != "" {
+		minioclient.OperatorNamespace = operatorNamespace
+	}
+
+	k8sClient, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+
+	driver := &cosi.Driver{
+		Name:   driverName,
+		Client: k8sClient,
+	}
+
+	ctx := log.IntoContext(ctrl.SetupSignalHandler(), ctrl.Log.WithName("cosi"))
+	if err := cosi.Serve(ctx, endpoint, driver); err != nil {
+		setupLog.Error(err, "problem running COSI driver")
+		os.Exit(1)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -31,11 +32,19 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 	"github.com/mxcd/mc-controller/internal/controller"
+	"github.com/mxcd/mc-controller/internal/metrics"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
+	webhookcorev1 "github.com/mxcd/mc-controller/internal/webhook/v1"
+	webhookv1alpha1 "github.com/mxcd/mc-controller/internal/webhook/v1alpha1"
+	webhookv1beta1 "github.com/mxcd/mc-controller/internal/webhook/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -48,6 +57,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(miniov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(miniov1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -55,10 +65,16 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var webhookPort int
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var operatorNamespace string
+	var dryRun bool
+	var allowNamespacedAmbientCredentials bool
+	var allowedSTSHosts string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the admission webhook server binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -66,6 +82,16 @@ func main() {
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&operatorNamespace, "operator-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace the operator runs in. Secrets referenced by ClusterAliases are read from it. "+
+			"Defaults to the POD_NAMESPACE environment variable.")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If set, MinIO changes are only planned and reported in the status and as events of the resources.")
+	flag.BoolVar(&allowNamespacedAmbientCredentials, "allow-namespaced-ambient-credentials", false,
+		"If set, namespaced Aliases may use WebIdentity and AWS credentials, which authenticate with the identity "+
+			"of the operator pod. By default only ClusterAliases may use them.")
+	flag.StringVar(&allowedSTSHosts, "allowed-sts-hosts", "",
+		"Comma-separated hosts namespaced Aliases may send the web identity token to besides the host of their URL.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -74,6 +100,14 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if operatorNamespace != "" {
+		minioclient.OperatorNamespace = operatorNamespace
+	}
+	minioclient.AllowNamespacedAmbientCredentials = allowNamespacedAmbientCredentials
+	if allowedSTSHosts != "" {
+		minioclient.AllowedSTSHosts = strings.Split(allowedSTSHosts, ",")
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancelation and
@@ -91,6 +125,7 @@ func main() {
 	}
 
 	webhookServer := webhook.NewServer(webhook.Options{
+		Port:    webhookPort,
 		TLSOpts: tlsOpts,
 	})
 
@@ -122,16 +157,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	ctx := ctrl.SetupSignalHandler()
+
+	// The tenant checks of controllers and webhooks find claims of the same bucket or policy by index
+	if err = tenant.SetupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to set up field indexes")
+		os.Exit(1)
+	}
+
 	if err = (&controller.BucketReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Bucket")
 		os.Exit(1)
 	}
 	if err = (&controller.UserReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "User")
 		os.Exit(1)
@@ -150,22 +197,175 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Alias")
 		os.Exit(1)
 	}
-	if err = (&controller.PolicyReconciler{
+	if err = (&controller.ClusterAliasReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterAlias")
+		os.Exit(1)
+	}
+	if err = (&controller.PolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Policy")
 		os.Exit(1)
 	}
 	if err = (&controller.PolicyAttachmentReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PolicyAttachment")
+		os.Exit(1)
+	}
+	if err = (&controller.BucketReplicationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BucketReplication")
+		os.Exit(1)
+	}
+	if err = (&controller.SiteReplicationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SiteReplication")
+		os.Exit(1)
+	}
+	if err = (&controller.TierReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Tier")
+		os.Exit(1)
+	}
+	if err = (&controller.LifecyclePolicyReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LifecyclePolicy")
+		os.Exit(1)
+	}
+	if err = (&controller.NotificationTargetReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NotificationTarget")
+		os.Exit(1)
+	}
+	if err = (&controller.ServerConfigReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ServerConfig")
+		os.Exit(1)
+	}
+	if err = (&controller.IdentityProviderReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("mc-controller"),
+		DryRun:   dryRun,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IdentityProvider")
+		os.Exit(1)
+	}
+	if err = (&controller.BucketClaimReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "PolicyAttachment")
+		setupLog.Error(err, "unable to create controller", "controller", "BucketClaim")
 		os.Exit(1)
 	}
+	// Webhooks are disabled with ENABLE_WEBHOOKS=false, e.g. when running the manager locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookv1beta1.SetupAliasWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Alias")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupClusterAliasWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterAlias")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupEndpointWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Endpoint")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupBucketWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Bucket")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupUserWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "User")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Policy")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupPolicyAttachmentWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PolicyAttachment")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupLifecyclePolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "LifecyclePolicy")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupBucketReplicationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BucketReplication")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupSiteReplicationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SiteReplication")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupTierWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Tier")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupNotificationTargetWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "NotificationTarget")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupServerConfigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ServerConfig")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupIdentityProviderWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IdentityProvider")
+			os.Exit(1)
+		}
+		if err = webhookv1beta1.SetupBucketClaimWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BucketClaim")
+			os.Exit(1)
+		}
+		if err = webhookcorev1.SetupPodWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Pod")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if err := ctrlmetrics.Registry.Register(metrics.NewResourceCollector(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to register resource metrics collector")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -176,7 +376,7 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
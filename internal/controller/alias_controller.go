@@ -29,7 +29,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/metrics"
 	minioclient "github.com/mxcd/mc-controller/internal/minio"
 )
 
@@ -50,7 +51,7 @@ func (r *AliasReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	logger := log.FromContext(ctx)
 
 	// Fetch the Alias instance
-	alias := &miniov1alpha1.Alias{}
+	alias := &miniov1beta1.Alias{}
 	err := r.Get(ctx, req.NamespacedName, alias)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -61,44 +62,46 @@ func (r *AliasReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, err
 	}
 
+	// Paused resources are left untouched, including their deletion
+	if paused, result, err := checkPaused(ctx, r.Client, alias, nil); paused {
+		return result, err
+	}
+
 	// Handle deletion
 	if alias.DeletionTimestamp != nil {
 		return r.handleDeletion(ctx, alias)
 	}
 
 	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(alias, miniov1alpha1.AliasFinalizer) {
-		controllerutil.AddFinalizer(alias, miniov1alpha1.AliasFinalizer)
+	if !controllerutil.ContainsFinalizer(alias, miniov1beta1.AliasFinalizer) {
+		controllerutil.AddFinalizer(alias, miniov1beta1.AliasFinalizer)
 		return ctrl.Result{}, r.Update(ctx, alias)
 	}
 
 	// Update status to indicate reconciliation is in progress
-	miniov1alpha1.SetCondition(&alias.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionTrue, "Reconciling", "Reconciling alias")
-	alias.Status.ObservedGeneration = alias.Generation
-	if err := r.Status().Update(ctx, alias); err != nil {
-		if apierrors.IsConflict(err) {
-			// Resource was modified, requeue to try again
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+	patch := client.MergeFrom(alias.DeepCopy())
+	if markReconciling(alias, "Reconciling alias") {
+		alias.Status.ObservedGeneration = alias.Generation
+		if err := r.Status().Patch(ctx, alias, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
 		}
-		logger.Error(err, "Failed to update status")
-		return ctrl.Result{}, err
 	}
 
-	// Create a temporary connection config for health checking
-	conn := miniov1alpha1.MinIOConnection{
-		URL:       &alias.Spec.URL,
-		SecretRef: &alias.Spec.SecretRef,
-		TLS:       alias.Spec.TLS,
-	}
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(alias.DeepCopy())
 
 	// Create MinIO client for health checking
-	minioClient, err := minioclient.NewClient(ctx, r.Client, conn, alias.Namespace)
+	minioClient, err := minioclient.NewAliasClient(ctx, r.Client, alias)
 	if err != nil {
 		logger.Error(err, "Failed to create MinIO client")
-		miniov1alpha1.SetCondition(&alias.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ClientError", fmt.Sprintf("Failed to create MinIO client: %v", err))
+		markFailed(alias, errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err))
 		alias.Status.Ready = false
 		alias.Status.Healthy = false
-		r.Status().Update(ctx, alias)
+		metrics.SetAliasHealth(alias.Namespace, alias.Name, false, "")
+		if err := r.Status().Patch(ctx, alias, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
@@ -106,25 +109,23 @@ func (r *AliasReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	result, err := r.reconcileAlias(ctx, alias, minioClient)
 	if err != nil {
 		logger.Error(err, "Failed to reconcile alias")
-		miniov1alpha1.SetCondition(&alias.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ReconcileError", fmt.Sprintf("Failed to reconcile alias: %v", err))
+		markFailed(alias, reasonReconcileError, fmt.Sprintf("Failed to reconcile alias: %v", err))
 		alias.Status.Ready = false
 		alias.Status.Healthy = false
 		alias.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-		r.Status().Update(ctx, alias)
+		if err := r.Status().Patch(ctx, alias, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return result, err
 	}
 
 	// Update status to ready
-	miniov1alpha1.SetCondition(&alias.Status.Conditions, miniov1alpha1.ConditionReady, metav1.ConditionTrue, "Ready", "Alias is ready")
-	miniov1alpha1.SetCondition(&alias.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionFalse, "Ready", "Alias reconciliation completed")
+	markReady(alias, "Alias is ready")
 	alias.Status.Ready = true
 	alias.Status.URL = alias.Spec.URL
 	alias.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
 
-	if err := r.Status().Update(ctx, alias); err != nil {
-		if apierrors.IsConflict(err) {
-			return ctrl.Result{RequeueAfter: time.Second}, nil
-		}
+	if err := r.Status().Patch(ctx, alias, patch); err != nil {
 		logger.Error(err, "Failed to update status to ready")
 		return ctrl.Result{}, err
 	}
@@ -133,14 +134,15 @@ func (r *AliasReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 }
 
 // handleDeletion handles the deletion of an Alias resource
-func (r *AliasReconciler) handleDeletion(ctx context.Context, alias *miniov1alpha1.Alias) (ctrl.Result, error) {
+func (r *AliasReconciler) handleDeletion(ctx context.Context, alias *miniov1beta1.Alias) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	if controllerutil.ContainsFinalizer(alias, miniov1alpha1.AliasFinalizer) {
+	if controllerutil.ContainsFinalizer(alias, miniov1beta1.AliasFinalizer) {
 		// For aliases, we don't need to do any cleanup in MinIO
 		// Just remove the finalizer
 		logger.Info("Alias being deleted", "url", alias.Spec.URL)
-		controllerutil.RemoveFinalizer(alias, miniov1alpha1.AliasFinalizer)
+		metrics.DeleteAlias(alias.Namespace, alias.Name)
+		controllerutil.RemoveFinalizer(alias, miniov1beta1.AliasFinalizer)
 		return ctrl.Result{}, r.Update(ctx, alias)
 	}
 
@@ -148,7 +150,7 @@ func (r *AliasReconciler) handleDeletion(ctx context.Context, alias *miniov1alph
 }
 
 // reconcileAlias reconciles the alias state
-func (r *AliasReconciler) reconcileAlias(ctx context.Context, alias *miniov1alpha1.Alias, minioClient *minioclient.Client) (ctrl.Result, error) {
+func (r *AliasReconciler) reconcileAlias(ctx context.Context, alias *miniov1beta1.Alias, minioClient *minioclient.Client) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	// Perform health check
@@ -159,6 +161,7 @@ func (r *AliasReconciler) reconcileAlias(ctx context.Context, alias *miniov1alph
 	if err != nil {
 		logger.Error(err, "Health check failed")
 		alias.Status.Healthy = false
+		metrics.SetAliasHealth(alias.Namespace, alias.Name, false, "")
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
@@ -182,6 +185,8 @@ func (r *AliasReconciler) reconcileAlias(ctx context.Context, alias *miniov1alph
 		}
 	}
 
+	metrics.SetAliasHealth(alias.Namespace, alias.Name, true, alias.Status.Version)
+
 	// Calculate next health check interval
 	interval := time.Minute * 5 // Default 5 minutes
 	if alias.Spec.HealthCheck != nil && alias.Spec.HealthCheck.Enabled {
@@ -197,6 +202,6 @@ func (r *AliasReconciler) reconcileAlias(ctx context.Context, alias *miniov1alph
 // SetupWithManager sets up the controller with the Manager.
 func (r *AliasReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&miniov1alpha1.Alias{}).
+		For(&miniov1beta1.Alias{}).
 		Complete(r)
 }
@@ -19,32 +19,44 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"time"
 
 	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/notification"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/metrics"
 	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
 )
 
 // BucketReconciler reconciles a Bucket object
 type BucketReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all buckets
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=buckets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=buckets/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=buckets/finalizers,verbs=update
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=endpoints,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=notificationtargets,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -53,7 +65,7 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	logger := log.FromContext(ctx)
 
 	// Fetch the Bucket instance
-	bucket := &miniov1alpha1.Bucket{}
+	bucket := &miniov1beta1.Bucket{}
 	err := r.Get(ctx, req.NamespacedName, bucket)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -64,54 +76,85 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	// Resources paused by their own annotation or by their alias are left untouched, including their deletion
+	if paused, result, err := checkPaused(ctx, r.Client, bucket, &bucket.Spec.Connection); paused {
+		return result, err
+	}
+
 	// Handle deletion
 	if bucket.DeletionTimestamp != nil {
 		return r.handleDeletion(ctx, bucket)
 	}
 
 	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(bucket, miniov1alpha1.BucketFinalizer) {
-		controllerutil.AddFinalizer(bucket, miniov1alpha1.BucketFinalizer)
+	if !controllerutil.ContainsFinalizer(bucket, miniov1beta1.BucketFinalizer) {
+		controllerutil.AddFinalizer(bucket, miniov1beta1.BucketFinalizer)
 		return ctrl.Result{}, r.Update(ctx, bucket)
 	}
 
 	// Update status to indicate reconciliation is in progress
-	miniov1alpha1.SetCondition(&bucket.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionTrue, "Reconciling", "Reconciling bucket")
-	bucket.Status.ObservedGeneration = bucket.Generation
-	if err := r.Status().Update(ctx, bucket); err != nil {
-		logger.Error(err, "Failed to update status")
-		return ctrl.Result{}, err
+	patch := client.MergeFrom(bucket.DeepCopy())
+	if markReconciling(bucket, "Reconciling bucket") {
+		bucket.Status.ObservedGeneration = bucket.Generation
+		if err := r.Status().Patch(ctx, bucket, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(bucket.DeepCopy())
+
+	// Enforce the tenant policies of the namespace
+	if err := tenant.Check(ctx, r.Client, bucket); err != nil {
+		logger.Error(err, "Bucket is not allowed by the tenant policies")
+		markFailed(bucket, errorReason(err, reasonReconcileError), err.Error())
+		bucket.Status.Ready = false
+		if err := r.Status().Patch(ctx, bucket, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Create MinIO client
-	minioClient, err := minioclient.NewClient(ctx, r.Client, bucket.Spec.Connection, bucket.Namespace)
+	minioClient, err := newMinIOClient(ctx, r.Client, bucket, bucket.Spec.Connection)
 	if err != nil {
 		logger.Error(err, "Failed to create MinIO client")
-		miniov1alpha1.SetCondition(&bucket.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ClientError", fmt.Sprintf("Failed to create MinIO client: %v", err))
+		markFailed(bucket, errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err))
 		bucket.Status.Ready = false
-		r.Status().Update(ctx, bucket)
+		if err := r.Status().Patch(ctx, bucket, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Reconcile the bucket
-	result, err := r.reconcileBucket(ctx, bucket, minioClient)
+	plan := newChangePlan(r.DryRun, bucket)
+	result, err := r.reconcileBucket(ctx, bucket, minioClient, plan)
 	if err != nil {
 		logger.Error(err, "Failed to reconcile bucket")
-		miniov1alpha1.SetCondition(&bucket.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ReconcileError", fmt.Sprintf("Failed to reconcile bucket: %v", err))
+		markFailed(bucket, errorReason(err, reasonReconcileError), fmt.Sprintf("Failed to reconcile bucket: %v", err))
 		bucket.Status.Ready = false
 		bucket.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-		r.Status().Update(ctx, bucket)
+		if err := r.Status().Patch(ctx, bucket, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return result, err
 	}
 
-	// Update status to ready
-	miniov1alpha1.SetCondition(&bucket.Status.Conditions, miniov1alpha1.ConditionReady, metav1.ConditionTrue, "Ready", "Bucket is ready")
-	miniov1alpha1.SetCondition(&bucket.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionFalse, "Ready", "Bucket reconciliation completed")
-	bucket.Status.Ready = true
+	// Update status to ready, unless a dry run left changes unapplied
+	bucket.Status.PlannedChanges = plan.planned()
+	if plan.pending() {
+		plan.report(r.Recorder, bucket)
+		bucket.Status.Ready = false
+	} else {
+		markReady(bucket, "Bucket is ready")
+		bucket.Status.Ready = true
+	}
 	bucket.Status.BucketName = bucket.Spec.BucketName
 	bucket.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
 
-	if err := r.Status().Update(ctx, bucket); err != nil {
+	if err := r.Status().Patch(ctx, bucket, patch); err != nil {
 		logger.Error(err, "Failed to update status to ready")
 		return ctrl.Result{}, err
 	}
@@ -120,12 +163,32 @@ func (r *BucketReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 }
 
 // handleDeletion handles the deletion of a Bucket resource
-func (r *BucketReconciler) handleDeletion(ctx context.Context, bucket *miniov1alpha1.Bucket) (ctrl.Result, error) {
+func (r *BucketReconciler) handleDeletion(ctx context.Context, bucket *miniov1beta1.Bucket) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	if controllerutil.ContainsFinalizer(bucket, miniov1alpha1.BucketFinalizer) {
+	if controllerutil.ContainsFinalizer(bucket, miniov1beta1.BucketFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, bucket)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if violation {
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+			controllerutil.RemoveFinalizer(bucket, miniov1beta1.BucketFinalizer)
+			return ctrl.Result{}, r.Update(ctx, bucket)
+		}
+
+		// Only the Full management policy deletes the bucket in MinIO
+		if !bucket.Spec.ManagementPolicy.Deletes() {
+			logger.Info("Keeping bucket as required by the management policy", "bucketName", bucket.Spec.BucketName, "managementPolicy", bucket.Spec.ManagementPolicy)
+			metrics.DeleteBucket(bucket.Namespace, bucket.Name)
+			controllerutil.RemoveFinalizer(bucket, miniov1beta1.BucketFinalizer)
+			return ctrl.Result{}, r.Update(ctx, bucket)
+		}
+
 		// Create MinIO client for cleanup
-		minioClient, err := minioclient.NewClient(ctx, r.Client, bucket.Spec.Connection, bucket.Namespace)
+		minioClient, err := newMinIOClient(ctx, r.Client, bucket, bucket.Spec.Connection)
 		if err != nil {
 			logger.Error(err, "Failed to create MinIO client for deletion")
 			// If we can't connect to MinIO, we'll remove the finalizer anyway after a grace period
@@ -140,25 +203,49 @@ func (r *BucketReconciler) handleDeletion(ctx context.Context, bucket *miniov1al
 			return ctrl.Result{RequeueAfter: time.Minute}, nil
 		}
 
+		if exists {
+			owner, _, err := bucketOwner(ctx, minioClient, bucket.Spec.BucketName)
+			if err != nil {
+				logger.Error(err, "Failed to get bucket owner during deletion")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if !ownedBy(bucket, owner, bucket.Status.CreationDate != nil) {
+				// Never remove the data of a bucket managed by another resource
+				logger.Info("Bucket is not owned by this resource, skipping deletion", "bucketName", bucket.Spec.BucketName, "owner", owner)
+				exists = false
+			}
+		}
+
+		plan := newChangePlan(r.DryRun, bucket)
 		if exists {
 			// Remove all objects from bucket first
-			err = r.emptyBucket(ctx, minioClient, bucket.Spec.BucketName)
+			err = plan.apply(fmt.Sprintf("remove all objects of bucket %s", bucket.Spec.BucketName), func() error {
+				return r.emptyBucket(ctx, minioClient, bucket.Spec.BucketName)
+			})
 			if err != nil {
 				logger.Error(err, "Failed to empty bucket during deletion")
 				return ctrl.Result{RequeueAfter: time.Minute}, nil
 			}
 
 			// Remove the bucket
-			err = minioClient.S3.RemoveBucket(ctx, bucket.Spec.BucketName)
+			err = plan.apply(fmt.Sprintf("remove bucket %s", bucket.Spec.BucketName), func() error {
+				return minioClient.S3.RemoveBucket(ctx, bucket.Spec.BucketName)
+			})
 			if err != nil {
 				logger.Error(err, "Failed to delete bucket")
 				return ctrl.Result{RequeueAfter: time.Minute}, nil
 			}
+		}
+		if plan.pending() {
+			return reportPlannedDeletion(ctx, r.Client, r.Recorder, bucket, &bucket.Status.PlannedChanges, plan)
+		}
+		if exists {
 			logger.Info("Bucket deleted successfully", "bucketName", bucket.Spec.BucketName)
 		}
+		metrics.DeleteBucket(bucket.Namespace, bucket.Name)
 
 		// Remove the finalizer
-		controllerutil.RemoveFinalizer(bucket, miniov1alpha1.BucketFinalizer)
+		controllerutil.RemoveFinalizer(bucket, miniov1beta1.BucketFinalizer)
 		return ctrl.Result{}, r.Update(ctx, bucket)
 	}
 
@@ -186,7 +273,7 @@ func (r *BucketReconciler) emptyBucket(ctx context.Context, minioClient *miniocl
 }
 
 // reconcileBucket reconciles the bucket state
-func (r *BucketReconciler) reconcileBucket(ctx context.Context, bucket *miniov1alpha1.Bucket, minioClient *minioclient.Client) (ctrl.Result, error) {
+func (r *BucketReconciler) reconcileBucket(ctx context.Context, bucket *miniov1beta1.Bucket, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	// Check if bucket exists
@@ -195,52 +282,321 @@ func (r *BucketReconciler) reconcileBucket(ctx context.Context, bucket *miniov1a
 		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to check bucket existence: %w", err)
 	}
 
-	if !exists {
+	// Resolve the desired bucket policy, which is only managed if it is set
+	policyDocument, desiredPolicyHash := "", ""
+	if bucket.Spec.BucketPolicy != nil {
+		policyDocument, err = bucketPolicyDocument(bucket.Spec.BucketName, bucket.Spec.BucketPolicy)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		desiredPolicyHash, err = bucketPolicyDigest(policyDocument)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("invalid bucket policy: %w", err)
+		}
+	}
+
+	managementPolicy := bucket.Spec.ManagementPolicy
+	switch {
+	case !exists && !managementPolicy.Creates():
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("bucket %s does not exist and is not created with management policy %s", bucket.Spec.BucketName, managementPolicy)
+	case exists && !correctsDrift(managementPolicy, bucket.Spec.DriftPolicy):
+		// Existing buckets are left untouched, only their live state and drift are reported
+		if err := r.observeBucket(ctx, bucket, minioClient); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		reportDrift(bucket, "Bucket", &bucket.Status.Drift, bucketDrift(bucket, desiredPolicyHash), false)
+		r.recordUsage(ctx, bucket, minioClient)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	owner := ""
+	var drift []miniov1beta1.DriftEntry
+	if exists {
+		// Only manage buckets owned by this resource
+		owner, _, err = bucketOwner(ctx, minioClient, bucket.Spec.BucketName)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		if err := checkOwner(bucket, "bucket", bucket.Spec.BucketName, owner, bucket.Spec.Adopt, bucket.Status.CreationDate != nil); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		if err := r.observeBucket(ctx, bucket, minioClient); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		// Differences to an unchanged spec have been made outside of the controller
+		if specSynced(bucket) {
+			drift = bucketDrift(bucket, desiredPolicyHash)
+		}
+	} else {
 		// Create the bucket
 		opts := minio.MakeBucketOptions{
 			ObjectLocking: bucket.Spec.ObjectLocking,
 		}
 		if bucket.Spec.Region != nil {
 			opts.Region = *bucket.Spec.Region
-			bucket.Status.Region = *bucket.Spec.Region
 		}
 
-		err = minioClient.S3.MakeBucket(ctx, bucket.Spec.BucketName, opts)
+		err = plan.apply(fmt.Sprintf("create bucket %s", bucket.Spec.BucketName), func() error {
+			return minioClient.S3.MakeBucket(ctx, bucket.Spec.BucketName, opts)
+		})
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to create bucket: %w", err)
 		}
-		logger.Info("Bucket created successfully", "bucketName", bucket.Spec.BucketName)
-		bucket.Status.CreationDate = &metav1.Time{Time: time.Now()}
+		if bucket.Status.CreationDate != nil {
+			// The bucket was created before and has been removed outside of the controller
+			drift = append(drift, removedDrift)
+		}
+		if !plan.dryRun {
+			logger.Info("Bucket created successfully", "bucketName", bucket.Spec.BucketName)
+			bucket.Status.CreationDate = &metav1.Time{Time: time.Now()}
+		}
+
+		// A new bucket has neither versioning, tags nor a bucket policy
+		bucket.Status.Versioning = ""
+		bucket.Status.Tags = nil
+		bucket.Status.BucketPolicyHash = ""
+		bucket.Status.EncryptionAlgorithm = ""
+		bucket.Status.KMSKeyID = ""
 	}
 
 	// Configure bucket versioning if specified
-	if bucket.Spec.Versioning {
-		err = minioClient.S3.SetBucketVersioning(ctx, bucket.Spec.BucketName, minio.BucketVersioningConfiguration{
-			Status: "Enabled",
+	if bucket.Spec.Versioning && bucket.Status.Versioning != minio.Enabled {
+		err = plan.apply(fmt.Sprintf("enable versioning of bucket %s", bucket.Spec.BucketName), func() error {
+			return minioClient.S3.SetBucketVersioning(ctx, bucket.Spec.BucketName, minio.BucketVersioningConfiguration{
+				Status: minio.Enabled,
+			})
 		})
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to enable versioning: %w", err)
 		}
 	}
 
-	// Set bucket tags if specified
-	if len(bucket.Spec.Tags) > 0 {
-		bucketTags, err := tags.MapToBucketTags(bucket.Spec.Tags)
+	// Set bucket tags, including the owner tag marking the bucket as managed by this resource
+	if len(tagsDrift(bucket.Spec.Tags, bucket.Status.Tags)) > 0 || owner != string(bucket.UID) {
+		tagMap := map[string]string{}
+		maps.Copy(tagMap, bucket.Spec.Tags)
+		tagMap[miniov1beta1.OwnerTag] = string(bucket.UID)
+		bucketTags, err := tags.MapToBucketTags(tagMap)
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to create tags: %w", err)
 		}
-		err = minioClient.S3.SetBucketTagging(ctx, bucket.Spec.BucketName, bucketTags)
+		err = plan.apply(fmt.Sprintf("set tags of bucket %s", bucket.Spec.BucketName), func() error {
+			return minioClient.S3.SetBucketTagging(ctx, bucket.Spec.BucketName, bucketTags)
+		})
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set bucket tags: %w", err)
 		}
 	}
 
+	// Set or remove the bucket policy
+	if bucket.Spec.BucketPolicy != nil && bucket.Status.BucketPolicyHash != desiredPolicyHash {
+		change := fmt.Sprintf("set policy of bucket %s", bucket.Spec.BucketName)
+		if policyDocument == "" {
+			change = fmt.Sprintf("remove policy of bucket %s", bucket.Spec.BucketName)
+		}
+		err = plan.apply(change, func() error {
+			return minioClient.S3.SetBucketPolicy(ctx, bucket.Spec.BucketName, policyDocument)
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set bucket policy: %w", err)
+		}
+	}
+
+	// Set or remove the default encryption
+	if bucket.Spec.Encryption != nil && !encryptionSynced(bucket) {
+		config := encryptionConfiguration(bucket.Spec.Encryption)
+		if config != nil {
+			err = plan.apply(fmt.Sprintf("set %s encryption of bucket %s", bucket.Spec.Encryption.Type, bucket.Spec.BucketName), func() error {
+				return minioClient.S3.SetBucketEncryption(ctx, bucket.Spec.BucketName, config)
+			})
+		} else {
+			err = plan.apply(fmt.Sprintf("remove encryption of bucket %s", bucket.Spec.BucketName), func() error {
+				return minioClient.S3.RemoveBucketEncryption(ctx, bucket.Spec.BucketName)
+			})
+		}
+		if isKMSNotConfigured(err) {
+			return ctrl.Result{RequeueAfter: time.Minute}, &kmsNotConfiguredError{bucketName: bucket.Spec.BucketName}
+		}
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set bucket encryption: %w", err)
+		}
+	}
+
+	// Set the event notifications
+	if bucket.Spec.Notification != nil {
+		if err := r.reconcileNotification(ctx, bucket, minioClient, plan, exists); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+	}
+
+	// A dry run reports the live state before the planned changes
+	if plan.dryRun {
+		reportDrift(bucket, "Bucket", &bucket.Status.Drift, drift, false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	if err := r.observeBucket(ctx, bucket, minioClient); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	reportDrift(bucket, "Bucket", &bucket.Status.Drift, drift, true)
+	r.recordUsage(ctx, bucket, minioClient)
+
 	return ctrl.Result{RequeueAfter: time.Hour}, nil
 }
 
+// reconcileNotification publishes the events of the bucket to the ARNs of the spec and of the referenced NotificationTarget
+func (r *BucketReconciler) reconcileNotification(ctx context.Context, bucket *miniov1beta1.Bucket, minioClient *minioclient.Client, plan *changePlan, exists bool) error {
+	targetARN := ""
+	if ref := bucket.Spec.Notification.TargetRef; ref != nil {
+		target := &miniov1beta1.NotificationTarget{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: bucket.Namespace}, target); err != nil {
+			return fmt.Errorf("failed to get notification target %s: %w", ref.Name, err)
+		}
+		if target.Status.ARN == "" {
+			return fmt.Errorf("notification target %s is not active yet", ref.Name)
+		}
+		targetARN = target.Status.ARN
+	}
+
+	desired, err := notificationConfiguration(bucket.Spec.Notification, targetARN)
+	if err != nil {
+		return err
+	}
+
+	// A bucket that is only planned to be created has no notifications yet
+	live := notification.Configuration{}
+	if exists {
+		live, err = minioClient.S3.GetBucketNotification(ctx, bucket.Spec.BucketName)
+		if err != nil {
+			return fmt.Errorf("failed to get bucket notifications: %w", err)
+		}
+	}
+	if notificationSynced(live, desired) {
+		return nil
+	}
+
+	err = plan.apply(fmt.Sprintf("set event notifications of bucket %s", bucket.Spec.BucketName), func() error {
+		return minioClient.S3.SetBucketNotification(ctx, bucket.Spec.BucketName, desired)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set bucket notifications: %w", err)
+	}
+	return nil
+}
+
+// bucketsForNotificationTarget maps a NotificationTarget to the Buckets of its namespace publishing events to it
+func (r *BucketReconciler) bucketsForNotificationTarget(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1beta1.BucketList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list buckets")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Spec.Notification != nil && item.Spec.Notification.TargetRef != nil && item.Spec.Notification.TargetRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// bucketDrift returns the drift between the spec and the observed state of a bucket, given the
+// hash of the desired bucket policy
+func bucketDrift(bucket *miniov1beta1.Bucket, desiredPolicyHash string) []miniov1beta1.DriftEntry {
+	var drift []miniov1beta1.DriftEntry
+	if bucket.Spec.Versioning && bucket.Status.Versioning != minio.Enabled {
+		drift = append(drift, miniov1beta1.DriftEntry{Field: "versioning", Desired: minio.Enabled, Actual: bucket.Status.Versioning})
+	}
+	if bucket.Spec.BucketPolicy != nil && bucket.Status.BucketPolicyHash != desiredPolicyHash {
+		drift = append(drift, miniov1beta1.DriftEntry{
+			Field:   "bucketPolicy",
+			Desired: bucketPolicyHashValue(desiredPolicyHash),
+			Actual:  bucketPolicyHashValue(bucket.Status.BucketPolicyHash),
+		})
+	}
+	drift = append(drift, encryptionDrift(bucket)...)
+	return append(drift, tagsDrift(bucket.Spec.Tags, bucket.Status.Tags)...)
+}
+
+// observeBucket reports the live state of the bucket in its status
+func (r *BucketReconciler) observeBucket(ctx context.Context, bucket *miniov1beta1.Bucket, minioClient *minioclient.Client) error {
+	region, err := minioClient.S3.GetBucketLocation(ctx, bucket.Spec.BucketName)
+	if err != nil {
+		return fmt.Errorf("failed to get bucket location: %w", err)
+	}
+
+	versioning, err := minioClient.S3.GetBucketVersioning(ctx, bucket.Spec.BucketName)
+	if err != nil {
+		return fmt.Errorf("failed to get bucket versioning: %w", err)
+	}
+
+	objectLock, _, _, _, err := minioClient.S3.GetObjectLockConfig(ctx, bucket.Spec.BucketName)
+	if err != nil && minio.ToErrorResponse(err).Code != "ObjectLockConfigurationNotFoundError" {
+		return fmt.Errorf("failed to get object lock configuration: %w", err)
+	}
+
+	_, tagMap, err := bucketOwner(ctx, minioClient, bucket.Spec.BucketName)
+	if err != nil {
+		return err
+	}
+	delete(tagMap, miniov1beta1.OwnerTag)
+
+	policyDocument, err := minioClient.S3.GetBucketPolicy(ctx, bucket.Spec.BucketName)
+	if err != nil {
+		return fmt.Errorf("failed to get bucket policy: %w", err)
+	}
+	policyHash, err := bucketPolicyDigest(policyDocument)
+	if err != nil {
+		return err
+	}
+
+	encryption, err := minioClient.S3.GetBucketEncryption(ctx, bucket.Spec.BucketName)
+	if err != nil && minio.ToErrorResponse(err).Code != "ServerSideEncryptionConfigurationNotFoundError" {
+		return fmt.Errorf("failed to get bucket encryption: %w", err)
+	}
+	encryptionAlgorithm, kmsKeyID := observedEncryption(encryption)
+
+	bucket.Status.Region = region
+	bucket.Status.Versioning = versioning.Status
+	bucket.Status.ObjectLocking = objectLock == "Enabled"
+	bucket.Status.Tags = tagMap
+	bucket.Status.BucketPolicyHash = policyHash
+	bucket.Status.EncryptionAlgorithm = encryptionAlgorithm
+	bucket.Status.KMSKeyID = kmsKeyID
+	return nil
+}
+
+// recordUsage exports the usage and quota of the bucket as metrics.
+// Failures are logged only, since usage reporting must not block reconciliation.
+func (r *BucketReconciler) recordUsage(ctx context.Context, bucket *miniov1beta1.Bucket, minioClient *minioclient.Client) {
+	logger := log.FromContext(ctx)
+
+	usage, err := minioClient.DataUsage(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get data usage info (non-fatal)")
+		return
+	}
+
+	var quota uint64
+	bucketQuota, err := minioClient.Admin.GetBucketQuota(ctx, bucket.Spec.BucketName)
+	if err != nil {
+		logger.Error(err, "Failed to get bucket quota (non-fatal)")
+	} else {
+		quota = bucketQuota.Size
+		if quota == 0 {
+			quota = bucketQuota.Quota
+		}
+	}
+
+	bucketUsage := usage.BucketsUsage[bucket.Spec.BucketName]
+	metrics.SetBucketUsage(bucket.Namespace, bucket.Name, bucket.Spec.BucketName, bucketUsage.Size, bucketUsage.ObjectsCount, quota)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *BucketReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&miniov1alpha1.Bucket{}).
+		For(&miniov1beta1.Bucket{}).
+		Watches(&miniov1beta1.NotificationTarget{}, handler.EnqueueRequestsFromMapFunc(r.bucketsForNotificationTarget)).
 		Complete(r)
 }
@@ -27,7 +27,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 )
 
 var _ = Describe("Bucket Controller", func() {
@@ -40,13 +40,13 @@ var _ = Describe("Bucket Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		bucket := &miniov1alpha1.Bucket{}
+		bucket := &miniov1beta1.Bucket{}
 
 		BeforeEach(func() {
 			By("creating the custom resource for the Kind Bucket")
 			err := k8sClient.Get(ctx, typeNamespacedName, bucket)
 			if err != nil && errors.IsNotFound(err) {
-				resource := &miniov1alpha1.Bucket{
+				resource := &miniov1beta1.Bucket{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
@@ -59,7 +59,7 @@ var _ = Describe("Bucket Controller", func() {
 
 		AfterEach(func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &miniov1alpha1.Bucket{}
+			resource := &miniov1beta1.Bucket{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,312 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
+)
+
+// BucketClaimReconciler reconciles a BucketClaim object
+type BucketClaimReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=bucketclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=bucketclaims/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=bucketclaims/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=buckets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=users,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// Reconcile creates the Bucket, Policy, User and connection secret of a claim. They are owned by the
+// claim and removed by the garbage collector when it is deleted.
+func (r *BucketClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the BucketClaim instance
+	claim := &miniov1beta1.BucketClaim{}
+	if err := r.Get(ctx, req.NamespacedName, claim); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object deleted
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Resources paused by their own annotation or by their alias are left untouched
+	if paused, result, err := checkPaused(ctx, r.Client, claim, &claim.Spec.Connection); paused {
+		return result, err
+	}
+
+	// The children of a deleted claim are removed by the garbage collector, which lets their own
+	// finalizers clean up MinIO
+	if claim.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	// Mark progressing
+	patch := client.MergeFrom(claim.DeepCopy())
+	if markReconciling(claim, "Reconciling bucket claim") {
+		claim.Status.ObservedGeneration = claim.Generation
+		if err := r.Status().Patch(ctx, claim, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(claim.DeepCopy())
+
+	// Enforce the tenant policies of the namespace
+	if err := tenant.Check(ctx, r.Client, claim); err != nil {
+		logger.Error(err, "BucketClaim is not allowed by the tenant policies")
+		markFailed(claim, errorReason(err, reasonReconcileError), err.Error())
+		claim.Status.Ready = false
+		if err := r.Status().Patch(ctx, claim, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// Resolve the endpoint published in the connection secret
+	endpoint, err := minioclient.ResolveEndpoint(ctx, r.Client, claim.Spec.Connection, claim.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve connection")
+		markFailed(claim, errorReason(err, reasonClientError), fmt.Sprintf("Failed to resolve connection: %v", err))
+		claim.Status.Ready = false
+		if err := r.Status().Patch(ctx, claim, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// Create or update the children of the claim
+	notReady, err := r.reconcileClaim(ctx, claim, endpoint)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile bucket claim")
+		markFailed(claim, errorReason(err, reasonReconcileError), fmt.Sprintf("Failed to reconcile bucket claim: %v", err))
+		claim.Status.Ready = false
+		claim.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+		if err := r.Status().Patch(ctx, claim, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Mark ready once all children are ready, their status changes trigger another reconciliation
+	if len(notReady) > 0 {
+		setCondition(claim, miniov1beta1.ConditionReady, metav1.ConditionFalse, reasonResourcesNotReady,
+			fmt.Sprintf("Waiting for %s to become ready", strings.Join(notReady, ", ")))
+		removeCondition(claim, miniov1beta1.ConditionReconciling)
+		removeCondition(claim, miniov1beta1.ConditionStalled)
+		claim.Status.Ready = false
+	} else {
+		markReady(claim, "Bucket claim is ready")
+		claim.Status.Ready = true
+	}
+	claim.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+	if err := r.Status().Patch(ctx, claim, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileClaim creates or updates the connection secret, Bucket, Policy and User of a claim and returns
+// the children that are not ready yet
+func (r *BucketClaimReconciler) reconcileClaim(ctx context.Context, claim *miniov1beta1.BucketClaim, endpoint *minioclient.ConnectionEndpoint) ([]string, error) {
+	bucketName := claimBucketName(claim)
+	username := claimUsername(claim)
+	secretName := claimConnectionSecretName(claim)
+	// The policy is specific to the user of the claim, so it is named after it
+	policyName := username
+
+	document, err := minioclient.BucketPolicy(bucketName, claim.Spec.Access)
+	if err != nil {
+		return nil, err
+	}
+
+	// The generated secret key is kept in the connection secret, which the User reads its password from
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: claim.Namespace}}
+	err = r.createOrUpdateChild(ctx, claim, "Secret", secret, func() error {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		if len(secret.Data[miniov1beta1.DefaultSecretAccessKeyKey]) == 0 {
+			secretKey, err := minioclient.GenerateSecretKey()
+			if err != nil {
+				return err
+			}
+			secret.Data[miniov1beta1.DefaultSecretAccessKeyKey] = []byte(secretKey)
+		}
+		secret.Data[miniov1beta1.DefaultAccessKeyIDKey] = []byte(username)
+		secret.Data[miniov1beta1.ConnectionSecretEndpointKey] = []byte(endpoint.URL)
+		secret.Data[miniov1beta1.ConnectionSecretRegionKey] = []byte(endpoint.Region)
+		secret.Data[miniov1beta1.ConnectionSecretBucketKey] = []byte(bucketName)
+		if len(endpoint.CABundle) > 0 {
+			secret.Data[miniov1beta1.ConnectionSecretCABundleKey] = endpoint.CABundle
+		} else {
+			delete(secret.Data, miniov1beta1.ConnectionSecretCABundleKey)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := &miniov1beta1.Bucket{ObjectMeta: metav1.ObjectMeta{Name: claim.Name, Namespace: claim.Namespace}}
+	err = r.createOrUpdateChild(ctx, claim, "Bucket", bucket, func() error {
+		bucket.Spec.Connection = claim.Spec.Connection
+		bucket.Spec.BucketName = bucketName
+		bucket.Spec.Versioning = claim.Spec.Versioning
+		bucket.Spec.ManagementPolicy = miniov1beta1.ManagementPolicyFull
+		if claim.Spec.DeletionPolicy == miniov1beta1.BucketClaimDeletionPolicyRetain {
+			bucket.Spec.ManagementPolicy = miniov1beta1.ManagementPolicyFullNoDelete
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &miniov1beta1.Policy{ObjectMeta: metav1.ObjectMeta{Name: claim.Name, Namespace: claim.Namespace}}
+	err = r.createOrUpdateChild(ctx, claim, "Policy", policy, func() error {
+		policy.Spec.Connection = claim.Spec.Connection
+		policy.Spec.PolicyName = policyName
+		policy.Spec.Policy = document
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user := &miniov1beta1.User{ObjectMeta: metav1.ObjectMeta{Name: claim.Name, Namespace: claim.Namespace}}
+	err = r.createOrUpdateChild(ctx, claim, "User", user, func() error {
+		user.Spec.Connection = claim.Spec.Connection
+		user.Spec.Username = username
+		user.Spec.SecretRef = &miniov1beta1.UserSecretReference{
+			Name:        secretName,
+			PasswordKey: miniov1beta1.DefaultSecretAccessKeyKey,
+		}
+		user.Spec.Status = miniov1beta1.UserStatusEnabled
+		user.Spec.Policies = []string{policyName}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claim.Status.BucketName = bucketName
+	claim.Status.PolicyName = policyName
+	claim.Status.Username = username
+	claim.Status.ConnectionSecretName = secretName
+
+	var notReady []string
+	if !bucket.Status.Ready || bucket.Status.ObservedGeneration != bucket.Generation {
+		notReady = append(notReady, "Bucket "+bucket.Name)
+	}
+	if !policy.Status.Ready || policy.Status.ObservedGeneration != policy.Generation {
+		notReady = append(notReady, "Policy "+policy.Name)
+	}
+	if !user.Status.Ready || user.Status.ObservedGeneration != user.Generation {
+		notReady = append(notReady, "User "+user.Name)
+	}
+	return notReady, nil
+}
+
+// createOrUpdateChild creates or updates a child of claim. Existing objects that are not controlled by the
+// claim are not taken over. The dry-run annotation of the claim is passed on to its children.
+func (r *BucketClaimReconciler) createOrUpdateChild(ctx context.Context, claim *miniov1beta1.BucketClaim, kind string, obj client.Object, mutate func() error) error {
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, obj, func() error {
+		if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, claim) {
+			return &conflictError{message: fmt.Sprintf("%s %s already exists and is not owned by the bucket claim", kind, obj.GetName())}
+		}
+		if err := mutate(); err != nil {
+			return err
+		}
+
+		annotations := obj.GetAnnotations()
+		if value, ok := claim.Annotations[miniov1beta1.DryRunAnnotation]; ok {
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[miniov1beta1.DryRunAnnotation] = value
+		} else {
+			delete(annotations, miniov1beta1.DryRunAnnotation)
+		}
+		obj.SetAnnotations(annotations)
+
+		return controllerutil.SetControllerReference(claim, obj, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile %s %s: %w", kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// claimBucketName returns the bucket name of a claim, which defaults to <namespace>-<name>
+func claimBucketName(claim *miniov1beta1.BucketClaim) string {
+	if claim.Spec.BucketName != "" {
+		return claim.Spec.BucketName
+	}
+	return claim.Namespace + "-" + claim.Name
+}
+
+// claimUsername returns the username of a claim, which defaults to the bucket name
+func claimUsername(claim *miniov1beta1.BucketClaim) string {
+	if claim.Spec.Username != "" {
+		return claim.Spec.Username
+	}
+	return claimBucketName(claim)
+}
+
+// claimConnectionSecretName returns the connection secret of a claim, which defaults to <name>-connection
+func claimConnectionSecretName(claim *miniov1beta1.BucketClaim) string {
+	if claim.Spec.ConnectionSecretName != "" {
+		return claim.Spec.ConnectionSecretName
+	}
+	return claim.Name + "-connection"
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BucketClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.BucketClaim{}).
+		Owns(&miniov1beta1.Bucket{}).
+		Owns(&miniov1beta1.Policy{}).
+		Owns(&miniov1beta1.User{}).
+		Owns(&corev1.Secret{}).
+		Complete(r)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+var _ = Describe("BucketClaim Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-bucket-claim"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind BucketClaim")
+			err := k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.BucketClaim{})
+			if err != nil && errors.IsNotFound(err) {
+				url := "https://minio.example.com"
+				resource := &miniov1beta1.BucketClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: miniov1beta1.BucketClaimSpec{
+						Connection: miniov1beta1.MinIOConnection{URL: &url},
+						Access:     miniov1beta1.BucketAccessReadOnly,
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.BucketClaim{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance BucketClaim")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should create the children owned by the claim", func() {
+			controllerReconciler := &BucketClaimReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName + "-connection", Namespace: "default"}, secret)).To(Succeed())
+			Expect(string(secret.Data[miniov1beta1.ConnectionSecretBucketKey])).To(Equal("default-" + resourceName))
+			Expect(string(secret.Data[miniov1beta1.ConnectionSecretEndpointKey])).To(Equal("https://minio.example.com"))
+			Expect(secret.Data["secretAccessKey"]).To(HaveLen(minioclient.SecretKeyLength))
+			Expect(metav1.IsControlledBy(secret, claimOwner(ctx, typeNamespacedName))).To(BeTrue())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.Bucket{})).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.Policy{})).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.User{})).To(Succeed())
+
+			// The children are not reconciled in this test, so the claim waits for them
+			resource := &miniov1beta1.BucketClaim{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(resource.Status.Ready).To(BeFalse())
+			Expect(resource.Status.BucketName).To(Equal("default-" + resourceName))
+		})
+	})
+
+	Context("When deriving the children of a claim", func() {
+		It("should default the names from the claim", func() {
+			claim := &miniov1beta1.BucketClaim{ObjectMeta: metav1.ObjectMeta{Name: "app-data", Namespace: "team-a"}}
+			Expect(claimBucketName(claim)).To(Equal("team-a-app-data"))
+			Expect(claimUsername(claim)).To(Equal("team-a-app-data"))
+			Expect(claimConnectionSecretName(claim)).To(Equal("app-data-connection"))
+
+			claim.Spec.BucketName = "shared-data"
+			claim.Spec.Username = "app"
+			Expect(claimBucketName(claim)).To(Equal("shared-data"))
+			Expect(claimUsername(claim)).To(Equal("app"))
+		})
+	})
+})
+
+// claimOwner returns the claim owning the children created in the test
+func claimOwner(ctx context.Context, name types.NamespacedName) *miniov1beta1.BucketClaim {
+	claim := &miniov1beta1.BucketClaim{}
+	Expect(k8sClient.Get(ctx, name, claim)).To(Succeed())
+	return claim
+}
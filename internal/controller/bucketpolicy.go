@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// bucketPolicyStatement is a statement of a bucket policy generated from a preset
+type bucketPolicyStatement struct {
+	Effect    string                       `json:"Effect"`
+	Principal map[string][]string          `json:"Principal"`
+	Action    []string                     `json:"Action"`
+	Resource  []string                     `json:"Resource"`
+	Condition map[string]map[string]string `json:"Condition,omitempty"`
+}
+
+// bucketPolicyDocument returns the desired bucket policy document of a bucket, empty if the
+// bucket should have no policy. Presets grant the same anonymous access as "mc anonymous set".
+func bucketPolicyDocument(bucketName string, bucketPolicy *miniov1beta1.BucketPolicy) (string, error) {
+	if bucketPolicy.Policy != "" {
+		return bucketPolicy.Policy, nil
+	}
+
+	bucketResource := "arn:aws:s3:::" + bucketName
+	objectResource := bucketResource + "/" + bucketPolicy.Prefix + "*"
+	anyone := map[string][]string{"AWS": {"*"}}
+
+	var statements []bucketPolicyStatement
+	if bucketPolicy.Preset == miniov1beta1.BucketPolicyPresetDownload || bucketPolicy.Preset == miniov1beta1.BucketPolicyPresetPublic {
+		listBucket := bucketPolicyStatement{
+			Effect: "Allow", Principal: anyone, Action: []string{"s3:ListBucket"}, Resource: []string{bucketResource},
+		}
+		if bucketPolicy.Prefix != "" {
+			listBucket.Condition = map[string]map[string]string{"StringEquals": {"s3:prefix": bucketPolicy.Prefix}}
+		}
+		statements = append(statements,
+			bucketPolicyStatement{
+				Effect: "Allow", Principal: anyone, Action: []string{"s3:GetBucketLocation"}, Resource: []string{bucketResource},
+			},
+			listBucket,
+			bucketPolicyStatement{
+				Effect: "Allow", Principal: anyone, Action: []string{"s3:GetObject"}, Resource: []string{objectResource},
+			},
+		)
+	}
+	if bucketPolicy.Preset == miniov1beta1.BucketPolicyPresetUpload || bucketPolicy.Preset == miniov1beta1.BucketPolicyPresetPublic {
+		statements = append(statements,
+			bucketPolicyStatement{
+				Effect: "Allow", Principal: anyone,
+				Action:   []string{"s3:GetBucketLocation", "s3:ListBucketMultipartUploads"},
+				Resource: []string{bucketResource},
+			},
+			bucketPolicyStatement{
+				Effect: "Allow", Principal: anyone,
+				Action:   []string{"s3:AbortMultipartUpload", "s3:DeleteObject", "s3:ListMultipartUploadParts", "s3:PutObject"},
+				Resource: []string{objectResource},
+			},
+		)
+	}
+	if len(statements) == 0 {
+		return "", nil
+	}
+
+	document, err := json.Marshal(map[string]any{
+		"Version":   "2012-10-17",
+		"Statement": statements,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate bucket policy: %w", err)
+	}
+	return string(document), nil
+}
+
+// bucketPolicyDigest returns the hash of a bucket policy document, empty if there is no policy
+func bucketPolicyDigest(document string) (string, error) {
+	if document == "" {
+		return "", nil
+	}
+	return policyDigest([]byte(document))
+}
+
+// bucketPolicyHashValue formats a bucket policy hash for drift reports
+func bucketPolicyHashValue(hash string) string {
+	if hash == "" {
+		return "none"
+	}
+	return "sha256:" + hash
+}
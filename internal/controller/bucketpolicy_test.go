@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Bucket policies", func() {
+	It("should have no policy for the none preset", func() {
+		document, err := bucketPolicyDocument("data", &miniov1beta1.BucketPolicy{Preset: miniov1beta1.BucketPolicyPresetNone})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(document).To(BeEmpty())
+
+		digest, err := bucketPolicyDigest(document)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digest).To(BeEmpty())
+	})
+
+	It("should limit the download preset to the prefix", func() {
+		document, err := bucketPolicyDocument("data", &miniov1beta1.BucketPolicy{Preset: miniov1beta1.BucketPolicyPresetDownload, Prefix: "public/"})
+		Expect(err).NotTo(HaveOccurred())
+
+		var policy struct {
+			Statement []bucketPolicyStatement
+		}
+		Expect(json.Unmarshal([]byte(document), &policy)).To(Succeed())
+		Expect(policy.Statement).To(HaveLen(3))
+		Expect(policy.Statement[1].Condition).To(HaveKeyWithValue("StringEquals", map[string]string{"s3:prefix": "public/"}))
+		Expect(policy.Statement[2].Resource).To(ConsistOf("arn:aws:s3:::data/public/*"))
+	})
+
+	It("should combine download and upload in the public preset", func() {
+		public, err := bucketPolicyDocument("data", &miniov1beta1.BucketPolicy{Preset: miniov1beta1.BucketPolicyPresetPublic})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(public).To(ContainSubstring("s3:GetObject"))
+		Expect(public).To(ContainSubstring("s3:PutObject"))
+	})
+
+	It("should treat the any principal like any AWS principal", func() {
+		short, err := bucketPolicyDigest(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}]}`)
+		Expect(err).NotTo(HaveOccurred())
+		long, err := bucketPolicyDigest(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::data/*"]}]}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(short).To(Equal(long))
+
+		restricted, err := bucketPolicyDigest(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123:root"]},"Action":"s3:GetObject","Resource":"arn:aws:s3:::data/*"}]}`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restricted).NotTo(Equal(short))
+	})
+})
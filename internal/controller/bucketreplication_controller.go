@@ -0,0 +1,493 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/replication"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/metrics"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// BucketReplicationReconciler reconciles a BucketReplication object
+type BucketReplicationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all bucket replications
+	DryRun bool
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=bucketreplications,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=bucketreplications/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=bucketreplications/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=buckets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile creates the remote target of the destination on the source server and applies the
+// replication rules to the source bucket
+func (r *BucketReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The source bucket is reached through the connection of the source Bucket
+	var bucket *miniov1beta1.Bucket
+	lifecycle := &resourceLifecycle[*miniov1beta1.BucketReplication, replicationClients]{
+		Client:    r.Client,
+		Recorder:  r.Recorder,
+		DryRun:    r.DryRun,
+		name:      "bucket replication",
+		finalizer: miniov1beta1.BucketReplicationFinalizer,
+		status: func(bucketReplication *miniov1beta1.BucketReplication) resourceStatus {
+			status := &bucketReplication.Status
+			return resourceStatus{&status.Ready, &status.ObservedGeneration, &status.LastSyncTime, &status.PlannedChanges}
+		},
+		connections: func(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication) ([]*miniov1beta1.MinIOConnection, error) {
+			var err error
+			if bucket, err = r.sourceBucket(ctx, bucketReplication); err != nil || bucket == nil {
+				return nil, err
+			}
+			return []*miniov1beta1.MinIOConnection{&bucket.Spec.Connection}, nil
+		},
+		delete: func(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication) (ctrl.Result, error) {
+			return r.handleDeletion(ctx, bucketReplication, bucket)
+		},
+		connect: func(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication) (replicationClients, error) {
+			if bucket == nil {
+				return replicationClients{}, fmt.Errorf("source Bucket %s not found", bucketReplication.Spec.BucketRef.Name)
+			}
+			source, err := newMinIOClient(ctx, r.Client, bucket, bucket.Spec.Connection)
+			if err != nil {
+				return replicationClients{}, err
+			}
+			destination, err := newMinIOClient(ctx, r.Client, bucketReplication, bucketReplication.Spec.Destination.Connection)
+			return replicationClients{source: source, destination: destination}, err
+		},
+		reconcile: func(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication, clients replicationClients, plan *changePlan) (ctrl.Result, error) {
+			result, err := r.reconcileReplication(ctx, bucketReplication, bucket.Spec.BucketName, clients.source, clients.destination, plan)
+			if err == nil {
+				bucketReplication.Status.SourceBucket = bucket.Spec.BucketName
+				bucketReplication.Status.DestinationBucket = bucketReplication.Spec.Destination.BucketName
+			}
+			return result, err
+		},
+	}
+	return lifecycle.run(ctx, req, &miniov1beta1.BucketReplication{})
+}
+
+// replicationClients are the MinIO clients of the source and the destination of a bucket replication
+type replicationClients struct {
+	source      *minioclient.Client
+	destination *minioclient.Client
+}
+
+// sourceBucket returns the source Bucket of bucketReplication, or nil if it does not exist
+func (r *BucketReplicationReconciler) sourceBucket(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication) (*miniov1beta1.Bucket, error) {
+	bucket := &miniov1beta1.Bucket{}
+	key := client.ObjectKey{Namespace: bucketReplication.Namespace, Name: bucketReplication.Spec.BucketRef.Name}
+	if err := r.Get(ctx, key, bucket); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return bucket, nil
+}
+
+// handleDeletion removes the replication rules and the remote target from the source bucket
+func (r *BucketReplicationReconciler) handleDeletion(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication, bucket *miniov1beta1.Bucket) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(bucketReplication, miniov1beta1.BucketReplicationFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, bucketReplication)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		arn := bucketReplication.Status.RemoteTargetARN
+		switch {
+		case violation:
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+		case bucket == nil || arn == "":
+			// Without the source Bucket there is no connection to the source server
+			logger.Info("Skipping cleanup of a replication without source Bucket or remote target")
+		default:
+			minioClient, err := newMinIOClient(ctx, r.Client, bucket, bucket.Spec.Connection)
+			if err != nil {
+				logger.Error(err, "Failed to create MinIO client for deletion, retrying")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+
+			plan := newChangePlan(r.DryRun, bucketReplication)
+			if err := r.removeReplication(ctx, minioClient, bucket.Spec.BucketName, arn, plan); err != nil {
+				logger.Error(err, "Failed to remove replication, will retry", "bucketName", bucket.Spec.BucketName)
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if plan.pending() {
+				return reportPlannedDeletion(ctx, r.Client, r.Recorder, bucketReplication, &bucketReplication.Status.PlannedChanges, plan)
+			}
+			logger.Info("Removed bucket replication", "bucketName", bucket.Spec.BucketName, "arn", arn)
+		}
+
+		metrics.DeleteReplication(bucketReplication.Namespace, bucketReplication.Name)
+
+		// Remove finalizer
+		controllerutil.RemoveFinalizer(bucketReplication, miniov1beta1.BucketReplicationFinalizer)
+		if err := r.Update(ctx, bucketReplication); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// removeReplication removes the rules replicating to the remote target arn and the remote target itself.
+// Rules of other remote targets are kept.
+func (r *BucketReplicationReconciler) removeReplication(ctx context.Context, minioClient *minioclient.Client, bucketName, arn string, plan *changePlan) error {
+	exists, err := minioClient.S3.BucketExists(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("failed to check bucket existence: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+
+	live, err := bucketReplicationConfig(ctx, minioClient, bucketName)
+	if err != nil {
+		return err
+	}
+	if len(targetRules(live, arn)) > 0 {
+		remaining := mergeReplicationRules(live, arn, nil)
+		err = plan.apply(fmt.Sprintf("remove replication rules of bucket %s", bucketName), func() error {
+			if len(remaining.Rules) == 0 {
+				return minioClient.S3.RemoveBucketReplication(ctx, bucketName)
+			}
+			return minioClient.S3.SetBucketReplication(ctx, bucketName, remaining)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove replication rules: %w", err)
+		}
+	}
+
+	targets, err := minioClient.Admin.ListRemoteTargets(ctx, bucketName, string(madmin.ReplicationService))
+	if err != nil {
+		return fmt.Errorf("failed to list remote targets: %w", err)
+	}
+	if slices.ContainsFunc(targets, func(target madmin.BucketTarget) bool { return target.Arn == arn }) {
+		err = plan.apply(fmt.Sprintf("remove remote target %s of bucket %s", arn, bucketName), func() error {
+			return minioClient.Admin.RemoveRemoteTarget(ctx, bucketName, arn)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove remote target: %w", err)
+		}
+	}
+	return nil
+}
+
+// reconcileReplication ensures the remote target and the replication rules of the source bucket match the spec
+func (r *BucketReplicationReconciler) reconcileReplication(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication, sourceBucket string, sourceClient, destinationClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	destination := bucketReplication.Spec.Destination
+
+	// Replication requires versioning on both buckets
+	if err := checkReplicationVersioning(ctx, sourceClient, "source", sourceBucket); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	if err := checkReplicationVersioning(ctx, destinationClient, "destination", destination.BucketName); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	// Find the remote target created for this resource, or one for the same destination
+	targets, err := sourceClient.Admin.ListRemoteTargets(ctx, sourceBucket, string(madmin.ReplicationService))
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to list remote targets: %w", err)
+	}
+	endpoint, secure := destinationClient.Endpoint()
+	accessKey, secretKey, err := destinationClient.Credentials()
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("destination cannot be used as remote target: %w", err)
+	}
+	index := slices.IndexFunc(targets, func(target madmin.BucketTarget) bool {
+		if bucketReplication.Status.RemoteTargetARN != "" {
+			return target.Arn == bucketReplication.Status.RemoteTargetARN
+		}
+		return target.Endpoint == endpoint && target.TargetBucket == destination.BucketName
+	})
+
+	desiredTarget := madmin.BucketTarget{
+		SourceBucket:    sourceBucket,
+		Endpoint:        endpoint,
+		Credentials:     &madmin.Credentials{AccessKey: accessKey, SecretKey: secretKey},
+		TargetBucket:    destination.BucketName,
+		Secure:          secure,
+		API:             "s3v4",
+		Type:            madmin.ReplicationService,
+		Region:          destination.Region,
+		ReplicationSync: destination.Synchronous,
+	}
+	if destination.BandwidthLimit != nil {
+		desiredTarget.BandwidthLimit = *destination.BandwidthLimit
+	}
+
+	arn := bucketReplication.Status.RemoteTargetARN
+	online := false
+	if index < 0 {
+		err = plan.apply(fmt.Sprintf("create remote target for bucket %s on %s", destination.BucketName, endpoint), func() error {
+			arn, err = sourceClient.Admin.SetRemoteTarget(ctx, sourceBucket, &desiredTarget)
+			return err
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to create remote target: %w", err)
+		}
+		if !plan.dryRun {
+			logger.Info("Created remote target", "bucketName", sourceBucket, "arn", arn)
+		}
+	} else {
+		live := targets[index]
+		arn, online = live.Arn, live.Online
+		desiredTarget.Arn = arn
+		if ops := remoteTargetUpdates(live, desiredTarget); len(ops) > 0 {
+			err = plan.apply(fmt.Sprintf("update remote target %s of bucket %s", arn, sourceBucket), func() error {
+				_, err := sourceClient.Admin.UpdateRemoteTarget(ctx, &desiredTarget, ops...)
+				return err
+			})
+			if err != nil {
+				return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to update remote target: %w", err)
+			}
+		}
+	}
+	if !plan.dryRun {
+		bucketReplication.Status.RemoteTargetARN = arn
+	}
+
+	// Apply the rules of this resource, keeping the rules of other remote targets
+	live, err := bucketReplicationConfig(ctx, sourceClient, sourceBucket)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	desired := replicationRules(bucketReplication.Spec.Rules, arn)
+	if !sameReplicationRules(targetRules(live, arn), desired) {
+		config := mergeReplicationRules(live, arn, desired)
+		err = plan.apply(fmt.Sprintf("set replication rules of bucket %s", sourceBucket), func() error {
+			return sourceClient.S3.SetBucketReplication(ctx, sourceBucket, config)
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set bucket replication: %w", err)
+		}
+	}
+
+	if !plan.dryRun {
+		bucketReplication.Status.TargetOnline = online
+		r.recordBacklog(ctx, bucketReplication, sourceClient, sourceBucket, arn)
+	}
+	return ctrl.Result{RequeueAfter: time.Hour}, nil
+}
+
+// recordBacklog reports the replication backlog of the remote target arn in status and as metrics.
+// Failures are logged only, since metrics reporting must not block reconciliation.
+func (r *BucketReplicationReconciler) recordBacklog(ctx context.Context, bucketReplication *miniov1beta1.BucketReplication, minioClient *minioclient.Client, bucketName, arn string) {
+	logger := log.FromContext(ctx)
+
+	replicationMetrics, err := minioClient.S3.GetBucketReplicationMetricsV2(ctx, bucketName)
+	if err != nil {
+		logger.Error(err, "Failed to get replication metrics (non-fatal)")
+		return
+	}
+
+	stats := replicationMetrics.CurrentStats.Stats[arn]
+	bucketReplication.Status.ReplicatedBytes = int64(stats.ReplicatedSize)
+	bucketReplication.Status.PendingObjects = int64(stats.PendingCount)
+	bucketReplication.Status.PendingBytes = int64(stats.PendingSize)
+	bucketReplication.Status.FailedObjects = int64(stats.FailedCount)
+	metrics.SetReplicationBacklog(bucketReplication.Namespace, bucketReplication.Name, bucketName, stats.PendingCount, stats.PendingSize, stats.FailedCount)
+}
+
+// checkReplicationVersioning returns an error unless the bucket exists and has versioning enabled
+func checkReplicationVersioning(ctx context.Context, minioClient *minioclient.Client, role, bucketName string) error {
+	exists, err := minioClient.S3.BucketExists(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("failed to check %s bucket existence: %w", role, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s bucket %s does not exist", role, bucketName)
+	}
+	versioning, err := minioClient.S3.GetBucketVersioning(ctx, bucketName)
+	if err != nil {
+		return fmt.Errorf("failed to get versioning of %s bucket: %w", role, err)
+	}
+	if versioning.Status != minio.Enabled {
+		return fmt.Errorf("%s bucket %s must have versioning enabled for replication", role, bucketName)
+	}
+	return nil
+}
+
+// bucketReplicationConfig returns the live replication configuration of a bucket, empty if it has none
+func bucketReplicationConfig(ctx context.Context, minioClient *minioclient.Client, bucketName string) (replication.Config, error) {
+	config, err := minioClient.S3.GetBucketReplication(ctx, bucketName)
+	if err != nil && minio.ToErrorResponse(err).Code != "ReplicationConfigurationNotFoundError" {
+		return replication.Config{}, fmt.Errorf("failed to get bucket replication: %w", err)
+	}
+	return config, nil
+}
+
+// remoteTargetUpdates returns the updates needed to make the live remote target match the desired one.
+// Secret keys cannot be read back, so credentials are only updated when the access key changes.
+func remoteTargetUpdates(live, desired madmin.BucketTarget) []madmin.TargetUpdateType {
+	var ops []madmin.TargetUpdateType
+	if live.Credentials == nil || live.Credentials.AccessKey != desired.Credentials.AccessKey {
+		ops = append(ops, madmin.CredentialsUpdateType)
+	}
+	if live.ReplicationSync != desired.ReplicationSync {
+		ops = append(ops, madmin.SyncUpdateType)
+	}
+	if live.BandwidthLimit != desired.BandwidthLimit {
+		ops = append(ops, madmin.BandwidthLimitUpdateType)
+	}
+	return ops
+}
+
+// replicationStatus converts a flag of a replication rule to the status of the configuration
+func replicationStatus(enabled bool) replication.Status {
+	if enabled {
+		return replication.Enabled
+	}
+	return replication.Disabled
+}
+
+// replicationRules returns the rules of the replication configuration for the rules of the spec,
+// replicating to the remote target arn
+func replicationRules(rules []miniov1beta1.ReplicationRule, arn string) []replication.Rule {
+	result := make([]replication.Rule, 0, len(rules))
+	for _, rule := range rules {
+		filter := replication.Filter{Prefix: rule.Prefix}
+		if len(rule.Tags) > 0 {
+			keys := make([]string, 0, len(rule.Tags))
+			for key := range rule.Tags {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			tags := make([]replication.Tag, 0, len(keys))
+			for _, key := range keys {
+				tags = append(tags, replication.Tag{Key: key, Value: rule.Tags[key]})
+			}
+
+			// A single tag is a filter of its own, everything else is combined with And
+			if len(tags) == 1 && rule.Prefix == "" {
+				filter = replication.Filter{Tag: tags[0]}
+			} else {
+				filter = replication.Filter{And: replication.And{Prefix: rule.Prefix, Tags: tags}}
+			}
+		}
+
+		result = append(result, replication.Rule{
+			ID:                        rule.ID,
+			Status:                    replicationStatus(rule.Status != miniov1beta1.ReplicationRuleStatusDisabled),
+			Priority:                  rule.Priority,
+			DeleteMarkerReplication:   replication.DeleteMarkerReplication{Status: replicationStatus(rule.DeleteMarkerReplication)},
+			DeleteReplication:         replication.DeleteReplication{Status: replicationStatus(rule.DeleteReplication)},
+			ExistingObjectReplication: replication.ExistingObjectReplication{Status: replicationStatus(rule.ExistingObjectReplication)},
+			SourceSelectionCriteria: replication.SourceSelectionCriteria{
+				ReplicaModifications: replication.ReplicaModifications{Status: replication.Enabled},
+			},
+			Destination: replication.Destination{Bucket: arn, StorageClass: rule.StorageClass},
+			Filter:      filter,
+		})
+	}
+	return result
+}
+
+// targetRules returns the rules of config that replicate to the remote target arn
+func targetRules(config replication.Config, arn string) []replication.Rule {
+	var rules []replication.Rule
+	for _, rule := range config.Rules {
+		if rule.Destination.Bucket == arn {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// mergeReplicationRules returns config with the rules replicating to the remote target arn replaced by rules
+func mergeReplicationRules(config replication.Config, arn string, rules []replication.Rule) replication.Config {
+	merged := replication.Config{}
+	for _, rule := range config.Rules {
+		if rule.Destination.Bucket != arn {
+			merged.Rules = append(merged.Rules, rule)
+		}
+	}
+	merged.Rules = append(merged.Rules, rules...)
+	return merged
+}
+
+// sameReplicationRules reports whether two lists of replication rules are equal, ignoring XML metadata
+func sameReplicationRules(a, b []replication.Rule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		// XML names are excluded from JSON, so equal encodings mean equal rules
+		encodedA, errA := json.Marshal(a[i])
+		encodedB, errB := json.Marshal(b[i])
+		if errA != nil || errB != nil || string(encodedA) != string(encodedB) {
+			return false
+		}
+	}
+	return true
+}
+
+// bucketReplicationsForBucket maps a Bucket to the BucketReplications replicating it
+func (r *BucketReplicationReconciler) bucketReplicationsForBucket(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1beta1.BucketReplicationList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list bucket replications")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Spec.BucketRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BucketReplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.BucketReplication{}).
+		Watches(&miniov1beta1.Bucket{}, handler.EnqueueRequestsFromMapFunc(r.bucketReplicationsForBucket)).
+		Complete(r)
+}
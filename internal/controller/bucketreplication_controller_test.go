@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7/pkg/replication"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("BucketReplication Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-replication"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind BucketReplication")
+			err := k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.BucketReplication{})
+			if err != nil && errors.IsNotFound(err) {
+				resource := &miniov1beta1.BucketReplication{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+					Spec: miniov1beta1.BucketReplicationSpec{
+						BucketRef: miniov1beta1.BucketReference{Name: "missing-bucket"},
+						Destination: miniov1beta1.ReplicationDestination{
+							Connection: miniov1beta1.MinIOConnection{
+								AliasRef: &miniov1beta1.AliasReference{Name: "minio-dr"},
+							},
+							BucketName: "replica",
+						},
+						Rules: []miniov1beta1.ReplicationRule{{ID: "all"}},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.BucketReplication{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance BucketReplication")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should report a missing source Bucket", func() {
+			controllerReconciler := &BucketReplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &miniov1beta1.BucketReplication{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+		})
+	})
+
+	Context("When building replication rules", func() {
+		const arn = "arn:minio:replication::uuid:replica"
+
+		It("should combine a prefix and tags", func() {
+			rules := replicationRules([]miniov1beta1.ReplicationRule{{
+				ID:                      "documents",
+				Priority:                1,
+				Prefix:                  "documents/",
+				Tags:                    map[string]string{"team": "backend", "class": "archive"},
+				DeleteMarkerReplication: true,
+			}}, arn)
+			Expect(rules).To(HaveLen(1))
+			Expect(rules[0].Status).To(Equal(replication.Enabled))
+			Expect(rules[0].Destination.Bucket).To(Equal(arn))
+			Expect(rules[0].DeleteMarkerReplication.Status).To(Equal(replication.Enabled))
+			Expect(rules[0].DeleteReplication.Status).To(Equal(replication.Disabled))
+			Expect(rules[0].Filter.And.Prefix).To(Equal("documents/"))
+			Expect(rules[0].Filter.And.Tags).To(Equal([]replication.Tag{
+				{Key: "class", Value: "archive"},
+				{Key: "team", Value: "backend"},
+			}))
+		})
+
+		It("should only replace the rules of its own remote target", func() {
+			other := replication.Rule{ID: "other", Destination: replication.Destination{Bucket: "arn:minio:replication::uuid:other"}}
+			own := replication.Rule{ID: "stale", Destination: replication.Destination{Bucket: arn}}
+			live := replication.Config{Rules: []replication.Rule{other, own}}
+
+			desired := replicationRules([]miniov1beta1.ReplicationRule{{ID: "all"}}, arn)
+			Expect(sameReplicationRules(targetRules(live, arn), desired)).To(BeFalse())
+
+			merged := mergeReplicationRules(live, arn, desired)
+			Expect(merged.Rules).To(HaveLen(2))
+			Expect(merged.Rules[0].ID).To(Equal("other"))
+			Expect(sameReplicationRules(targetRules(merged, arn), desired)).To(BeTrue())
+
+			Expect(mergeReplicationRules(live, arn, nil).Rules).To(ConsistOf(other))
+		})
+
+		It("should only update the credentials of a remote target if the access key changed", func() {
+			live := madmin.BucketTarget{Credentials: &madmin.Credentials{AccessKey: "replicator"}}
+			desired := madmin.BucketTarget{Credentials: &madmin.Credentials{AccessKey: "replicator", SecretKey: "secret"}}
+			Expect(remoteTargetUpdates(live, desired)).To(BeEmpty())
+
+			desired.Credentials.AccessKey = "rotated"
+			desired.ReplicationSync = true
+			Expect(remoteTargetUpdates(live, desired)).To(ConsistOf(madmin.CredentialsUpdateType, madmin.SyncUpdateType))
+		})
+	})
+})
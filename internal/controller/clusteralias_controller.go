@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/metrics"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// ClusterAliasReconciler reconciles a ClusterAlias object
+type ClusterAliasReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=clusteraliases,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=clusteraliases/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=clusteraliases/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ClusterAliasReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the ClusterAlias instance
+	alias := &miniov1beta1.ClusterAlias{}
+	err := r.Get(ctx, req.NamespacedName, alias)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("ClusterAlias resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get ClusterAlias")
+		return ctrl.Result{}, err
+	}
+
+	// Paused resources are left untouched, including their deletion
+	if paused, result, err := checkPaused(ctx, r.Client, alias, nil); paused {
+		return result, err
+	}
+
+	// Handle deletion
+	if alias.DeletionTimestamp != nil {
+		return r.handleDeletion(ctx, alias)
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(alias, miniov1beta1.ClusterAliasFinalizer) {
+		controllerutil.AddFinalizer(alias, miniov1beta1.ClusterAliasFinalizer)
+		return ctrl.Result{}, r.Update(ctx, alias)
+	}
+
+	// Update status to indicate reconciliation is in progress
+	patch := client.MergeFrom(alias.DeepCopy())
+	if markReconciling(alias, "Reconciling cluster alias") {
+		alias.Status.ObservedGeneration = alias.Generation
+		if err := r.Status().Patch(ctx, alias, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(alias.DeepCopy())
+
+	// Create MinIO client for health checking
+	minioClient, err := minioclient.NewClusterAliasClient(ctx, r.Client, alias)
+	if err != nil {
+		logger.Error(err, "Failed to create MinIO client")
+		markFailed(alias, errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err))
+		alias.Status.Ready = false
+		alias.Status.Healthy = false
+		metrics.SetAliasHealth("", alias.Name, false, "")
+		if err := r.Status().Patch(ctx, alias, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// Reconcile the alias
+	result, err := r.reconcileClusterAlias(ctx, alias, minioClient)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile cluster alias")
+		markFailed(alias, reasonReconcileError, fmt.Sprintf("Failed to reconcile cluster alias: %v", err))
+		alias.Status.Ready = false
+		alias.Status.Healthy = false
+		alias.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+		if err := r.Status().Patch(ctx, alias, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return result, err
+	}
+
+	// Update status to ready
+	markReady(alias, "Cluster alias is ready")
+	alias.Status.Ready = true
+	alias.Status.URL = alias.Spec.URL
+	alias.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+
+	if err := r.Status().Patch(ctx, alias, patch); err != nil {
+		logger.Error(err, "Failed to update status to ready")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// handleDeletion handles the deletion of a ClusterAlias resource
+func (r *ClusterAliasReconciler) handleDeletion(ctx context.Context, alias *miniov1beta1.ClusterAlias) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(alias, miniov1beta1.ClusterAliasFinalizer) {
+		// For cluster aliases, we don't need to do any cleanup in MinIO
+		// Just remove the finalizer
+		logger.Info("ClusterAlias being deleted", "url", alias.Spec.URL)
+		metrics.DeleteAlias("", alias.Name)
+		controllerutil.RemoveFinalizer(alias, miniov1beta1.ClusterAliasFinalizer)
+		return ctrl.Result{}, r.Update(ctx, alias)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileClusterAlias reconciles the cluster alias state
+func (r *ClusterAliasReconciler) reconcileClusterAlias(ctx context.Context, alias *miniov1beta1.ClusterAlias, minioClient *minioclient.Client) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Perform health check
+	now := metav1.Time{Time: time.Now()}
+	alias.Status.LastHealthCheck = &now
+
+	err := minioClient.HealthCheck(ctx)
+	if err != nil {
+		logger.Error(err, "Health check failed")
+		alias.Status.Healthy = false
+		metrics.SetAliasHealth("", alias.Name, false, "")
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	alias.Status.Healthy = true
+	if alias.Status.ConnectedAt == nil {
+		alias.Status.ConnectedAt = &now
+	}
+
+	// Get server info to populate version and region
+	serverInfo, err := minioClient.GetServerInfo(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get server info")
+		// Don't fail reconciliation for this
+	} else {
+		// Get version from the first server
+		if len(serverInfo.Servers) > 0 {
+			alias.Status.Version = serverInfo.Servers[0].Version
+		}
+		if alias.Spec.Region != nil {
+			alias.Status.Region = *alias.Spec.Region
+		}
+	}
+
+	metrics.SetAliasHealth("", alias.Name, true, alias.Status.Version)
+
+	// Calculate next health check interval
+	interval := time.Minute * 5 // Default 5 minutes
+	if alias.Spec.HealthCheck != nil && alias.Spec.HealthCheck.Enabled {
+		if alias.Spec.HealthCheck.IntervalSeconds != nil {
+			interval = time.Duration(*alias.Spec.HealthCheck.IntervalSeconds) * time.Second
+		}
+	}
+
+	logger.Info("ClusterAlias health check successful", "url", alias.Spec.URL, "version", alias.Status.Version)
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterAliasReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.ClusterAlias{}).
+		Complete(r)
+}
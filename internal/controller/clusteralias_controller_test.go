@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("ClusterAlias Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-cluster-alias"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		alias := &miniov1beta1.ClusterAlias{}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind ClusterAlias")
+			err := k8sClient.Get(ctx, typeNamespacedName, alias)
+			if err != nil && errors.IsNotFound(err) {
+				resource := &miniov1beta1.ClusterAlias{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+					Spec: miniov1beta1.ClusterAliasSpec{
+						URL:       "http://localhost:9000",
+						SecretRef: miniov1beta1.ClusterSecretReference{Name: "minio-credentials"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.ClusterAlias{}
+			err := k8sClient.Get(ctx, typeNamespacedName, resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cleanup the specific resource instance ClusterAlias")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+		It("should successfully reconcile the resource", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &ClusterAliasReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
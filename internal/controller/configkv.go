@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/minio/madmin-go/v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// configKey returns the key of a configuration subsystem, qualified with the target if there is one
+func configKey(subSystem, target string) string {
+	if target == "" {
+		return subSystem
+	}
+	return subSystem + ":" + target
+}
+
+// configKVLine formats configuration keys in the format of "mc admin config set". Values are
+// quoted, so they may contain spaces.
+func configKVLine(key string, values map[string]string) string {
+	return key + " " + configKVPairs(values)
+}
+
+// configValueEscaper escapes the characters that would end a quoted configuration value
+var configValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// configKVPairs formats configuration keys as sorted key="value" pairs
+func configKVPairs(values map[string]string) string {
+	fields := make([]string, 0, len(values))
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		fields = append(fields, fmt.Sprintf(`%s="%s"`, name, configValueEscaper.Replace(values[name])))
+	}
+	return strings.Join(fields, " ")
+}
+
+// liveConfig returns the live configuration of a subsystem target, or nil if the target is not configured
+func liveConfig(ctx context.Context, admin *madmin.AdminClient, subSystem, target string) (*madmin.SubsysConfig, error) {
+	output, err := admin.GetConfigKV(ctx, subSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration of %s: %w", subSystem, err)
+	}
+	configs, err := madmin.ParseServerConfigOutput(string(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration of %s: %w", subSystem, err)
+	}
+	for i := range configs {
+		if configs[i].SubSystem == subSystem && configs[i].Target == target {
+			return &configs[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// configMismatch returns the sorted keys whose live values differ from the desired ones
+func configMismatch(live *madmin.SubsysConfig, desired map[string]string) []string {
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(desired)) {
+		if value, _ := live.Lookup(key); value != desired[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// configSecretValues reads the values of configuration keys from their secrets. References to
+// secrets in other namespaces must be granted like those of connections.
+func configSecretValues(ctx context.Context, c client.Client, namespace string, values []miniov1beta1.ConfigSecretValue) (map[string]string, error) {
+	result := map[string]string{}
+	for _, value := range values {
+		ref := value.SecretKeyRef
+		secretNamespace := namespace
+		if ref.Namespace != nil {
+			secretNamespace = *ref.Namespace
+		}
+		if err := minioclient.CheckSecretReference(ctx, c, namespace, secretNamespace, ref.Name); err != nil {
+			return nil, err
+		}
+
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: secretNamespace}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get secret %s/%s: %w", secretNamespace, ref.Name, err)
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s/%s", ref.Key, secretNamespace, ref.Name)
+		}
+		// A line break would start another configuration line, so control characters are never written
+		if strings.ContainsFunc(string(data), unicode.IsControl) {
+			return nil, &invalidSpecError{message: fmt.Sprintf("key %s in secret %s/%s contains control characters", ref.Key, secretNamespace, ref.Name)}
+		}
+		result[value.Key] = string(data)
+	}
+	return result, nil
+}
+
+// configSecretsDigest returns the SHA-256 digest of configuration values read from secrets
+func configSecretsDigest(values map[string]string) string {
+	hash := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		// Length prefixes keep the digests of different splits of the same bytes apart
+		fmt.Fprintf(hash, "%d:%s%d:%s", len(key), key, len(values[key]), values[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// referencesSecret reports whether one of the values is read from the secret
+func referencesSecret(namespace string, values []miniov1beta1.ConfigSecretValue, secret client.Object) bool {
+	return slices.ContainsFunc(values, func(value miniov1beta1.ConfigSecretValue) bool {
+		secretNamespace := namespace
+		if value.SecretKeyRef.Namespace != nil {
+			secretNamespace = *value.SecretKeyRef.Namespace
+		}
+		return value.SecretKeyRef.Name == secret.GetName() && secretNamespace == secret.GetNamespace()
+	})
+}
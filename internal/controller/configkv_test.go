@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/minio/madmin-go/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MinIO configuration", func() {
+	It("should format sorted and quoted key/value pairs", func() {
+		line := configKVLine(configKey("notify_webhook", "audit"), map[string]string{
+			"endpoint":   "https://events.example.com/minio",
+			"auth_token": "Bearer secret token",
+		})
+		Expect(line).To(Equal(`notify_webhook:audit auth_token="Bearer secret token" endpoint="https://events.example.com/minio"`))
+	})
+
+	It("should escape quotes and backslashes in values", func() {
+		line := configKVLine("identity_openid", map[string]string{
+			"scopes": `openid" role_policy="consoleAdmin`,
+			"vendor": `C:\path\`,
+		})
+		Expect(line).To(Equal(`identity_openid scopes="openid\" role_policy=\"consoleAdmin" vendor="C:\\path\\"`))
+	})
+
+	It("should only qualify keys of named targets", func() {
+		Expect(configKey("api", "")).To(Equal("api"))
+	})
+
+	It("should report keys differing from the live configuration", func() {
+		configs, err := madmin.ParseServerConfigOutput("notify_webhook:audit endpoint=https://events.example.com/minio queue_limit=0\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs).To(HaveLen(1))
+
+		Expect(configMismatch(&configs[0], map[string]string{
+			"endpoint":    "https://events.example.com/minio",
+			"queue_limit": "10000",
+			"queue_dir":   "/var/minio/events",
+		})).To(Equal([]string{"queue_dir", "queue_limit"}))
+		Expect(configMismatch(&configs[0], map[string]string{"endpoint": "https://events.example.com/minio"})).To(BeEmpty())
+	})
+
+	It("should tell apart secret values split differently", func() {
+		Expect(configSecretsDigest(map[string]string{"password": "ab", "username": "c"})).
+			NotTo(Equal(configSecretsDigest(map[string]string{"password": "a", "username": "bc"})))
+		Expect(configSecretsDigest(map[string]string{"password": "a"})).
+			To(Equal(configSecretsDigest(map[string]string{"password": "a"})))
+	})
+})
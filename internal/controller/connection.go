@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
+)
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliasgrants,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=tenantpolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// newMinIOClient creates a MinIO client for a resource, falling back to the deprecated endpointRef
+// preserved by the v1alpha1 conversion when the connection has no other method configured
+func newMinIOClient(ctx context.Context, c client.Client, obj client.Object, conn miniov1beta1.MinIOConnection) (*minioclient.Client, error) {
+	if ref, ok := obj.GetAnnotations()[miniov1beta1.LegacyEndpointRefAnnotation]; ok && conn.AliasRef == nil && conn.ClusterAliasRef == nil && conn.URL == nil {
+		return minioclient.NewClientForEndpoint(ctx, c, *miniov1alpha1.ParseEndpointReference(ref), obj.GetNamespace())
+	}
+	return minioclient.NewClient(ctx, c, conn, obj.GetNamespace())
+}
+
+// errorReason returns the condition reason for err, using fallback unless the error has a more specific reason
+func errorReason(err error, fallback string) string {
+	var notPermitted *minioclient.ReferenceNotPermittedError
+	if errors.As(err, &notPermitted) {
+		return reasonReferenceNotPermitted
+	}
+	var credentialsNotPermitted *minioclient.CredentialsNotPermittedError
+	if errors.As(err, &credentialsNotPermitted) {
+		return reasonCredentialsNotPermitted
+	}
+	var tenantErr *tenant.Error
+	if errors.As(err, &tenantErr) {
+		return tenantErr.Reason
+	}
+	var conflict *conflictError
+	if errors.As(err, &conflict) {
+		return reasonConflict
+	}
+	var kmsNotConfigured *kmsNotConfiguredError
+	if errors.As(err, &kmsNotConfigured) {
+		return reasonKMSNotConfigured
+	}
+	var invalidSpec *invalidSpecError
+	if errors.As(err, &invalidSpec) {
+		return reasonInvalidSpec
+	}
+	return fallback
+}
+
+// tenantViolation reports whether obj is not allowed by the tenant policies of its namespace. The MinIO
+// objects named by such a resource may belong to someone else and must not be removed when it is deleted.
+func tenantViolation(ctx context.Context, c client.Reader, obj client.Object) (bool, error) {
+	err := tenant.Check(ctx, c, obj)
+	if err == nil {
+		return false, nil
+	}
+	var tenantErr *tenant.Error
+	if errors.As(err, &tenantErr) {
+		return true, nil
+	}
+	return false, err
+}
+
+// checkPaused reports whether reconciliation of obj is paused by its own paused annotation or by the one
+// of the Alias or ClusterAlias of conn, and records the Paused condition. Callers must not touch obj or
+// its MinIO resources while it is paused and return the result and error.
+func checkPaused(ctx context.Context, c client.Client, obj conditionsAccessor, conn *miniov1beta1.MinIOConnection) (bool, ctrl.Result, error) {
+	message, byAlias, err := pausedBy(ctx, c, obj, conn)
+	if err != nil || message == "" {
+		return err != nil, ctrl.Result{}, err
+	}
+	log.FromContext(ctx).Info("Reconciliation is paused", "reason", message)
+
+	condition := meta.FindStatusCondition(obj.GetConditions(), miniov1beta1.ConditionPaused)
+	if condition == nil || condition.Message != message {
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		markPaused(obj, message)
+		if err := c.Status().Patch(ctx, obj, patch); err != nil {
+			return true, ctrl.Result{}, err
+		}
+	}
+
+	// Resources are not notified when their alias is resumed
+	if byAlias {
+		return true, ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	return true, ctrl.Result{}, nil
+}
+
+// pausedBy returns why reconciliation of obj is paused, or an empty message if it is not paused.
+// byAlias is set if it is paused by the Alias or ClusterAlias of conn.
+func pausedBy(ctx context.Context, c client.Reader, obj client.Object, conn *miniov1beta1.MinIOConnection) (message string, byAlias bool, err error) {
+	if hasPausedAnnotation(obj) {
+		return "Reconciliation is paused by the " + miniov1beta1.PausedAnnotation + " annotation", false, nil
+	}
+	if conn == nil {
+		return "", false, nil
+	}
+
+	var alias client.Object
+	var kind string
+	var key client.ObjectKey
+	switch {
+	case conn.AliasRef != nil:
+		alias, kind = &miniov1beta1.Alias{}, "Alias"
+		key = client.ObjectKey{Name: conn.AliasRef.Name, Namespace: obj.GetNamespace()}
+		if conn.AliasRef.Namespace != nil {
+			key.Namespace = *conn.AliasRef.Namespace
+		}
+	case conn.ClusterAliasRef != nil:
+		alias, kind = &miniov1beta1.ClusterAlias{}, "ClusterAlias"
+		key = client.ObjectKey{Name: conn.ClusterAliasRef.Name}
+	default:
+		return "", false, nil
+	}
+
+	// A missing alias is reported when the client is created
+	if err := c.Get(ctx, key, alias); err != nil {
+		return "", false, client.IgnoreNotFound(err)
+	}
+	if hasPausedAnnotation(alias) {
+		return "Reconciliation is paused by " + kind + " " + strings.TrimPrefix(key.String(), "/"), true, nil
+	}
+	return "", false, nil
+}
+
+// hasPausedAnnotation reports whether obj carries the paused annotation
+func hasPausedAnnotation(obj client.Object) bool {
+	return obj.GetAnnotations()[miniov1beta1.PausedAnnotation] == "true"
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Pausing reconciliation", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	newFakeClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&miniov1beta1.Bucket{}).Build()
+	}
+
+	pausedAnnotation := map[string]string{miniov1beta1.PausedAnnotation: "true"}
+
+	newBucket := func(annotations map[string]string, conn miniov1beta1.MinIOConnection) *miniov1beta1.Bucket {
+		return &miniov1beta1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default", Annotations: annotations},
+			Spec:       miniov1beta1.BucketSpec{Connection: conn, BucketName: "test-bucket"},
+		}
+	}
+
+	It("should pause resources with the paused annotation", func() {
+		bucket := newBucket(pausedAnnotation, miniov1beta1.MinIOConnection{})
+		c := newFakeClient(bucket)
+
+		paused, result, err := checkPaused(ctx, c, bucket, &bucket.Spec.Connection)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeTrue())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(bucket), bucket)).To(Succeed())
+		Expect(meta.IsStatusConditionTrue(bucket.Status.Conditions, miniov1beta1.ConditionPaused)).To(BeTrue())
+	})
+
+	It("should pause resources connecting through a paused alias", func() {
+		alias := &miniov1beta1.Alias{ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "default", Annotations: pausedAnnotation}}
+		bucket := newBucket(nil, miniov1beta1.MinIOConnection{AliasRef: &miniov1beta1.AliasReference{Name: "minio"}})
+		c := newFakeClient(alias, bucket)
+
+		message, byAlias, err := pausedBy(ctx, c, bucket, &bucket.Spec.Connection)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(ContainSubstring("Alias default/minio"))
+		Expect(byAlias).To(BeTrue())
+
+		paused, result, err := checkPaused(ctx, c, bucket, &bucket.Spec.Connection)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeTrue())
+		Expect(result.RequeueAfter).NotTo(BeZero())
+	})
+
+	It("should pause resources connecting through a paused cluster alias", func() {
+		alias := &miniov1beta1.ClusterAlias{ObjectMeta: metav1.ObjectMeta{Name: "shared", Annotations: pausedAnnotation}}
+		bucket := newBucket(nil, miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared"}})
+
+		message, _, err := pausedBy(ctx, newFakeClient(alias, bucket), bucket, &bucket.Spec.Connection)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(HaveSuffix("ClusterAlias shared"))
+	})
+
+	It("should not pause resources of an active or missing alias", func() {
+		alias := &miniov1beta1.Alias{ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "default"}}
+		bucket := newBucket(nil, miniov1beta1.MinIOConnection{AliasRef: &miniov1beta1.AliasReference{Name: "minio"}})
+		paused, _, err := checkPaused(ctx, newFakeClient(alias, bucket), bucket, &bucket.Spec.Connection)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeFalse())
+
+		paused, _, err = checkPaused(ctx, newFakeClient(bucket), bucket, &bucket.Spec.Connection)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeFalse())
+	})
+
+	It("should remove the Paused condition once reconciliation resumes", func() {
+		bucket := newBucket(nil, miniov1beta1.MinIOConnection{})
+		markPaused(bucket, "Reconciliation is paused")
+		markReconciling(bucket, "Reconciling bucket")
+		Expect(meta.FindStatusCondition(bucket.Status.Conditions, miniov1beta1.ConditionPaused)).To(BeNil())
+	})
+})
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/metrics"
+)
+
+// removedDrift is the drift of a MinIO resource that was created by the controller and has been
+// removed outside of the controller
+var removedDrift = miniov1beta1.DriftEntry{Field: "exists", Desired: "true", Actual: "false"}
+
+// correctsDrift reports whether drift is corrected for the given management and drift policies.
+// Drift is only reported if the management policy does not allow updates.
+func correctsDrift(managementPolicy miniov1beta1.ManagementPolicy, driftPolicy miniov1beta1.DriftPolicy) bool {
+	return managementPolicy.Updates() && driftPolicy != miniov1beta1.DriftPolicyReport
+}
+
+// specSynced reports whether the current spec of obj has been applied by the last successful
+// reconciliation. Only then are differences to the live state drift rather than spec changes.
+func specSynced(obj conditionsAccessor) bool {
+	ready := meta.FindStatusCondition(obj.GetConditions(), miniov1beta1.ConditionReady)
+	return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == obj.GetGeneration()
+}
+
+// reportDrift records drift of obj in the Drifted condition and in status. Corrected drift is
+// counted and cleared from status, drift that is only reported is kept until it disappears.
+func reportDrift(obj conditionsAccessor, kind string, status *[]miniov1beta1.DriftEntry, drift []miniov1beta1.DriftEntry, corrected bool) {
+	if len(drift) == 0 {
+		removeCondition(obj, miniov1beta1.ConditionDrifted)
+		*status = nil
+		return
+	}
+
+	fields := make([]string, 0, len(drift))
+	for _, entry := range drift {
+		fields = append(fields, entry.Field)
+	}
+
+	if corrected {
+		metrics.DriftDetectedTotal.WithLabelValues(kind).Inc()
+		metrics.DriftCorrectionsTotal.WithLabelValues(kind).Inc()
+		setCondition(obj, miniov1beta1.ConditionDrifted, metav1.ConditionFalse, reasonDriftCorrected,
+			fmt.Sprintf("Corrected drift of %s", strings.Join(fields, ", ")))
+		*status = nil
+		return
+	}
+
+	// Drift that is still reported is only counted once
+	if !equality.Semantic.DeepEqual(*status, drift) {
+		metrics.DriftDetectedTotal.WithLabelValues(kind).Inc()
+	}
+	setCondition(obj, miniov1beta1.ConditionDrifted, metav1.ConditionTrue, reasonDriftDetected,
+		fmt.Sprintf("Live state differs from the desired state in %s", strings.Join(fields, ", ")))
+	*status = drift
+}
+
+// tagsDrift returns the drift between desired and live tags, one entry per differing tag
+func tagsDrift(desired, actual map[string]string) []miniov1beta1.DriftEntry {
+	keys := make([]string, 0, len(desired)+len(actual))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, ok := desired[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var drift []miniov1beta1.DriftEntry
+	for _, key := range keys {
+		desiredValue, desiredOK := desired[key]
+		actualValue, actualOK := actual[key]
+		if desiredOK != actualOK || desiredValue != actualValue {
+			drift = append(drift, miniov1beta1.DriftEntry{Field: "tags." + key, Desired: desiredValue, Actual: actualValue})
+		}
+	}
+	return drift
+}
+
+// normalizedStatement is a policy statement in a canonical form that is independent of
+// the formatting of the document, e.g. single strings versus lists
+type normalizedStatement struct {
+	Sid          string                           `json:"Sid,omitempty"`
+	Effect       string                           `json:"Effect"`
+	Principal    policyPrincipal                  `json:"Principal,omitempty"`
+	NotPrincipal policyPrincipal                  `json:"NotPrincipal,omitempty"`
+	Action       policyList                       `json:"Action,omitempty"`
+	NotAction    policyList                       `json:"NotAction,omitempty"`
+	Resource     policyList                       `json:"Resource,omitempty"`
+	NotResource  policyList                       `json:"NotResource,omitempty"`
+	Condition    map[string]map[string]policyList `json:"Condition,omitempty"`
+}
+
+// policyList is a JSON value that is either a single string or a list of strings
+type policyList []string
+
+// UnmarshalJSON implements json.Unmarshaler and sorts the values
+func (l *policyList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = policyList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected a string or a list of strings")
+	}
+	slices.Sort(list)
+	*l = slices.Compact(list)
+	return nil
+}
+
+// policyPrincipal is the principal of a bucket policy statement, either "*" or a map of
+// principal types to single strings or lists of strings
+type policyPrincipal map[string]policyList
+
+// UnmarshalJSON implements json.Unmarshaler and treats "*" as any AWS principal
+func (p *policyPrincipal) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*p = policyPrincipal{"AWS": policyList{single}}
+		return nil
+	}
+	var principal map[string]policyList
+	if err := json.Unmarshal(data, &principal); err != nil {
+		return fmt.Errorf("expected \"*\" or a map of principals")
+	}
+	*p = principal
+	return nil
+}
+
+// policyDigest returns a hash of the canonical form of a policy document. The ID holding the owner
+// marker is ignored.
+func policyDigest(document []byte) (string, error) {
+	var policy struct {
+		Version   string                `json:"Version"`
+		Statement []normalizedStatement `json:"Statement"`
+	}
+	if err := json.NewDecoder(bytes.NewReader(document)).Decode(&policy); err != nil {
+		return "", fmt.Errorf("failed to parse policy document: %w", err)
+	}
+	canonical, err := json.Marshal(policy)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// policyDrift returns the drift between a desired and a live policy document
+func policyDrift(desired, actual []byte) ([]miniov1beta1.DriftEntry, error) {
+	desiredDigest, err := policyDigest(desired)
+	if err != nil {
+		return nil, err
+	}
+	actualDigest, err := policyDigest(actual)
+	if err != nil {
+		return nil, err
+	}
+	if desiredDigest == actualDigest {
+		return nil, nil
+	}
+	return []miniov1beta1.DriftEntry{{Field: "policy", Desired: "sha256:" + desiredDigest, Actual: "sha256:" + actualDigest}}, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Drift detection", func() {
+	It("should report one entry per differing tag", func() {
+		drift := tagsDrift(
+			map[string]string{"team": "backend", "env": "production"},
+			map[string]string{"team": "frontend", "owner": "ops"},
+		)
+		Expect(drift).To(Equal([]miniov1beta1.DriftEntry{
+			{Field: "tags.env", Desired: "production"},
+			{Field: "tags.owner", Actual: "ops"},
+			{Field: "tags.team", Desired: "backend", Actual: "frontend"},
+		}))
+	})
+
+	It("should ignore the formatting and owner marker of policy documents", func() {
+		desired := []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":["arn:aws:s3:::data/*"]}]}`)
+		live := []byte(`{"ID":"mc-controller.mxcd.de/owner=uid-1","Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::data/*"]}]}`)
+		drift, err := policyDrift(desired, live)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drift).To(BeEmpty())
+
+		edited := []byte(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:*"],"Resource":["arn:aws:s3:::data/*"]}]}`)
+		drift, err = policyDrift(desired, edited)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(drift).To(HaveLen(1))
+		Expect(drift[0].Field).To(Equal("policy"))
+	})
+
+	It("should only correct drift if the management policy allows updates", func() {
+		Expect(correctsDrift(miniov1beta1.ManagementPolicyFull, "")).To(BeTrue())
+		Expect(correctsDrift(miniov1beta1.ManagementPolicyFull, miniov1beta1.DriftPolicyReport)).To(BeFalse())
+		Expect(correctsDrift(miniov1beta1.ManagementPolicyObserve, miniov1beta1.DriftPolicyCorrect)).To(BeFalse())
+	})
+
+	It("should record reported and corrected drift", func() {
+		bucket := &miniov1beta1.Bucket{ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Generation: 1}}
+		drift := []miniov1beta1.DriftEntry{{Field: "versioning", Desired: "Enabled", Actual: "Suspended"}}
+
+		reportDrift(bucket, "Bucket", &bucket.Status.Drift, drift, false)
+		Expect(bucket.Status.Drift).To(Equal(drift))
+		Expect(meta.IsStatusConditionTrue(bucket.Status.Conditions, miniov1beta1.ConditionDrifted)).To(BeTrue())
+
+		reportDrift(bucket, "Bucket", &bucket.Status.Drift, drift, true)
+		Expect(bucket.Status.Drift).To(BeEmpty())
+		Expect(meta.IsStatusConditionFalse(bucket.Status.Conditions, miniov1beta1.ConditionDrifted)).To(BeTrue())
+
+		reportDrift(bucket, "Bucket", &bucket.Status.Drift, nil, true)
+		Expect(meta.FindStatusCondition(bucket.Status.Conditions, miniov1beta1.ConditionDrifted)).To(BeNil())
+	})
+
+	It("should only treat differences to an applied spec as drift", func() {
+		bucket := &miniov1beta1.Bucket{ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Generation: 1}}
+		Expect(specSynced(bucket)).To(BeFalse())
+
+		markReady(bucket, "Bucket is ready")
+		Expect(specSynced(bucket)).To(BeTrue())
+
+		bucket.Generation = 2
+		Expect(specSynced(bucket)).To(BeFalse())
+	})
+})
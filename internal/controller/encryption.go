@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/sse"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// Algorithms of the default bucket encryption
+const (
+	encryptionAlgorithmSSES3  = "AES256"
+	encryptionAlgorithmSSEKMS = "aws:kms"
+)
+
+// kmsKeyARNPrefix is the prefix MinIO may report in front of KMS key IDs
+const kmsKeyARNPrefix = "arn:aws:kms:"
+
+// kmsNotConfiguredError is returned when bucket encryption requires a KMS that the server does not have
+type kmsNotConfiguredError struct {
+	bucketName string
+}
+
+// Error implements error
+func (e *kmsNotConfiguredError) Error() string {
+	return fmt.Sprintf("encryption of bucket %s requires a KMS, but the MinIO server has no KMS configured", e.bucketName)
+}
+
+// encryptionConfiguration returns the desired default encryption of a bucket, nil if it should have none
+func encryptionConfiguration(encryption *miniov1beta1.BucketEncryption) *sse.Configuration {
+	switch encryption.Type {
+	case miniov1beta1.BucketEncryptionSSES3:
+		return sse.NewConfigurationSSES3()
+	case miniov1beta1.BucketEncryptionSSEKMS:
+		return sse.NewConfigurationSSEKMS(encryption.KMSKeyID)
+	default:
+		return nil
+	}
+}
+
+// encryptionSynced reports whether the live default encryption in status matches the spec
+func encryptionSynced(bucket *miniov1beta1.Bucket) bool {
+	status := bucket.Status
+	switch bucket.Spec.Encryption.Type {
+	case miniov1beta1.BucketEncryptionSSES3:
+		return status.EncryptionAlgorithm == encryptionAlgorithmSSES3
+	case miniov1beta1.BucketEncryptionSSEKMS:
+		return status.EncryptionAlgorithm == encryptionAlgorithmSSEKMS &&
+			(bucket.Spec.Encryption.KMSKeyID == "" || status.KMSKeyID == bucket.Spec.Encryption.KMSKeyID)
+	default:
+		return status.EncryptionAlgorithm == ""
+	}
+}
+
+// encryptionValue formats a default encryption for drift reports
+func encryptionValue(algorithm, kmsKeyID string) string {
+	switch {
+	case algorithm == "":
+		return "none"
+	case kmsKeyID != "":
+		return algorithm + ":" + kmsKeyID
+	default:
+		return algorithm
+	}
+}
+
+// encryptionDrift returns the drift between the desired and the live default encryption of a bucket
+func encryptionDrift(bucket *miniov1beta1.Bucket) []miniov1beta1.DriftEntry {
+	if bucket.Spec.Encryption == nil || encryptionSynced(bucket) {
+		return nil
+	}
+	desired := encryptionValue(observedEncryption(encryptionConfiguration(bucket.Spec.Encryption)))
+	return []miniov1beta1.DriftEntry{{
+		Field:   "encryption",
+		Desired: desired,
+		Actual:  encryptionValue(bucket.Status.EncryptionAlgorithm, bucket.Status.KMSKeyID),
+	}}
+}
+
+// observedEncryption returns the algorithm and KMS key of a live default encryption configuration
+func observedEncryption(config *sse.Configuration) (string, string) {
+	if config == nil || len(config.Rules) == 0 {
+		return "", ""
+	}
+	apply := config.Rules[0].Apply
+	return apply.SSEAlgorithm, strings.TrimPrefix(apply.KmsMasterKeyID, kmsKeyARNPrefix)
+}
+
+// isKMSNotConfigured reports whether err is the error of a MinIO server without KMS
+func isKMSNotConfigured(err error) bool {
+	response := minio.ToErrorResponse(err)
+	return response.Code == "NotImplemented" && strings.Contains(response.Message, "KMS is not configured")
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/minio/minio-go/v7"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Bucket encryption", func() {
+	var bucket *miniov1beta1.Bucket
+
+	BeforeEach(func() {
+		bucket = &miniov1beta1.Bucket{Spec: miniov1beta1.BucketSpec{BucketName: "data"}}
+	})
+
+	It("should report drift to the desired key", func() {
+		bucket.Spec.Encryption = &miniov1beta1.BucketEncryption{Type: miniov1beta1.BucketEncryptionSSEKMS, KMSKeyID: "key-2"}
+		bucket.Status.EncryptionAlgorithm = encryptionAlgorithmSSEKMS
+		bucket.Status.KMSKeyID = "key-1"
+		Expect(encryptionDrift(bucket)).To(Equal([]miniov1beta1.DriftEntry{
+			{Field: "encryption", Desired: "aws:kms:key-2", Actual: "aws:kms:key-1"},
+		}))
+
+		bucket.Status.KMSKeyID = "key-2"
+		Expect(encryptionDrift(bucket)).To(BeEmpty())
+	})
+
+	It("should accept any key if SSE-KMS has no key", func() {
+		bucket.Spec.Encryption = &miniov1beta1.BucketEncryption{Type: miniov1beta1.BucketEncryptionSSEKMS}
+		bucket.Status.EncryptionAlgorithm = encryptionAlgorithmSSEKMS
+		bucket.Status.KMSKeyID = "default-key"
+		Expect(encryptionSynced(bucket)).To(BeTrue())
+	})
+
+	It("should report live encryption if it should be removed", func() {
+		bucket.Spec.Encryption = &miniov1beta1.BucketEncryption{Type: miniov1beta1.BucketEncryptionNone}
+		bucket.Status.EncryptionAlgorithm = encryptionAlgorithmSSES3
+		Expect(encryptionDrift(bucket)).To(Equal([]miniov1beta1.DriftEntry{
+			{Field: "encryption", Desired: "none", Actual: "AES256"},
+		}))
+	})
+
+	It("should not manage encryption that is not set", func() {
+		bucket.Status.EncryptionAlgorithm = encryptionAlgorithmSSES3
+		Expect(encryptionDrift(bucket)).To(BeEmpty())
+	})
+
+	It("should recognize a server without KMS", func() {
+		err := minio.ErrorResponse{Code: "NotImplemented", Message: "Server side encryption specified but KMS is not configured"}
+		Expect(isKMSNotConfigured(err)).To(BeTrue())
+		Expect(isKMSNotConfigured(minio.ErrorResponse{Code: "NotImplemented"})).To(BeFalse())
+		Expect(errorReason(&kmsNotConfiguredError{bucketName: "data"}, reasonReconcileError)).To(Equal(reasonKMSNotConfigured))
+	})
+})
@@ -19,17 +19,22 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 	minioclient "github.com/mxcd/mc-controller/internal/minio"
 )
 
@@ -43,6 +48,13 @@ type EndpointReconciler struct {
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=endpoints/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=endpoints/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliases,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliasgrants,verbs=patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=buckets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=users,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policies,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policyattachments,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=lifecyclepolicies,verbs=get;list;watch;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -61,6 +73,11 @@ func (r *EndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
+	// Paused resources are left untouched, including their deletion
+	if paused, result, err := checkPaused(ctx, r.Client, endpoint, nil); paused {
+		return result, err
+	}
+
 	// Handle deletion
 	if endpoint.DeletionTimestamp != nil {
 		return r.handleDeletion(ctx, endpoint)
@@ -73,28 +90,46 @@ func (r *EndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	// Update status to indicate reconciliation is in progress
-	miniov1alpha1.SetCondition(&endpoint.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionTrue, "Reconciling", "Reconciling endpoint")
-	endpoint.Status.ObservedGeneration = endpoint.Generation
-	if err := r.Status().Update(ctx, endpoint); err != nil {
-		logger.Error(err, "Failed to update status")
-		return ctrl.Result{}, err
+	patch := client.MergeFrom(endpoint.DeepCopy())
+	if markReconciling(endpoint, "Reconciling endpoint") {
+		endpoint.Status.ObservedGeneration = endpoint.Generation
+		if err := r.Status().Patch(ctx, endpoint, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(endpoint.DeepCopy())
+
+	// Migrate to an Alias if requested and count the resources still using the Endpoint
+	if err := r.reconcileMigration(ctx, endpoint); err != nil {
+		logger.Error(err, "Failed to migrate endpoint")
+		markFailed(endpoint, reasonReconcileError, fmt.Sprintf("Failed to migrate endpoint: %v", err))
+		endpoint.Status.Ready = false
+		if err := r.Status().Patch(ctx, endpoint, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Create a temporary connection config for health checking
-	conn := miniov1alpha1.MinIOConnection{
+	conn := miniov1beta1.MinIOConnection{
 		URL:       &endpoint.Spec.URL,
-		SecretRef: &endpoint.Spec.SecretRef,
-		TLS:       endpoint.Spec.TLS,
+		SecretRef: (*miniov1beta1.SecretReference)(&endpoint.Spec.SecretRef),
+		TLS:       (*miniov1beta1.TLSConfig)(endpoint.Spec.TLS),
 	}
 
 	// Create MinIO client for health checking
 	minioClient, err := minioclient.NewClient(ctx, r.Client, conn, endpoint.Namespace)
 	if err != nil {
 		logger.Error(err, "Failed to create MinIO client")
-		miniov1alpha1.SetCondition(&endpoint.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ClientError", fmt.Sprintf("Failed to create MinIO client: %v", err))
+		markFailed(endpoint, errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err))
 		endpoint.Status.Ready = false
 		endpoint.Status.Healthy = false
-		r.Status().Update(ctx, endpoint)
+		if err := r.Status().Patch(ctx, endpoint, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
@@ -102,22 +137,23 @@ func (r *EndpointReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	result, err := r.reconcileEndpoint(ctx, endpoint, minioClient)
 	if err != nil {
 		logger.Error(err, "Failed to reconcile endpoint")
-		miniov1alpha1.SetCondition(&endpoint.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ReconcileError", fmt.Sprintf("Failed to reconcile endpoint: %v", err))
+		markFailed(endpoint, reasonReconcileError, fmt.Sprintf("Failed to reconcile endpoint: %v", err))
 		endpoint.Status.Ready = false
 		endpoint.Status.Healthy = false
 		endpoint.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-		r.Status().Update(ctx, endpoint)
+		if err := r.Status().Patch(ctx, endpoint, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return result, err
 	}
 
 	// Update status to ready
-	miniov1alpha1.SetCondition(&endpoint.Status.Conditions, miniov1alpha1.ConditionReady, metav1.ConditionTrue, "Ready", "Endpoint is ready")
-	miniov1alpha1.SetCondition(&endpoint.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionFalse, "Ready", "Endpoint reconciliation completed")
+	markReady(endpoint, "Endpoint is ready")
 	endpoint.Status.Ready = true
 	endpoint.Status.URL = endpoint.Spec.URL
 	endpoint.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
 
-	if err := r.Status().Update(ctx, endpoint); err != nil {
+	if err := r.Status().Patch(ctx, endpoint, patch); err != nil {
 		logger.Error(err, "Failed to update status to ready")
 		return ctrl.Result{}, err
 	}
@@ -187,9 +223,238 @@ func (r *EndpointReconciler) reconcileEndpoint(ctx context.Context, endpoint *mi
 	return ctrl.Result{RequeueAfter: interval}, nil
 }
 
+// reconcileMigration counts the resources still connecting through the Endpoint and, when the
+// migration is enabled, creates the Alias and rewrites those resources to reference it once the Alias
+// is ready. AliasGrants permitting other namespaces to use the Endpoint are extended to the Alias first.
+func (r *EndpointReconciler) reconcileMigration(ctx context.Context, endpoint *miniov1alpha1.Endpoint) error {
+	logger := log.FromContext(ctx)
+
+	dependents, err := r.listDependents(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	endpoint.Status.Dependents = int32(len(dependents))
+
+	if endpoint.Spec.Migration == nil || !endpoint.Spec.Migration.Enabled {
+		return nil
+	}
+
+	aliasName := endpoint.Name
+	if endpoint.Spec.Migration.AliasName != nil {
+		aliasName = *endpoint.Spec.Migration.AliasName
+	}
+
+	// Create the Alias unless it already exists
+	alias := &miniov1beta1.Alias{}
+	err = r.Get(ctx, client.ObjectKey{Name: aliasName, Namespace: endpoint.Namespace}, alias)
+	if apierrors.IsNotFound(err) {
+		alias = aliasForEndpoint(endpoint, aliasName)
+		if err := r.Create(ctx, alias); err != nil {
+			return fmt.Errorf("failed to create alias %s: %w", aliasName, err)
+		}
+		logger.Info("Created alias for endpoint", "alias", aliasName)
+	} else if err != nil {
+		return fmt.Errorf("failed to get alias %s: %w", aliasName, err)
+	}
+
+	if endpoint.Status.Migration == nil || endpoint.Status.Migration.AliasName != aliasName {
+		endpoint.Status.Migration = &miniov1alpha1.EndpointMigrationStatus{
+			AliasName:  aliasName,
+			MigratedAt: &metav1.Time{Time: time.Now()},
+		}
+	}
+
+	// Dependents are only moved to an Alias that can serve them, the Alias watch resumes the migration
+	if !alias.Status.Ready {
+		logger.Info("Waiting for the alias to become ready before migrating dependents", "alias", aliasName)
+		return nil
+	}
+
+	// Dependents in other namespaces keep the access the Endpoint granted them
+	if err := r.grantAlias(ctx, endpoint, aliasName); err != nil {
+		return err
+	}
+
+	// Rewrite the dependents, the ones that fail are retried on the next reconciliation
+	for _, dependent := range dependents {
+		if err := r.migrateDependent(ctx, dependent, endpoint, aliasName); err != nil {
+			logger.Error(err, "Failed to migrate dependent", "dependent", client.ObjectKeyFromObject(dependent))
+			continue
+		}
+		logger.Info("Migrated dependent to alias", "dependent", client.ObjectKeyFromObject(dependent), "alias", aliasName)
+		endpoint.Status.Migration.MigratedDependents++
+		endpoint.Status.Dependents--
+	}
+
+	return nil
+}
+
+// grantAlias adds the Alias to the AliasGrants that permit references to the Endpoint
+func (r *EndpointReconciler) grantAlias(ctx context.Context, endpoint *miniov1alpha1.Endpoint, aliasName string) error {
+	grants := &miniov1beta1.AliasGrantList{}
+	if err := r.List(ctx, grants, client.InNamespace(endpoint.Namespace)); err != nil {
+		return fmt.Errorf("failed to list alias grants: %w", err)
+	}
+	for i := range grants.Items {
+		grant := &grants.Items[i]
+		if !grantsTo(grant, miniov1beta1.AliasGrantKindEndpoint, endpoint.Name) || grantsTo(grant, miniov1beta1.AliasGrantKindAlias, aliasName) {
+			continue
+		}
+
+		patch := client.MergeFrom(grant.DeepCopy())
+		name := aliasName
+		grant.Spec.To = append(grant.Spec.To, miniov1beta1.AliasGrantTo{Kind: miniov1beta1.AliasGrantKindAlias, Name: &name})
+		if err := r.Patch(ctx, grant, patch); err != nil {
+			return fmt.Errorf("failed to grant alias %s in alias grant %s: %w", aliasName, grant.Name, err)
+		}
+		log.FromContext(ctx).Info("Granted alias in alias grant of endpoint", "aliasGrant", grant.Name, "alias", aliasName)
+	}
+	return nil
+}
+
+// grantsTo reports whether a grant permits references to the named resource of kind from any namespace
+func grantsTo(grant *miniov1beta1.AliasGrant, kind, name string) bool {
+	return slices.ContainsFunc(grant.Spec.To, func(to miniov1beta1.AliasGrantTo) bool {
+		return to.Kind == kind && (to.Name == nil || *to.Name == name)
+	})
+}
+
+// listDependents lists the resources connecting through the Endpoint
+func (r *EndpointReconciler) listDependents(ctx context.Context, endpoint *miniov1alpha1.Endpoint) ([]client.Object, error) {
+	var dependents []client.Object
+	for _, list := range []client.ObjectList{
+		&miniov1beta1.BucketList{},
+		&miniov1beta1.UserList{},
+		&miniov1beta1.PolicyList{},
+		&miniov1beta1.PolicyAttachmentList{},
+		&miniov1beta1.LifecyclePolicyList{},
+	} {
+		if err := r.List(ctx, list); err != nil {
+			return nil, fmt.Errorf("failed to list dependents: %w", err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list dependents: %w", err)
+		}
+		for _, item := range items {
+			obj := item.(client.Object)
+			if ref, ok := endpointRefOf(obj); ok && ref == client.ObjectKeyFromObject(endpoint) {
+				dependents = append(dependents, obj)
+			}
+		}
+	}
+	return dependents, nil
+}
+
+// migrateDependent replaces the endpointRef of a dependent with a reference to the Alias
+func (r *EndpointReconciler) migrateDependent(ctx context.Context, obj client.Object, endpoint *miniov1alpha1.Endpoint, aliasName string) error {
+	conn := connectionOf(obj)
+	if conn == nil {
+		return fmt.Errorf("unsupported dependent %T", obj)
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	annotations := obj.GetAnnotations()
+	delete(annotations, miniov1beta1.LegacyEndpointRefAnnotation)
+	obj.SetAnnotations(annotations)
+
+	conn.AliasRef = &miniov1beta1.AliasReference{Name: aliasName}
+	if obj.GetNamespace() != endpoint.Namespace {
+		namespace := endpoint.Namespace
+		conn.AliasRef.Namespace = &namespace
+	}
+
+	return r.Patch(ctx, obj, patch)
+}
+
+// aliasForEndpoint builds an Alias with the connection settings of an Endpoint
+func aliasForEndpoint(endpoint *miniov1alpha1.Endpoint, name string) *miniov1beta1.Alias {
+	description := fmt.Sprintf("Migrated from Endpoint %s", endpoint.Name)
+	return &miniov1beta1.Alias{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: endpoint.Namespace,
+		},
+		Spec: miniov1beta1.AliasSpec{
+			URL:         endpoint.Spec.URL,
+			SecretRef:   miniov1beta1.SecretReference(endpoint.Spec.SecretRef),
+			TLS:         (*miniov1beta1.TLSConfig)(endpoint.Spec.TLS),
+			HealthCheck: (*miniov1beta1.AliasHealthCheck)(endpoint.Spec.HealthCheck),
+			Region:      endpoint.Spec.Region,
+			PathStyle:   endpoint.Spec.PathStyle,
+			Description: &description,
+			Tags:        endpoint.Spec.Tags,
+		},
+	}
+}
+
+// endpointRefOf returns the Endpoint a resource connects through, if any
+func endpointRefOf(obj client.Object) (client.ObjectKey, bool) {
+	value, ok := obj.GetAnnotations()[miniov1beta1.LegacyEndpointRefAnnotation]
+	if !ok {
+		return client.ObjectKey{}, false
+	}
+
+	ref := miniov1alpha1.ParseEndpointReference(value)
+	key := client.ObjectKey{Name: ref.Name, Namespace: obj.GetNamespace()}
+	if ref.Namespace != nil {
+		key.Namespace = *ref.Namespace
+	}
+	return key, true
+}
+
+// connectionOf returns the MinIO connection of a resource
+func connectionOf(obj client.Object) *miniov1beta1.MinIOConnection {
+	switch o := obj.(type) {
+	case *miniov1beta1.Bucket:
+		return &o.Spec.Connection
+	case *miniov1beta1.User:
+		return &o.Spec.Connection
+	case *miniov1beta1.Policy:
+		return &o.Spec.Connection
+	case *miniov1beta1.PolicyAttachment:
+		return &o.Spec.Connection
+	case *miniov1beta1.LifecyclePolicy:
+		return &o.Spec.Connection
+	}
+	return nil
+}
+
+// endpointForDependent maps a resource to the Endpoint it connects through
+func endpointForDependent(ctx context.Context, obj client.Object) []reconcile.Request {
+	key, ok := endpointRefOf(obj)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: key}}
+}
+
+// endpointsForAlias maps an Alias to the Endpoints of its namespace migrating to it
+func (r *EndpointReconciler) endpointsForAlias(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1alpha1.EndpointList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list endpoints")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Status.Migration != nil && item.Status.Migration.AliasName == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *EndpointReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	dependents := handler.EnqueueRequestsFromMapFunc(endpointForDependent)
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&miniov1alpha1.Endpoint{}).
+		Watches(&miniov1beta1.Alias{}, handler.EnqueueRequestsFromMapFunc(r.endpointsForAlias)).
+		Watches(&miniov1beta1.Bucket{}, dependents).
+		Watches(&miniov1beta1.User{}, dependents).
+		Watches(&miniov1beta1.Policy{}, dependents).
+		Watches(&miniov1beta1.PolicyAttachment{}, dependents).
+		Watches(&miniov1beta1.LifecyclePolicy{}, dependents).
 		Complete(r)
 }
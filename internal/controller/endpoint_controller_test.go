@@ -22,12 +22,16 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 )
 
 var _ = Describe("Endpoint Controller", func() {
@@ -81,4 +85,134 @@ var _ = Describe("Endpoint Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When migrating to an Alias", func() {
+		ctx := context.Background()
+
+		newReconciler := func(objs ...client.Object) *EndpointReconciler {
+			scheme := runtime.NewScheme()
+			Expect(miniov1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			return &EndpointReconciler{Client: c, Scheme: scheme}
+		}
+
+		newEndpoint := func() *miniov1alpha1.Endpoint {
+			return &miniov1alpha1.Endpoint{
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy-endpoint", Namespace: "default"},
+				Spec: miniov1alpha1.EndpointSpec{
+					URL:       "https://minio.example.com",
+					SecretRef: miniov1alpha1.SecretReference{Name: "minio-credentials"},
+					Migration: &miniov1alpha1.EndpointMigration{Enabled: true},
+				},
+			}
+		}
+
+		newBucket := func(namespace, endpointRef string) *miniov1beta1.Bucket {
+			return &miniov1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "legacy-bucket",
+					Namespace:   namespace,
+					Annotations: map[string]string{miniov1beta1.LegacyEndpointRefAnnotation: endpointRef},
+				},
+				Spec: miniov1beta1.BucketSpec{BucketName: "legacy-bucket"},
+			}
+		}
+
+		readyAlias := func() *miniov1beta1.Alias {
+			return &miniov1beta1.Alias{
+				ObjectMeta: metav1.ObjectMeta{Name: "legacy-endpoint", Namespace: "default"},
+				Spec:       miniov1beta1.AliasSpec{URL: "https://minio.example.com"},
+				Status:     miniov1beta1.AliasStatus{Ready: true},
+			}
+		}
+
+		It("should create the Alias and wait for it to become ready", func() {
+			endpoint := newEndpoint()
+			bucket := newBucket("default", "legacy-endpoint")
+			r := newReconciler(endpoint, bucket)
+
+			Expect(r.reconcileMigration(ctx, endpoint)).To(Succeed())
+			Expect(endpoint.Status.Dependents).To(Equal(int32(1)))
+			Expect(endpoint.Status.Migration.AliasName).To(Equal("legacy-endpoint"))
+			Expect(endpoint.Status.Migration.MigratedDependents).To(BeZero())
+
+			alias := &miniov1beta1.Alias{}
+			Expect(r.Get(ctx, types.NamespacedName{Name: "legacy-endpoint", Namespace: "default"}, alias)).To(Succeed())
+			Expect(alias.Spec.URL).To(Equal(endpoint.Spec.URL))
+
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(bucket), bucket)).To(Succeed())
+			Expect(bucket.Annotations).To(HaveKey(miniov1beta1.LegacyEndpointRefAnnotation))
+			Expect(bucket.Spec.Connection.AliasRef).To(BeNil())
+		})
+
+		It("should rewrite dependents once the Alias is ready", func() {
+			endpoint := newEndpoint()
+			bucket := newBucket("default", "legacy-endpoint")
+			r := newReconciler(endpoint, bucket, readyAlias())
+
+			Expect(r.reconcileMigration(ctx, endpoint)).To(Succeed())
+			Expect(endpoint.Status.Dependents).To(BeZero())
+			Expect(endpoint.Status.Migration.MigratedDependents).To(Equal(int32(1)))
+
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(bucket), bucket)).To(Succeed())
+			Expect(bucket.Annotations).NotTo(HaveKey(miniov1beta1.LegacyEndpointRefAnnotation))
+			Expect(bucket.Spec.Connection.AliasRef).To(Equal(&miniov1beta1.AliasReference{Name: "legacy-endpoint"}))
+		})
+
+		It("should grant the Alias to namespaces granted the Endpoint", func() {
+			endpoint := newEndpoint()
+			bucket := newBucket("team-a", "default/legacy-endpoint")
+			name := "legacy-endpoint"
+			grant := &miniov1beta1.AliasGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default"},
+				Spec: miniov1beta1.AliasGrantSpec{
+					From: []miniov1beta1.AliasGrantFrom{{Namespace: "team-a"}},
+					To:   []miniov1beta1.AliasGrantTo{{Kind: miniov1beta1.AliasGrantKindEndpoint, Name: &name}},
+				},
+			}
+			unrelated := &miniov1beta1.AliasGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "secrets", Namespace: "default"},
+				Spec: miniov1beta1.AliasGrantSpec{
+					From: []miniov1beta1.AliasGrantFrom{{Namespace: "team-b"}},
+					To:   []miniov1beta1.AliasGrantTo{{Kind: miniov1beta1.AliasGrantKindSecret}},
+				},
+			}
+			r := newReconciler(endpoint, bucket, readyAlias(), grant, unrelated)
+
+			Expect(r.reconcileMigration(ctx, endpoint)).To(Succeed())
+			Expect(endpoint.Status.Migration.MigratedDependents).To(Equal(int32(1)))
+
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(grant), grant)).To(Succeed())
+			Expect(grant.Permits("team-a", miniov1beta1.AliasGrantKindAlias, "legacy-endpoint")).To(BeTrue())
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(unrelated), unrelated)).To(Succeed())
+			Expect(unrelated.Spec.To).To(HaveLen(1))
+
+			namespace := "default"
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(bucket), bucket)).To(Succeed())
+			Expect(bucket.Spec.Connection.AliasRef).To(Equal(&miniov1beta1.AliasReference{Name: "legacy-endpoint", Namespace: &namespace}))
+
+			// A second pass leaves the converted grant alone
+			Expect(r.grantAlias(ctx, endpoint, "legacy-endpoint")).To(Succeed())
+			Expect(r.Get(ctx, client.ObjectKeyFromObject(grant), grant)).To(Succeed())
+			Expect(grant.Spec.To).To(HaveLen(2))
+		})
+
+		It("should resolve the Endpoint referenced by a dependent", func() {
+			bucket := &miniov1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "legacy-bucket",
+					Namespace:   "team-a",
+					Annotations: map[string]string{miniov1beta1.LegacyEndpointRefAnnotation: "minio-system/minio"},
+				},
+			}
+			key, ok := endpointRefOf(bucket)
+			Expect(ok).To(BeTrue())
+			Expect(key).To(Equal(types.NamespacedName{Name: "minio", Namespace: "minio-system"}))
+
+			bucket.Annotations[miniov1beta1.LegacyEndpointRefAnnotation] = "minio"
+			key, _ = endpointRefOf(bucket)
+			Expect(key).To(Equal(types.NamespacedName{Name: "minio", Namespace: "team-a"}))
+		})
+	})
 })
@@ -0,0 +1,347 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// IdentityProviderReconciler reconciles an IdentityProvider object
+type IdentityProviderReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all identity providers
+	DryRun bool
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=identityproviders,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=identityproviders/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=identityproviders/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile writes the configuration of the identity provider to MinIO and restarts the server if required
+func (r *IdentityProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	connection := func(provider *miniov1beta1.IdentityProvider) *miniov1beta1.MinIOConnection {
+		return &provider.Spec.Connection
+	}
+	active := false
+	lifecycle := &resourceLifecycle[*miniov1beta1.IdentityProvider, *minioclient.Client]{
+		Client:    r.Client,
+		Recorder:  r.Recorder,
+		DryRun:    r.DryRun,
+		name:      "identity provider",
+		finalizer: miniov1beta1.IdentityProviderFinalizer,
+		status: func(provider *miniov1beta1.IdentityProvider) resourceStatus {
+			return resourceStatus{&provider.Status.Ready, &provider.Status.ObservedGeneration, &provider.Status.LastSyncTime, &provider.Status.PlannedChanges}
+		},
+		connections: singleConnection(connection),
+		delete:      r.handleDeletion,
+		connect:     clientFor(r.Client, connection),
+		reconcile: func(ctx context.Context, provider *miniov1beta1.IdentityProvider, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+			var result ctrl.Result
+			var err error
+			active, result, err = r.reconcileProvider(ctx, provider, minioClient, plan)
+			return result, err
+		},
+		// The provider is active once the server runs the configuration
+		ready: func(provider *miniov1beta1.IdentityProvider) (bool, string, string) {
+			switch {
+			case provider.Status.RestartRequired:
+				return false, reasonRestartRequired, "The MinIO server must be restarted to activate the identity provider"
+			case !active:
+				return false, reasonProviderInactive, "The identity provider is not enabled on the MinIO server"
+			default:
+				return true, "", "Identity provider is active"
+			}
+		},
+	}
+	return lifecycle.run(ctx, req, &miniov1beta1.IdentityProvider{})
+}
+
+// handleDeletion removes the configuration from MinIO if it was created by the controller
+func (r *IdentityProviderReconciler) handleDeletion(ctx context.Context, provider *miniov1beta1.IdentityProvider) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(provider, miniov1beta1.IdentityProviderFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, provider)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		configType, configName := string(provider.Spec.Type), identityProviderConfigName(provider)
+		switch {
+		case violation:
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+		case provider.Status.CreationDate == nil:
+			// The configuration carries no owner marker, so adopted configurations are kept
+			logger.Info("Keeping identity provider that was not created by this resource", "type", configType, "configName", configName)
+		default:
+			minioClient, err := newMinIOClient(ctx, r.Client, provider, provider.Spec.Connection)
+			if err != nil {
+				logger.Error(err, "Failed to create MinIO client for deletion, retrying")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+
+			item, err := liveIdentityProvider(ctx, minioClient.Admin, configType, configName)
+			if err != nil {
+				logger.Error(err, "Failed to get identity provider, will retry")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if item != nil {
+				plan := newChangePlan(r.DryRun, provider)
+				restart := false
+				err = plan.apply(fmt.Sprintf("remove %s identity provider %s", configType, configName), func() error {
+					restart, err = minioClient.Admin.DeleteIDPConfig(ctx, configType, configName)
+					return err
+				})
+				if err == nil && restart && provider.Spec.RestartPolicy != miniov1beta1.RestartPolicyNever {
+					err = plan.apply("restart MinIO server to deactivate the identity provider", func() error {
+						return minioClient.Admin.ServiceRestartV2(ctx)
+					})
+				}
+				if err != nil {
+					logger.Error(err, "Failed to remove identity provider, will retry", "type", configType, "configName", configName)
+					return ctrl.Result{RequeueAfter: time.Minute}, nil
+				}
+				if plan.pending() {
+					return reportPlannedDeletion(ctx, r.Client, r.Recorder, provider, &provider.Status.PlannedChanges, plan)
+				}
+				logger.Info("Removed identity provider", "type", configType, "configName", configName)
+			}
+		}
+
+		// Remove finalizer
+		controllerutil.RemoveFinalizer(provider, miniov1beta1.IdentityProviderFinalizer)
+		if err := r.Update(ctx, provider); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileProvider writes the configuration of the identity provider if it differs from the live one
+// and restarts the server if the change requires it. It reports whether the server lists the
+// configuration as enabled.
+func (r *IdentityProviderReconciler) reconcileProvider(ctx context.Context, provider *miniov1beta1.IdentityProvider, minioClient *minioclient.Client, plan *changePlan) (bool, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	configType, configName := string(provider.Spec.Type), identityProviderConfigName(provider)
+	provider.Status.ConfigName = configName
+
+	secretValues, err := configSecretValues(ctx, r.Client, provider.Namespace, provider.Spec.SecretConfig)
+	if err != nil {
+		return false, ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get secret configuration: %w", err)
+	}
+	digest := configSecretsDigest(secretValues)
+
+	item, err := liveIdentityProvider(ctx, minioClient.Admin, configType, configName)
+	if err != nil {
+		return false, ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	var change string
+	if item == nil {
+		change = fmt.Sprintf("add %s identity provider %s", configType, configName)
+	} else {
+		// The configuration carries no owner marker, so only configurations created by this resource or adopted ones are managed
+		if err := checkOwner(provider, "identity provider", configType+" "+configName, "", provider.Spec.Adopt, provider.Status.CreationDate != nil); err != nil {
+			return false, ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		live, err := minioClient.Admin.GetIDPConfig(ctx, configType, configName)
+		if err != nil {
+			return false, ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get %s identity provider %s: %w", configType, configName, err)
+		}
+		if overridden := identityProviderEnvOverrides(live.Info, provider.Spec.Config); len(overridden) > 0 {
+			return false, ctrl.Result{RequeueAfter: time.Hour}, fmt.Errorf("settings %s are set by environment variables of the MinIO server and cannot be changed", strings.Join(overridden, ", "))
+		}
+
+		// Secret values may be redacted in the live configuration, so their changes are detected by their digest
+		if keys := identityProviderMismatch(live.Info, provider.Spec.Config); len(keys) > 0 {
+			change = fmt.Sprintf("set %s of %s identity provider %s", strings.Join(keys, ", "), configType, configName)
+		} else if digest != provider.Status.SecretsHash {
+			change = fmt.Sprintf("update secret configuration of %s identity provider %s", configType, configName)
+		}
+	}
+
+	if change != "" {
+		values := map[string]string{"enable": "on"}
+		maps.Copy(values, provider.Spec.Config)
+		maps.Copy(values, secretValues)
+
+		restart := false
+		err = plan.apply(change, func() error {
+			restart, err = minioClient.Admin.AddOrUpdateIDPConfig(ctx, configType, configName, configKVPairs(values), item != nil)
+			return err
+		})
+		if err != nil {
+			return false, ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to configure identity provider: %w", err)
+		}
+		if !plan.dryRun {
+			logger.Info("Identity provider configured", "type", configType, "configName", configName, "restartRequired", restart)
+			if item == nil {
+				provider.Status.CreationDate = &metav1.Time{Time: time.Now()}
+			}
+			provider.Status.SecretsHash = digest
+			if restart && !provider.Status.RestartRequired {
+				provider.Status.RestartRequired = true
+				provider.Status.RestartRequiredSince = &metav1.Time{Time: time.Now()}
+			}
+		}
+	}
+
+	// A restart is remembered in status until it happened, since the configuration is already written
+	if provider.Status.RestartRequired {
+		if provider.Spec.RestartPolicy == miniov1beta1.RestartPolicyNever {
+			info, err := minioClient.Admin.ServerInfo(ctx)
+			if err != nil {
+				return false, ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get server info: %w", err)
+			}
+			if provider.Status.RestartRequiredSince == nil || !restartedSince(info.Servers, provider.Status.RestartRequiredSince.Time, time.Now()) {
+				return false, ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+			}
+		} else {
+			err = plan.apply("restart MinIO server to activate the identity provider", func() error {
+				return minioClient.Admin.ServiceRestartV2(ctx)
+			})
+			if err != nil {
+				return false, ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to restart MinIO server: %w", err)
+			}
+			if plan.dryRun {
+				return false, ctrl.Result{RequeueAfter: time.Hour}, nil
+			}
+			logger.Info("Restarted MinIO server to activate the identity provider", "type", configType, "configName", configName)
+		}
+		provider.Status.RestartRequired = false
+		provider.Status.RestartRequiredSince = nil
+		// The configuration is looked up once the server is back
+		return false, ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+
+	if plan.dryRun {
+		return item != nil && item.Enabled, ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	item, err = liveIdentityProvider(ctx, minioClient.Admin, configType, configName)
+	if err != nil {
+		return false, ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	if item == nil || !item.Enabled {
+		return false, ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	provider.Status.RoleARN = item.RoleARN
+	return true, ctrl.Result{RequeueAfter: time.Hour}, nil
+}
+
+// identityProviderConfigName returns the name of the configuration in MinIO
+func identityProviderConfigName(provider *miniov1beta1.IdentityProvider) string {
+	if provider.Spec.ConfigName == "" {
+		return miniov1beta1.DefaultIdentityProviderConfigName
+	}
+	return provider.Spec.ConfigName
+}
+
+// liveIdentityProvider returns the configuration listed by the server, or nil if it is not configured
+func liveIdentityProvider(ctx context.Context, admin *madmin.AdminClient, configType, configName string) (*madmin.IDPListItem, error) {
+	items, err := admin.ListIDPConfig(ctx, configType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s identity providers: %w", configType, err)
+	}
+	index := slices.IndexFunc(items, func(item madmin.IDPListItem) bool {
+		return item.Name == configName
+	})
+	if index < 0 {
+		return nil, nil
+	}
+	return &items[index], nil
+}
+
+// identityProviderMismatch returns the sorted keys whose live values differ from the desired ones
+func identityProviderMismatch(live []madmin.IDPCfgInfo, desired map[string]string) []string {
+	var keys []string
+	for _, key := range slices.Sorted(maps.Keys(desired)) {
+		index := slices.IndexFunc(live, func(info madmin.IDPCfgInfo) bool {
+			return info.IsCfg && info.Key == key
+		})
+		if index < 0 || live[index].Value != desired[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// identityProviderEnvOverrides returns the sorted desired keys whose live value is set by an environment
+// variable of the server
+func identityProviderEnvOverrides(live []madmin.IDPCfgInfo, desired map[string]string) []string {
+	var overridden []string
+	for _, info := range live {
+		if _, ok := desired[info.Key]; ok && info.IsCfg && info.IsEnv {
+			overridden = append(overridden, info.Key)
+		}
+	}
+	slices.Sort(overridden)
+	return overridden
+}
+
+// providersForSecret maps a Secret to the IdentityProviders reading configuration values from it
+func (r *IdentityProviderReconciler) providersForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1beta1.IdentityProviderList{}
+	if err := r.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list identity providers")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if referencesSecret(item.Namespace, item.Spec.SecretConfig, obj) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *IdentityProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.IdentityProvider{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.providersForSecret)).
+		Complete(r)
+}
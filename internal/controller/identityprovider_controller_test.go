@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/minio/madmin-go/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("IdentityProvider Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-identity-provider"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind IdentityProvider")
+			err := k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.IdentityProvider{})
+			if err != nil && errors.IsNotFound(err) {
+				resource := &miniov1beta1.IdentityProvider{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: miniov1beta1.IdentityProviderSpec{
+						Connection: miniov1beta1.MinIOConnection{
+							AliasRef: &miniov1beta1.AliasReference{Name: "missing-alias"},
+						},
+						Type:   miniov1beta1.IdentityProviderTypeOpenID,
+						Config: map[string]string{"config_url": "https://keycloak.example.com/.well-known/openid-configuration"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.IdentityProvider{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance IdentityProvider")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should report a connection that cannot be established", func() {
+			controllerReconciler := &IdentityProviderReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &miniov1beta1.IdentityProvider{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+			Expect(resource.Status.Ready).To(BeFalse())
+		})
+	})
+
+	Context("When comparing the live configuration", func() {
+		live := []madmin.IDPCfgInfo{
+			{Key: "config_url", Value: "https://keycloak.example.com/.well-known/openid-configuration", IsCfg: true},
+			{Key: "client_id", Value: "minio", IsCfg: true, IsEnv: true},
+			{Key: "claim_name", Value: "policy", IsCfg: true},
+			{Key: "scopes", Value: "openid", IsCfg: false},
+		}
+
+		It("should report keys differing from the live configuration", func() {
+			Expect(identityProviderMismatch(live, map[string]string{
+				"config_url": "https://keycloak.example.com/.well-known/openid-configuration",
+				"claim_name": "groups",
+				"scopes":     "openid",
+			})).To(Equal([]string{"claim_name", "scopes"}))
+		})
+
+		It("should find settings overridden by the environment", func() {
+			Expect(identityProviderEnvOverrides(live, map[string]string{"client_id": "minio", "claim_name": "policy"})).To(Equal([]string{"client_id"}))
+			Expect(identityProviderEnvOverrides(live, map[string]string{"claim_name": "policy"})).To(BeEmpty())
+		})
+
+		It("should default to the default configuration", func() {
+			provider := &miniov1beta1.IdentityProvider{}
+			Expect(identityProviderConfigName(provider)).To(Equal(miniov1beta1.DefaultIdentityProviderConfigName))
+			provider.Spec.ConfigName = "keycloak"
+			Expect(identityProviderConfigName(provider)).To(Equal("keycloak"))
+		})
+	})
+})
@@ -18,45 +18,325 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
 )
 
 // LifecyclePolicyReconciler reconciles a LifecyclePolicy object
 type LifecyclePolicyReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all lifecycle policies
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=lifecyclepolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=lifecyclepolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=lifecyclepolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliases,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=endpoints,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the LifecyclePolicy object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.17.0/pkg/reconcile
+// Reconcile puts the lifecycle rules of a LifecyclePolicy to its bucket, once the tiers its
+// transitions move objects to exist in MinIO.
 func (r *LifecyclePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	connection := func(lifecyclePolicy *miniov1beta1.LifecyclePolicy) *miniov1beta1.MinIOConnection {
+		return &lifecyclePolicy.Spec.Connection
+	}
+	lifecycle := &resourceLifecycle[*miniov1beta1.LifecyclePolicy, *minioclient.Client]{
+		Client:    r.Client,
+		Recorder:  r.Recorder,
+		DryRun:    r.DryRun,
+		name:      "lifecycle policy",
+		finalizer: miniov1beta1.LifecyclePolicyFinalizer,
+		status: func(lifecyclePolicy *miniov1beta1.LifecyclePolicy) resourceStatus {
+			status := &lifecyclePolicy.Status
+			return resourceStatus{&status.Ready, &status.ObservedGeneration, &status.LastSyncTime, &status.PlannedChanges}
+		},
+		connections: singleConnection(connection),
+		delete:      r.handleDeletion,
+		connect:     clientFor(r.Client, connection),
+		reconcile: func(ctx context.Context, lifecyclePolicy *miniov1beta1.LifecyclePolicy, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+			result, err := r.reconcileLifecycle(ctx, lifecyclePolicy, minioClient, plan)
+			if err == nil {
+				lifecyclePolicy.Status.BucketName = lifecyclePolicy.Spec.BucketName
+			}
+			return result, err
+		},
+	}
+	return lifecycle.run(ctx, req, &miniov1beta1.LifecyclePolicy{})
+}
+
+// handleDeletion removes the lifecycle configuration of the bucket before releasing the finalizer
+func (r *LifecyclePolicyReconciler) handleDeletion(ctx context.Context, lifecyclePolicy *miniov1beta1.LifecyclePolicy) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(lifecyclePolicy, miniov1beta1.LifecyclePolicyFinalizer) {
+		// Resources rejected by the tenant policies never managed the bucket they name
+		violation, err := tenantViolation(ctx, r.Client, lifecyclePolicy)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		// Rules were only put to the bucket once a hash was recorded
+		if !violation && lifecyclePolicy.Status.PolicyHash != "" {
+			minioClient, err := newMinIOClient(ctx, r.Client, lifecyclePolicy, lifecyclePolicy.Spec.Connection)
+			if err != nil {
+				logger.Error(err, "Failed to create MinIO client for deletion, retrying")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
 
-	// TODO(user): your logic here
+			// An empty configuration removes the lifecycle configuration of the bucket
+			plan := newChangePlan(r.DryRun, lifecyclePolicy)
+			err = plan.apply(fmt.Sprintf("remove lifecycle configuration of bucket %s", lifecyclePolicy.Spec.BucketName), func() error {
+				err := minioClient.S3.SetBucketLifecycle(ctx, lifecyclePolicy.Spec.BucketName, lifecycle.NewConfiguration())
+				if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+					return nil
+				}
+				return err
+			})
+			if err != nil {
+				logger.Error(err, "Failed to remove lifecycle configuration, will retry", "bucketName", lifecyclePolicy.Spec.BucketName)
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if plan.pending() {
+				return reportPlannedDeletion(ctx, r.Client, r.Recorder, lifecyclePolicy, &lifecyclePolicy.Status.PlannedChanges, plan)
+			}
+			logger.Info("Removed lifecycle configuration", "bucketName", lifecyclePolicy.Spec.BucketName)
+		}
+
+		// Remove finalizer
+		controllerutil.RemoveFinalizer(lifecyclePolicy, miniov1beta1.LifecyclePolicyFinalizer)
+		if err := r.Update(ctx, lifecyclePolicy); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
 	return ctrl.Result{}, nil
 }
 
+// reconcileLifecycle puts the lifecycle rules to the bucket when they changed
+func (r *LifecyclePolicyReconciler) reconcileLifecycle(ctx context.Context, lifecyclePolicy *miniov1beta1.LifecyclePolicy, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	config, err := lifecycleConfiguration(lifecyclePolicy.Spec)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// MinIO rejects rules transitioning objects to tiers it does not know
+	if err := checkTiers(ctx, lifecyclePolicy, minioClient); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	document, err := xml.Marshal(config)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	sum := sha256.Sum256(document)
+	hash := hex.EncodeToString(sum[:])
+
+	// Rules removed from the bucket outside of the controller are put again
+	exists := true
+	if lifecyclePolicy.Status.PolicyHash == hash {
+		_, err := minioClient.S3.GetBucketLifecycle(ctx, lifecyclePolicy.Spec.BucketName)
+		if minio.ToErrorResponse(err).Code == "NoSuchLifecycleConfiguration" {
+			exists = false
+		} else if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get lifecycle configuration: %w", err)
+		}
+	}
+
+	if lifecyclePolicy.Status.PolicyHash != hash || !exists {
+		err := plan.apply(fmt.Sprintf("set lifecycle configuration of bucket %s", lifecyclePolicy.Spec.BucketName), func() error {
+			return minioClient.S3.SetBucketLifecycle(ctx, lifecyclePolicy.Spec.BucketName, config)
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set lifecycle configuration: %w", err)
+		}
+		if !plan.dryRun {
+			logger.Info("Applied lifecycle configuration", "bucketName", lifecyclePolicy.Spec.BucketName)
+			lifecyclePolicy.Status.PolicyHash = hash
+			lifecyclePolicy.Status.AppliedAt = &metav1.Time{Time: time.Now()}
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: time.Hour}, nil
+}
+
+// checkTiers lists the tiers of MinIO and reports in the TiersAvailable condition whether the
+// transitions of the rules move objects to tiers that exist. Missing tiers are returned as error.
+func checkTiers(ctx context.Context, lifecyclePolicy *miniov1beta1.LifecyclePolicy, minioClient *minioclient.Client) error {
+	tierNames := transitionTiers(lifecyclePolicy.Spec)
+	if len(tierNames) == 0 {
+		removeCondition(lifecyclePolicy, miniov1beta1.ConditionTiersAvailable)
+		return nil
+	}
+
+	tiers, err := minioClient.Admin.ListTiers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tiers: %w", err)
+	}
+	var missing []string
+	for _, name := range tierNames {
+		if findTier(tiers, name) == nil {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		message := fmt.Sprintf("Tiers %s do not exist in MinIO", strings.Join(missing, ", "))
+		setCondition(lifecyclePolicy, miniov1beta1.ConditionTiersAvailable, metav1.ConditionFalse, reasonTierNotFound, message)
+		return fmt.Errorf("tiers %s do not exist in MinIO", strings.Join(missing, ", "))
+	}
+	setCondition(lifecyclePolicy, miniov1beta1.ConditionTiersAvailable, metav1.ConditionTrue, reasonTiersAvailable, "All tiers of the transitions exist in MinIO")
+	return nil
+}
+
+// transitionTiers returns the sorted names of the tiers the transitions of the rules move objects to
+func transitionTiers(spec miniov1beta1.LifecyclePolicySpec) []string {
+	var names []string
+	for _, rule := range spec.Rules {
+		for _, transition := range rule.Transitions {
+			names = append(names, transition.StorageClass)
+		}
+		for _, transition := range rule.NoncurrentVersionTransitions {
+			names = append(names, transition.StorageClass)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// lifecycleConfiguration converts the rules of a LifecyclePolicy to the lifecycle configuration of MinIO
+func lifecycleConfiguration(spec miniov1beta1.LifecyclePolicySpec) (*lifecycle.Configuration, error) {
+	config := lifecycle.NewConfiguration()
+	for _, rule := range spec.Rules {
+		if len(rule.Transitions) > 1 || len(rule.NoncurrentVersionTransitions) > 1 {
+			return nil, &invalidSpecError{message: fmt.Sprintf("rule %s has more than one transition of current or noncurrent versions", rule.ID)}
+		}
+
+		status := string(rule.Status)
+		if status == "" {
+			status = string(miniov1beta1.LifecycleRuleStatusEnabled)
+		}
+		converted := lifecycle.Rule{ID: rule.ID, Status: status}
+
+		if filter := rule.Filter; filter != nil {
+			switch {
+			case filter.And != nil:
+				converted.RuleFilter.And = lifecycle.And{Prefix: filterPrefix(filter.And.Prefix), Tags: lifecycleTags(filter.And.Tags)}
+			case len(filter.Tags) > 1 || (len(filter.Tags) == 1 && filter.Prefix != nil):
+				// Several conditions can only be combined with And
+				converted.RuleFilter.And = lifecycle.And{Prefix: filterPrefix(filter.Prefix), Tags: lifecycleTags(filter.Tags)}
+			case len(filter.Tags) == 1:
+				converted.RuleFilter.Tag = lifecycleTags(filter.Tags)[0]
+			default:
+				converted.RuleFilter.Prefix = filterPrefix(filter.Prefix)
+			}
+		}
+
+		if expiration := rule.Expiration; expiration != nil {
+			if expiration.Days != nil {
+				converted.Expiration.Days = lifecycle.ExpirationDays(*expiration.Days)
+			}
+			if expiration.Date != nil {
+				converted.Expiration.Date = lifecycle.ExpirationDate{Time: expiration.Date.UTC()}
+			}
+			if expiration.ExpiredObjectDeleteMarker != nil {
+				converted.Expiration.DeleteMarker = lifecycle.ExpireDeleteMarker(*expiration.ExpiredObjectDeleteMarker)
+			}
+		}
+		if expiration := rule.NoncurrentVersionExpiration; expiration != nil {
+			converted.NoncurrentVersionExpiration.NoncurrentDays = lifecycle.ExpirationDays(expiration.NoncurrentDays)
+		}
+		if abort := rule.AbortIncompleteMultipartUpload; abort != nil {
+			converted.AbortIncompleteMultipartUpload.DaysAfterInitiation = lifecycle.ExpirationDays(abort.DaysAfterInitiation)
+		}
+		for _, transition := range rule.Transitions {
+			converted.Transition.StorageClass = transition.StorageClass
+			if transition.Days != nil {
+				converted.Transition.Days = lifecycle.ExpirationDays(*transition.Days)
+			}
+			if transition.Date != nil {
+				converted.Transition.Date = lifecycle.ExpirationDate{Time: transition.Date.UTC()}
+			}
+		}
+		for _, transition := range rule.NoncurrentVersionTransitions {
+			converted.NoncurrentVersionTransition.StorageClass = transition.StorageClass
+			converted.NoncurrentVersionTransition.NoncurrentDays = lifecycle.ExpirationDays(transition.NoncurrentDays)
+		}
+
+		config.Rules = append(config.Rules, converted)
+	}
+	return config, nil
+}
+
+// lifecycleTags converts tags to lifecycle filter tags, sorted by key so that the configuration is stable
+func lifecycleTags(tags map[string]string) []lifecycle.Tag {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	converted := make([]lifecycle.Tag, 0, len(keys))
+	for _, key := range keys {
+		converted = append(converted, lifecycle.Tag{Key: key, Value: tags[key]})
+	}
+	return converted
+}
+
+// filterPrefix returns the prefix of a filter, which is empty if not set
+func filterPrefix(prefix *string) string {
+	if prefix == nil {
+		return ""
+	}
+	return *prefix
+}
+
+// lifecyclePoliciesForTier maps a Tier to the LifecyclePolicies of its namespace, which may wait for its tier
+func (r *LifecyclePolicyReconciler) lifecyclePoliciesForTier(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1beta1.LifecyclePolicyList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list lifecycle policies")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if slices.Contains(transitionTiers(item.Spec), obj.(*miniov1beta1.Tier).Spec.TierName) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *LifecyclePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&miniov1alpha1.LifecyclePolicy{}).
+		For(&miniov1beta1.LifecyclePolicy{}).
+		Watches(&miniov1beta1.Tier{}, handler.EnqueueRequestsFromMapFunc(r.lifecyclePoliciesForTier)).
 		Complete(r)
 }
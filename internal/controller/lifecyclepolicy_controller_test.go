@@ -19,15 +19,18 @@ package controller
 import (
 	"context"
 
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 )
 
 var _ = Describe("LifecyclePolicy Controller", func() {
@@ -40,30 +43,30 @@ var _ = Describe("LifecyclePolicy Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		lifecyclepolicy := &miniov1alpha1.LifecyclePolicy{}
+		lifecyclepolicy := &miniov1beta1.LifecyclePolicy{}
 
 		BeforeEach(func() {
 			By("creating the custom resource for the Kind LifecyclePolicy")
 			err := k8sClient.Get(ctx, typeNamespacedName, lifecyclepolicy)
 			if err != nil && errors.IsNotFound(err) {
-				resource := &miniov1alpha1.LifecyclePolicy{
+				resource := &miniov1beta1.LifecyclePolicy{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					Spec: miniov1alpha1.LifecyclePolicySpec{
-						Connection: miniov1alpha1.MinIOConnection{
+					Spec: miniov1beta1.LifecyclePolicySpec{
+						Connection: miniov1beta1.MinIOConnection{
 							URL: &[]string{"http://localhost:9000"}[0],
-							SecretRef: &miniov1alpha1.SecretReference{
+							SecretRef: &miniov1beta1.SecretReference{
 								Name: "minio-secret",
 							},
 						},
 						BucketName: "test-bucket",
-						Rules: []miniov1alpha1.LifecycleRule{
+						Rules: []miniov1beta1.LifecycleRule{
 							{
 								ID:     "test-rule",
-								Status: miniov1alpha1.LifecycleRuleStatusEnabled,
-								Expiration: &miniov1alpha1.LifecycleExpiration{
+								Status: miniov1beta1.LifecycleRuleStatusEnabled,
+								Expiration: &miniov1beta1.LifecycleExpiration{
 									Days: &[]int{30}[0],
 								},
 							},
@@ -76,7 +79,7 @@ var _ = Describe("LifecyclePolicy Controller", func() {
 
 		AfterEach(func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &miniov1alpha1.LifecyclePolicy{}
+			resource := &miniov1beta1.LifecyclePolicy{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -94,8 +97,112 @@ var _ = Describe("LifecyclePolicy Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
-			// Example: If you expect a certain status condition after reconciliation, verify it here.
+
+			resource := &miniov1beta1.LifecyclePolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+			Expect(resource.Status.Ready).To(BeFalse())
+		})
+	})
+
+	Context("When converting rules", func() {
+		prefix := "logs/"
+		days := 30
+
+		It("should convert filters, expirations and transitions", func() {
+			config, err := lifecycleConfiguration(miniov1beta1.LifecyclePolicySpec{Rules: []miniov1beta1.LifecycleRule{
+				{
+					ID:          "archive",
+					Filter:      &miniov1beta1.LifecycleFilter{Prefix: &prefix},
+					Expiration:  &miniov1beta1.LifecycleExpiration{Days: &days},
+					Transitions: []miniov1beta1.LifecycleTransition{{Days: &days, StorageClass: "WARM"}},
+				},
+				{
+					ID:     "tagged",
+					Status: miniov1beta1.LifecycleRuleStatusDisabled,
+					Filter: &miniov1beta1.LifecycleFilter{Prefix: &prefix, Tags: map[string]string{"b": "2", "a": "1"}},
+					NoncurrentVersionTransitions: []miniov1beta1.NoncurrentVersionTransition{
+						{NoncurrentDays: 7, StorageClass: "COLD"},
+					},
+				},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Rules).To(HaveLen(2))
+
+			Expect(config.Rules[0].Status).To(Equal("Enabled"))
+			Expect(config.Rules[0].RuleFilter.Prefix).To(Equal(prefix))
+			Expect(config.Rules[0].Expiration.Days).To(Equal(lifecycle.ExpirationDays(30)))
+			Expect(config.Rules[0].Transition.StorageClass).To(Equal("WARM"))
+
+			Expect(config.Rules[1].Status).To(Equal("Disabled"))
+			Expect(config.Rules[1].RuleFilter.And.Prefix).To(Equal(prefix))
+			Expect(config.Rules[1].RuleFilter.And.Tags).To(Equal([]lifecycle.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}))
+			Expect(config.Rules[1].NoncurrentVersionTransition.StorageClass).To(Equal("COLD"))
+		})
+
+		It("should reject more than one transition per rule as invalid spec", func() {
+			_, err := lifecycleConfiguration(miniov1beta1.LifecyclePolicySpec{Rules: []miniov1beta1.LifecycleRule{{
+				ID: "archive",
+				Transitions: []miniov1beta1.LifecycleTransition{
+					{Days: &days, StorageClass: "WARM"},
+					{Days: &days, StorageClass: "COLD"},
+				},
+			}}})
+			Expect(err).To(HaveOccurred())
+			Expect(errorReason(err, reasonReconcileError)).To(Equal(reasonInvalidSpec))
+		})
+	})
+
+	Context("When checking the tiers of transitions", func() {
+		var admin *fakeAdmin
+
+		AfterEach(func() {
+			admin.server.Close()
+		})
+
+		newLifecyclePolicy := func(tiers ...string) *miniov1beta1.LifecyclePolicy {
+			lifecyclePolicy := &miniov1beta1.LifecyclePolicy{Spec: miniov1beta1.LifecyclePolicySpec{BucketName: "logs"}}
+			for _, tier := range tiers {
+				lifecyclePolicy.Spec.Rules = append(lifecyclePolicy.Spec.Rules, miniov1beta1.LifecycleRule{
+					ID:                           tier,
+					NoncurrentVersionTransitions: []miniov1beta1.NoncurrentVersionTransition{{NoncurrentDays: 7, StorageClass: tier}},
+				})
+			}
+			return lifecyclePolicy
+		}
+
+		listTiers := func() map[string]interface{} {
+			warm, err := madmin.NewTierMinIO("WARM", "https://minio-warm.example.com", "access", "secret", "transitions")
+			Expect(err).NotTo(HaveOccurred())
+			return map[string]interface{}{"tier": []*madmin.TierConfig{warm}}
+		}
+
+		It("should report tiers that exist", func() {
+			admin = newFakeAdmin(listTiers())
+			lifecyclePolicy := newLifecyclePolicy("WARM")
+
+			Expect(checkTiers(context.Background(), lifecyclePolicy, admin.client())).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(lifecyclePolicy.Status.Conditions, miniov1beta1.ConditionTiersAvailable)).To(BeTrue())
+		})
+
+		It("should report missing tiers", func() {
+			admin = newFakeAdmin(listTiers())
+			lifecyclePolicy := newLifecyclePolicy("WARM", "COLD")
+
+			Expect(checkTiers(context.Background(), lifecyclePolicy, admin.client())).To(MatchError(ContainSubstring("COLD")))
+			condition := meta.FindStatusCondition(lifecyclePolicy.Status.Conditions, miniov1beta1.ConditionTiersAvailable)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(reasonTierNotFound))
+		})
+
+		It("should not list tiers for rules without transitions", func() {
+			admin = newFakeAdmin(nil)
+			lifecyclePolicy := newLifecyclePolicy()
+
+			Expect(checkTiers(context.Background(), lifecyclePolicy, admin.client())).To(Succeed())
+			Expect(admin.called()).To(BeEmpty())
+			Expect(lifecyclePolicy.Status.Conditions).To(BeEmpty())
 		})
 	})
 })
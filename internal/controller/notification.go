@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// notificationConfiguration returns the desired event notifications of a bucket. targetARN is the ARN
+// of the referenced NotificationTarget, which MinIO publishes as a queue.
+func notificationConfiguration(spec *miniov1beta1.BucketNotification, targetARN string) (notification.Configuration, error) {
+	config := notification.Configuration{}
+
+	newConfig := func(arnString string) (notification.Config, error) {
+		arn, err := notification.NewArnFromString(arnString)
+		if err != nil {
+			return notification.Config{}, fmt.Errorf("invalid notification ARN %s: %w", arnString, err)
+		}
+		target := notification.NewConfig(arn)
+		for _, event := range spec.Events {
+			target.AddEvents(notification.EventType(event))
+		}
+		if spec.FilterPrefix != nil && *spec.FilterPrefix != "" {
+			target.AddFilterPrefix(*spec.FilterPrefix)
+		}
+		if spec.FilterSuffix != nil && *spec.FilterSuffix != "" {
+			target.AddFilterSuffix(*spec.FilterSuffix)
+		}
+		return target, nil
+	}
+
+	if spec.Topic != nil {
+		target, err := newConfig(*spec.Topic)
+		if err != nil {
+			return config, err
+		}
+		config.AddTopic(target)
+	}
+	for _, queue := range []*string{spec.Queue, &targetARN} {
+		if queue == nil || *queue == "" {
+			continue
+		}
+		target, err := newConfig(*queue)
+		if err != nil {
+			return config, err
+		}
+		config.AddQueue(target)
+	}
+	if spec.LambdaFunction != nil {
+		target, err := newConfig(*spec.LambdaFunction)
+		if err != nil {
+			return config, err
+		}
+		config.AddLambda(target)
+	}
+	return config, nil
+}
+
+// notificationSynced reports whether the live event notifications of a bucket match the desired ones
+func notificationSynced(live, desired notification.Configuration) bool {
+	if len(live.TopicConfigs) != len(desired.TopicConfigs) || len(live.QueueConfigs) != len(desired.QueueConfigs) ||
+		len(live.LambdaConfigs) != len(desired.LambdaConfigs) {
+		return false
+	}
+
+	// The ARNs are only set in the ARN fields of the live configurations
+	matches := func(live notification.Config, liveARN string, desired notification.Config) bool {
+		prefix, suffix := "", ""
+		if desired.Filter != nil {
+			for _, rule := range desired.Filter.S3Key.FilterRules {
+				switch rule.Name {
+				case "prefix":
+					prefix = rule.Value
+				case "suffix":
+					suffix = rule.Value
+				}
+			}
+		}
+		return liveARN == desired.Arn.String() && live.Equal(desired.Events, prefix, suffix)
+	}
+	for _, want := range desired.TopicConfigs {
+		if !slices.ContainsFunc(live.TopicConfigs, func(got notification.TopicConfig) bool { return matches(got.Config, got.Topic, want.Config) }) {
+			return false
+		}
+	}
+	for _, want := range desired.QueueConfigs {
+		if !slices.ContainsFunc(live.QueueConfigs, func(got notification.QueueConfig) bool { return matches(got.Config, got.Queue, want.Config) }) {
+			return false
+		}
+	}
+	for _, want := range desired.LambdaConfigs {
+		if !slices.ContainsFunc(live.LambdaConfigs, func(got notification.LambdaConfig) bool { return matches(got.Config, got.Lambda, want.Config) }) {
+			return false
+		}
+	}
+	return true
+}
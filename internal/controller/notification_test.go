@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/xml"
+
+	"github.com/minio/minio-go/v7/pkg/notification"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Bucket notifications", func() {
+	const targetARN = "arn:minio:sqs::audit:webhook"
+
+	// live parses the notification configuration as returned by MinIO
+	live := func(document string) notification.Configuration {
+		config := notification.Configuration{}
+		Expect(xml.Unmarshal([]byte(document), &config)).To(Succeed())
+		return config
+	}
+
+	var spec *miniov1beta1.BucketNotification
+
+	BeforeEach(func() {
+		prefix := "uploads/"
+		spec = &miniov1beta1.BucketNotification{
+			Events:       []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"},
+			FilterPrefix: &prefix,
+			TargetRef:    &miniov1beta1.NotificationTargetReference{Name: "audit"},
+		}
+	})
+
+	It("should publish the events of a notification target as queue", func() {
+		desired, err := notificationConfiguration(spec, targetARN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(desired.QueueConfigs).To(HaveLen(1))
+		Expect(desired.QueueConfigs[0].Queue).To(Equal(targetARN))
+		Expect(desired.TopicConfigs).To(BeEmpty())
+	})
+
+	It("should reject malformed ARNs", func() {
+		topic := "arn:aws:sns"
+		spec.Topic = &topic
+		_, err := notificationConfiguration(spec, targetARN)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should accept live notifications with the same events in any order", func() {
+		desired, err := notificationConfiguration(spec, targetARN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notificationSynced(live(`<NotificationConfiguration><QueueConfiguration>
+			<Id>1</Id><Queue>arn:minio:sqs::audit:webhook</Queue>
+			<Event>s3:ObjectRemoved:*</Event><Event>s3:ObjectCreated:*</Event>
+			<Filter><S3Key><FilterRule><Name>prefix</Name><Value>uploads/</Value></FilterRule></S3Key></Filter>
+		</QueueConfiguration></NotificationConfiguration>`), desired)).To(BeTrue())
+	})
+
+	It("should detect changed filters and additional notifications", func() {
+		desired, err := notificationConfiguration(spec, targetARN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(notificationSynced(live(`<NotificationConfiguration><QueueConfiguration>
+			<Queue>arn:minio:sqs::audit:webhook</Queue>
+			<Event>s3:ObjectRemoved:*</Event><Event>s3:ObjectCreated:*</Event>
+		</QueueConfiguration></NotificationConfiguration>`), desired)).To(BeFalse())
+		Expect(notificationSynced(live(`<NotificationConfiguration><QueueConfiguration>
+			<Queue>arn:minio:sqs::audit:webhook</Queue>
+			<Event>s3:ObjectRemoved:*</Event><Event>s3:ObjectCreated:*</Event>
+			<Filter><S3Key><FilterRule><Name>prefix</Name><Value>uploads/</Value></FilterRule></S3Key></Filter>
+		</QueueConfiguration><QueueConfiguration>
+			<Queue>arn:minio:sqs::other:kafka</Queue><Event>s3:ObjectCreated:*</Event>
+		</QueueConfiguration></NotificationConfiguration>`), desired)).To(BeFalse())
+	})
+})
@@ -0,0 +1,294 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// NotificationTargetReconciler reconciles a NotificationTarget object
+type NotificationTargetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all notification targets
+	DryRun bool
+}
+
+// notificationSubsystems maps the target types to their configuration subsystem and the type in their ARN
+var notificationSubsystems = map[miniov1beta1.NotificationTargetType][2]string{
+	miniov1beta1.NotificationTargetTypeWebhook:  {"notify_webhook", "webhook"},
+	miniov1beta1.NotificationTargetTypeKafka:    {"notify_kafka", "kafka"},
+	miniov1beta1.NotificationTargetTypeNATS:     {"notify_nats", "nats"},
+	miniov1beta1.NotificationTargetTypeAMQP:     {"notify_amqp", "amqp"},
+	miniov1beta1.NotificationTargetTypeRedis:    {"notify_redis", "redis"},
+	miniov1beta1.NotificationTargetTypePostgres: {"notify_postgres", "postgresql"},
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=notificationtargets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=notificationtargets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=notificationtargets/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile writes the configuration of the notification target to MinIO, restarts the server if
+// required and publishes the ARN of the active target
+func (r *NotificationTargetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	connection := func(target *miniov1beta1.NotificationTarget) *miniov1beta1.MinIOConnection {
+		return &target.Spec.Connection
+	}
+	lifecycle := &resourceLifecycle[*miniov1beta1.NotificationTarget, *minioclient.Client]{
+		Client:    r.Client,
+		Recorder:  r.Recorder,
+		DryRun:    r.DryRun,
+		name:      "notification target",
+		finalizer: miniov1beta1.NotificationTargetFinalizer,
+		status: func(target *miniov1beta1.NotificationTarget) resourceStatus {
+			return resourceStatus{&target.Status.Ready, &target.Status.ObservedGeneration, &target.Status.LastSyncTime, &target.Status.PlannedChanges}
+		},
+		connections: singleConnection(connection),
+		delete:      r.handleDeletion,
+		connect:     clientFor(r.Client, connection),
+		reconcile:   r.reconcileTarget,
+		// The target is active once the server publishes its ARN
+		ready: func(target *miniov1beta1.NotificationTarget) (bool, string, string) {
+			switch {
+			case target.Status.ARN != "":
+				return true, "", "Notification target is active"
+			case target.Status.RestartRequired:
+				return false, reasonRestartRequired, "The MinIO server must be restarted to activate the notification target"
+			default:
+				return false, reasonTargetInactive, "The notification target is not active on the MinIO server yet"
+			}
+		},
+	}
+	return lifecycle.run(ctx, req, &miniov1beta1.NotificationTarget{})
+}
+
+// handleDeletion removes the target from the MinIO configuration if it was created by the controller
+func (r *NotificationTargetReconciler) handleDeletion(ctx context.Context, target *miniov1beta1.NotificationTarget) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(target, miniov1beta1.NotificationTargetFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, target)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		switch {
+		case violation:
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+		case target.Status.CreationDate == nil:
+			// The configuration carries no owner marker, so adopted targets are kept
+			logger.Info("Keeping notification target that was not created by this resource", "targetName", target.Spec.TargetName)
+		default:
+			minioClient, err := newMinIOClient(ctx, r.Client, target, target.Spec.Connection)
+			if err != nil {
+				logger.Error(err, "Failed to create MinIO client for deletion, retrying")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+
+			subSystem := notificationSubsystems[target.Spec.Type][0]
+			live, err := liveConfig(ctx, minioClient.Admin, subSystem, target.Spec.TargetName)
+			if err != nil {
+				logger.Error(err, "Failed to get notification target, will retry")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if live != nil {
+				// MinIO refuses to remove targets that buckets still publish events to
+				key := configKey(subSystem, target.Spec.TargetName)
+				plan := newChangePlan(r.DryRun, target)
+				restart := false
+				err = plan.apply(fmt.Sprintf("remove notification target %s", key), func() error {
+					restart, err = minioClient.Admin.DelConfigKV(ctx, key)
+					return err
+				})
+				if err == nil && restart && target.Spec.RestartPolicy != miniov1beta1.RestartPolicyNever {
+					err = plan.apply("restart MinIO server to deactivate the notification target", func() error {
+						return minioClient.Admin.ServiceRestartV2(ctx)
+					})
+				}
+				if err != nil {
+					logger.Error(err, "Failed to remove notification target, will retry", "targetName", target.Spec.TargetName)
+					return ctrl.Result{RequeueAfter: time.Minute}, nil
+				}
+				if plan.pending() {
+					return reportPlannedDeletion(ctx, r.Client, r.Recorder, target, &target.Status.PlannedChanges, plan)
+				}
+				logger.Info("Removed notification target", "targetName", target.Spec.TargetName)
+			}
+		}
+
+		// Remove finalizer
+		controllerutil.RemoveFinalizer(target, miniov1beta1.NotificationTargetFinalizer)
+		if err := r.Update(ctx, target); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileTarget writes the configuration of the target if it differs from the live one, restarts
+// the server if the change requires it and looks up the ARN of the active target
+func (r *NotificationTargetReconciler) reconcileTarget(ctx context.Context, target *miniov1beta1.NotificationTarget, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	subSystem, arnType := notificationSubsystems[target.Spec.Type][0], notificationSubsystems[target.Spec.Type][1]
+	key := configKey(subSystem, target.Spec.TargetName)
+
+	secretValues, err := configSecretValues(ctx, r.Client, target.Namespace, target.Spec.SecretConfig)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get secret configuration: %w", err)
+	}
+	digest := configSecretsDigest(secretValues)
+
+	live, err := liveConfig(ctx, minioClient.Admin, subSystem, target.Spec.TargetName)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	var change string
+	if live == nil {
+		change = fmt.Sprintf("add %s notification target %s", target.Spec.Type, target.Spec.TargetName)
+	} else {
+		// The configuration carries no owner marker, so only targets created by this resource or adopted ones are managed
+		if err := checkOwner(target, "notification target", key, "", target.Spec.Adopt, target.Status.CreationDate != nil); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		// Secret values may be redacted in the live configuration, so their changes are detected by their digest
+		if keys := configMismatch(live, target.Spec.Config); len(keys) > 0 {
+			change = fmt.Sprintf("set %s of notification target %s", strings.Join(keys, ", "), target.Spec.TargetName)
+		} else if digest != target.Status.SecretsHash {
+			change = fmt.Sprintf("update secret configuration of notification target %s", target.Spec.TargetName)
+		}
+	}
+
+	if change != "" {
+		values := map[string]string{"enable": "on"}
+		maps.Copy(values, target.Spec.Config)
+		maps.Copy(values, secretValues)
+
+		restart := false
+		err = plan.apply(change, func() error {
+			restart, err = minioClient.Admin.SetConfigKV(ctx, configKVLine(key, values))
+			return err
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to configure notification target: %w", err)
+		}
+		if !plan.dryRun {
+			logger.Info("Notification target configured", "targetName", target.Spec.TargetName, "restartRequired", restart)
+			if live == nil {
+				target.Status.CreationDate = &metav1.Time{Time: time.Now()}
+			}
+			target.Status.SecretsHash = digest
+			target.Status.RestartRequired = target.Status.RestartRequired || restart
+		}
+	}
+
+	// A restart is remembered in status until it happened, since the configuration is already written
+	if target.Status.RestartRequired && target.Spec.RestartPolicy != miniov1beta1.RestartPolicyNever {
+		err = plan.apply("restart MinIO server to activate the notification target", func() error {
+			return minioClient.Admin.ServiceRestartV2(ctx)
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to restart MinIO server: %w", err)
+		}
+		if !plan.dryRun {
+			logger.Info("Restarted MinIO server to activate the notification target", "targetName", target.Spec.TargetName)
+			target.Status.RestartRequired = false
+			target.Status.ARN = ""
+			// The ARN is looked up once the server is back
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
+	if plan.dryRun {
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	info, err := minioClient.Admin.ServerInfo(ctx)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get server info: %w", err)
+	}
+	target.Status.ARN = findTargetARN(info.SQSARN, target.Spec.TargetName, arnType)
+	if target.Status.ARN == "" {
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	// The server runs the target, so a pending restart has happened in the meantime
+	target.Status.RestartRequired = false
+	return ctrl.Result{RequeueAfter: time.Hour}, nil
+}
+
+// findTargetARN returns the ARN of the target among the ARNs published by the server, e.g.
+// arn:minio:sqs::primary:webhook, or an empty string if the target is not active
+func findTargetARN(arns []string, targetName, arnType string) string {
+	index := slices.IndexFunc(arns, func(arn string) bool {
+		return strings.HasSuffix(arn, ":"+targetName+":"+arnType)
+	})
+	if index < 0 {
+		return ""
+	}
+	return arns[index]
+}
+
+// targetsForSecret maps a Secret to the NotificationTargets reading configuration values from it
+func (r *NotificationTargetReconciler) targetsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1beta1.NotificationTargetList{}
+	if err := r.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list notification targets")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if referencesSecret(item.Namespace, item.Spec.SecretConfig, obj) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NotificationTargetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.NotificationTarget{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.targetsForSecret)).
+		Complete(r)
+}
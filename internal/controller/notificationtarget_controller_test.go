@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("NotificationTarget Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-notification-target"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind NotificationTarget")
+			err := k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.NotificationTarget{})
+			if err != nil && errors.IsNotFound(err) {
+				resource := &miniov1beta1.NotificationTarget{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: miniov1beta1.NotificationTargetSpec{
+						Connection: miniov1beta1.MinIOConnection{
+							AliasRef: &miniov1beta1.AliasReference{Name: "missing-alias"},
+						},
+						Type:       miniov1beta1.NotificationTargetTypeWebhook,
+						TargetName: "audit",
+						Config:     map[string]string{"endpoint": "https://events.example.com/minio"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.NotificationTarget{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance NotificationTarget")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should report a connection that cannot be established", func() {
+			controllerReconciler := &NotificationTargetReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &miniov1beta1.NotificationTarget{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+			Expect(resource.Status.Ready).To(BeFalse())
+			Expect(resource.Status.ARN).To(BeEmpty())
+		})
+	})
+
+	Context("When looking up the ARN of a target", func() {
+		arns := []string{"arn:minio:sqs::audit:kafka", "arn:minio:sqs::audit:webhook", "arn:minio:sqs::orders:postgresql"}
+
+		It("should match the name and type of the target", func() {
+			Expect(findTargetARN(arns, "audit", "webhook")).To(Equal("arn:minio:sqs::audit:webhook"))
+			Expect(findTargetARN(arns, "orders", "postgresql")).To(Equal("arn:minio:sqs::orders:postgresql"))
+		})
+
+		It("should not match targets sharing a name suffix", func() {
+			Expect(findTargetARN(arns, "dit", "webhook")).To(BeEmpty())
+			Expect(findTargetARN(arns, "audit", "nats")).To(BeEmpty())
+		})
+
+		It("should map all target types to a subsystem", func() {
+			for _, targetType := range []miniov1beta1.NotificationTargetType{
+				miniov1beta1.NotificationTargetTypeWebhook, miniov1beta1.NotificationTargetTypeKafka,
+				miniov1beta1.NotificationTargetTypeNATS, miniov1beta1.NotificationTargetTypeAMQP,
+				miniov1beta1.NotificationTargetTypeRedis, miniov1beta1.NotificationTargetTypePostgres,
+			} {
+				Expect(notificationSubsystems).To(HaveKey(targetType))
+			}
+		})
+	})
+})
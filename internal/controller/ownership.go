@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// conflictError is returned when a MinIO resource is owned by another resource or by no resource at all
+type conflictError struct {
+	message string
+}
+
+// Error implements error
+func (e *conflictError) Error() string {
+	return e.message
+}
+
+// checkOwner returns a conflictError unless obj may manage an existing MinIO resource whose owner
+// marker holds owner. Unowned resources may be managed if adopt is set, or if the controller created
+// them for obj before ownership markers were introduced.
+func checkOwner(obj client.Object, kind, name, owner string, adopt, createdByController bool) error {
+	switch {
+	case owner == string(obj.GetUID()):
+		return nil
+	case owner != "":
+		return &conflictError{message: fmt.Sprintf("%s %s is owned by another resource with UID %s", kind, name, owner)}
+	case adopt || createdByController:
+		return nil
+	}
+	return &conflictError{message: fmt.Sprintf("%s %s already exists and is not owned by any resource, set adopt to take it over", kind, name)}
+}
+
+// ownedBy reports whether the MinIO resource with the owner marker owner is managed by obj and may be removed
+func ownedBy(obj client.Object, owner string, createdByController bool) bool {
+	return owner == string(obj.GetUID()) || (owner == "" && createdByController)
+}
+
+// bucketOwner returns the owner tag and all tags of a bucket
+func bucketOwner(ctx context.Context, minioClient *minioclient.Client, bucketName string) (string, map[string]string, error) {
+	bucketTags, err := minioClient.S3.GetBucketTagging(ctx, bucketName)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchTagSet" {
+			return "", nil, nil
+		}
+		return "", nil, fmt.Errorf("failed to get bucket tags: %w", err)
+	}
+	tagMap := bucketTags.ToMap()
+	return tagMap[miniov1beta1.OwnerTag], tagMap, nil
+}
+
+// lookupUser returns the info of a MinIO user and whether it exists. Only a missing user counts as
+// absent, any other error is returned so that an unreachable server is never mistaken for a free name.
+func lookupUser(ctx context.Context, minioClient *minioclient.Client, username string) (madmin.UserInfo, bool, error) {
+	userInfo, err := minioClient.Admin.GetUserInfo(ctx, username)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchUser" {
+			return madmin.UserInfo{}, false, nil
+		}
+		return madmin.UserInfo{}, false, fmt.Errorf("failed to get user %s: %w", username, err)
+	}
+	return userInfo, true, nil
+}
+
+// lookupCannedPolicy returns the document of a canned policy and whether it exists. Only a missing
+// policy counts as absent, any other error is returned.
+func lookupCannedPolicy(ctx context.Context, minioClient *minioclient.Client, policyName string) ([]byte, bool, error) {
+	document, err := minioClient.Admin.InfoCannedPolicy(ctx, policyName)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchPolicy" {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get canned policy %s: %w", policyName, err)
+	}
+	return document, len(document) > 0, nil
+}
+
+// userOwner returns the owner recorded in the owner group memberships of a user
+func userOwner(memberOf []string) string {
+	for _, group := range memberOf {
+		if owner, ok := strings.CutPrefix(group, miniov1beta1.OwnerGroupPrefix); ok {
+			return owner
+		}
+	}
+	return ""
+}
+
+// policyOwner returns the owner recorded in the ID of a canned policy document
+func policyOwner(document []byte) string {
+	var policy struct {
+		ID string `json:"ID"`
+	}
+	if err := json.Unmarshal(document, &policy); err != nil {
+		return ""
+	}
+	if owner, ok := strings.CutPrefix(policy.ID, miniov1beta1.OwnerPolicyIDPrefix); ok {
+		return owner
+	}
+	return ""
+}
+
+// withPolicyOwner sets the ID of a canned policy document to the owner marker of obj
+func withPolicyOwner(document []byte, obj client.Object) ([]byte, error) {
+	var policy map[string]interface{}
+	if err := json.Unmarshal(document, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy document: %w", err)
+	}
+	policy["ID"] = miniov1beta1.OwnerPolicyIDPrefix + string(obj.GetUID())
+	return json.Marshal(policy)
+}
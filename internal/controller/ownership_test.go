@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// fakeAdmin is a MinIO admin API recording the called APIs
+type fakeAdmin struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	calls  []string
+}
+
+// newFakeAdmin starts a MinIO admin API answering the APIs named in responses with their JSON encoded
+// value, and all others with an empty success response
+func newFakeAdmin(responses map[string]interface{}) *fakeAdmin {
+	return startAdmin(func(w http.ResponseWriter, api string) {
+		if response, ok := responses[api]; ok {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+		}
+	})
+}
+
+// newFailingAdmin starts a MinIO admin API answering every request with status and the error code code
+func newFailingAdmin(status int, code string) *fakeAdmin {
+	return startAdmin(func(w http.ResponseWriter, api string) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(madmin.ErrorResponse{Code: code, Message: code})
+	})
+}
+
+// startAdmin starts a MinIO admin API answering requests with respond
+func startAdmin(respond func(w http.ResponseWriter, api string)) *fakeAdmin {
+	admin := &fakeAdmin{}
+	admin.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		admin.mu.Lock()
+		admin.calls = append(admin.calls, api)
+		admin.mu.Unlock()
+		respond(w, api)
+	}))
+	return admin
+}
+
+// client returns a MinIO client talking to the admin API
+func (a *fakeAdmin) client() *minioclient.Client {
+	adminClient, err := madmin.NewWithOptions(strings.TrimPrefix(a.server.URL, "http://"), &madmin.Options{
+		Creds: credentials.NewStaticV4("access", "secret", ""),
+	})
+	Expect(err).NotTo(HaveOccurred())
+	return &minioclient.Client{Admin: adminClient}
+}
+
+// called returns the names of the admin APIs called so far
+func (a *fakeAdmin) called() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.calls...)
+}
+
+// reset forgets the APIs called so far
+func (a *fakeAdmin) reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = nil
+}
+
+var _ = Describe("Ownership markers", func() {
+	var bucket *miniov1beta1.Bucket
+
+	BeforeEach(func() {
+		bucket = &miniov1beta1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default", UID: "uid-1"},
+		}
+	})
+
+	It("should manage resources owned by the resource", func() {
+		Expect(checkOwner(bucket, "bucket", "data", "uid-1", false, false)).To(Succeed())
+		Expect(ownedBy(bucket, "uid-1", false)).To(BeTrue())
+	})
+
+	It("should report a conflict for resources owned by another resource", func() {
+		err := checkOwner(bucket, "bucket", "data", "uid-2", true, true)
+		Expect(errorReason(err, reasonReconcileError)).To(Equal(reasonConflict))
+		Expect(ownedBy(bucket, "uid-2", true)).To(BeFalse())
+	})
+
+	It("should only take over unowned resources when adopting", func() {
+		err := checkOwner(bucket, "bucket", "data", "", false, false)
+		Expect(errorReason(err, reasonReconcileError)).To(Equal(reasonConflict))
+		Expect(checkOwner(bucket, "bucket", "data", "", true, false)).To(Succeed())
+		Expect(ownedBy(bucket, "", false)).To(BeFalse())
+	})
+
+	It("should keep managing unowned resources created before ownership markers", func() {
+		Expect(checkOwner(bucket, "bucket", "data", "", false, true)).To(Succeed())
+		Expect(ownedBy(bucket, "", true)).To(BeTrue())
+	})
+
+	It("should read the owner from the owner group of a user", func() {
+		Expect(userOwner([]string{"developers", miniov1beta1.OwnerGroupPrefix + "uid-1"})).To(Equal("uid-1"))
+		Expect(userOwner([]string{"developers"})).To(BeEmpty())
+	})
+
+	It("should stamp and read the owner in the ID of a policy document", func() {
+		document, err := withPolicyOwner([]byte(`{"Version":"2012-10-17","Statement":[]}`), bucket)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policyOwner(document)).To(Equal("uid-1"))
+		Expect(policyOwner([]byte(`{"ID":"custom","Version":"2012-10-17"}`))).To(BeEmpty())
+	})
+
+	It("should set the Conflict condition only for ownership conflicts", func() {
+		markFailed(bucket, reasonConflict, "bucket data is owned by another resource")
+		Expect(bucket.Status.Conditions).To(ContainElement(HaveField("Type", miniov1beta1.ConditionConflict)))
+
+		markReady(bucket, "Bucket is ready")
+		Expect(bucket.Status.Conditions).NotTo(ContainElement(HaveField("Type", miniov1beta1.ConditionConflict)))
+	})
+})
+
+var _ = Describe("Looking up MinIO resources", func() {
+	ctx := context.Background()
+
+	It("should treat only missing users and policies as absent", func() {
+		admin := newFailingAdmin(http.StatusNotFound, "XMinioAdminNoSuchUser")
+		defer admin.server.Close()
+		_, exists, err := lookupUser(ctx, admin.client(), "alice")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+
+		admin = newFailingAdmin(http.StatusNotFound, "XMinioAdminNoSuchPolicy")
+		defer admin.server.Close()
+		_, exists, err = lookupCannedPolicy(ctx, admin.client(), "readers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should return any other lookup error", func() {
+		admin := newFailingAdmin(http.StatusInternalServerError, "XMinioServerNotInitialized")
+		defer admin.server.Close()
+		_, _, err := lookupUser(ctx, admin.client(), "alice")
+		Expect(err).To(HaveOccurred())
+		_, _, err = lookupCannedPolicy(ctx, admin.client(), "readers")
+		Expect(err).To(HaveOccurred())
+
+		admin = newFailingAdmin(http.StatusForbidden, "AccessDenied")
+		defer admin.server.Close()
+		_, _, err = lookupUser(ctx, admin.client(), "alice")
+		Expect(err).To(HaveOccurred())
+	})
+})
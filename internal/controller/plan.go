@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// changePlan collects the MinIO changes of a reconciliation. In dry-run mode the changes are
+// only recorded and never applied.
+type changePlan struct {
+	dryRun  bool
+	changes []string
+}
+
+// newChangePlan returns the plan for obj, which is a dry run if dryRun is set for the
+// controller or obj carries the dry-run annotation
+func newChangePlan(dryRun bool, obj client.Object) *changePlan {
+	return &changePlan{dryRun: dryRun || obj.GetAnnotations()[miniov1beta1.DryRunAnnotation] == "true"}
+}
+
+// apply records change and performs write unless the plan is a dry run
+func (p *changePlan) apply(change string, write func() error) error {
+	p.changes = append(p.changes, change)
+	if p.dryRun {
+		return nil
+	}
+	return write()
+}
+
+// pending reports whether a dry run planned changes that were not applied
+func (p *changePlan) pending() bool {
+	return p.dryRun && len(p.changes) > 0
+}
+
+// planned returns the changes of a dry run, or nil if the changes were applied
+func (p *changePlan) planned() []string {
+	if !p.dryRun {
+		return nil
+	}
+	return p.changes
+}
+
+// report marks obj as not ready because the planned changes were not applied and emits an event
+// for each of them. recorder may be nil.
+func (p *changePlan) report(recorder record.EventRecorder, obj conditionsAccessor) {
+	message := fmt.Sprintf("Dry run planned %d changes: %s", len(p.changes), strings.Join(p.changes, "; "))
+	setCondition(obj, miniov1beta1.ConditionReady, metav1.ConditionFalse, reasonDryRun, message)
+	removeCondition(obj, miniov1beta1.ConditionReconciling)
+	removeCondition(obj, miniov1beta1.ConditionStalled)
+
+	if recorder == nil {
+		return
+	}
+	for _, change := range p.changes {
+		recorder.Event(obj, corev1.EventTypeNormal, reasonDryRun, change)
+	}
+}
+
+// reportPlannedDeletion records the changes a dry run planned for the deletion of obj in status and keeps
+// the finalizer, so that the cleanup is performed once the dry run is over
+func reportPlannedDeletion(ctx context.Context, c client.Client, recorder record.EventRecorder, obj conditionsAccessor, status *[]string, plan *changePlan) (ctrl.Result, error) {
+	return reportPlannedChanges(ctx, c, recorder, obj, status, plan)
+}
+
+// reportPlannedChanges records the changes a dry run planned for obj in status, for reconciliations that
+// cannot go on before the changes are applied
+func reportPlannedChanges(ctx context.Context, c client.Client, recorder record.EventRecorder, obj conditionsAccessor, status *[]string, plan *changePlan) (ctrl.Result, error) {
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	*status = plan.planned()
+	plan.report(recorder, obj)
+	return ctrl.Result{}, c.Status().Patch(ctx, obj, patch)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Change plans", func() {
+	var bucket *miniov1beta1.Bucket
+
+	BeforeEach(func() {
+		bucket = &miniov1beta1.Bucket{ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default"}}
+	})
+
+	It("should apply changes outside of dry runs", func() {
+		plan := newChangePlan(false, bucket)
+		applied := false
+		Expect(plan.apply("create bucket test-bucket", func() error {
+			applied = true
+			return nil
+		})).To(Succeed())
+		Expect(applied).To(BeTrue())
+		Expect(plan.pending()).To(BeFalse())
+		Expect(plan.planned()).To(BeNil())
+
+		err := errors.New("access denied")
+		Expect(plan.apply("remove bucket test-bucket", func() error { return err })).To(MatchError(err))
+	})
+
+	It("should only record changes in dry runs", func() {
+		bucket.Annotations = map[string]string{miniov1beta1.DryRunAnnotation: "true"}
+		plan := newChangePlan(false, bucket)
+		Expect(plan.apply("create bucket test-bucket", func() error {
+			Fail("dry runs must not write to MinIO")
+			return nil
+		})).To(Succeed())
+		Expect(plan.pending()).To(BeTrue())
+		Expect(plan.planned()).To(Equal([]string{"create bucket test-bucket"}))
+	})
+
+	It("should make every plan a dry run if enabled for the controller", func() {
+		Expect(newChangePlan(true, bucket).dryRun).To(BeTrue())
+		Expect(newChangePlan(false, bucket).dryRun).To(BeFalse())
+	})
+
+	It("should report planned changes as condition and events", func() {
+		plan := newChangePlan(true, bucket)
+		Expect(plan.apply("create bucket test-bucket", nil)).To(Succeed())
+		Expect(plan.apply("set tags of bucket test-bucket", nil)).To(Succeed())
+
+		recorder := record.NewFakeRecorder(10)
+		plan.report(recorder, bucket)
+
+		ready := meta.FindStatusCondition(bucket.Status.Conditions, miniov1beta1.ConditionReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(reasonDryRun))
+		Expect(ready.Message).To(ContainSubstring("2 changes"))
+		Expect(recorder.Events).To(HaveLen(2))
+		Expect(<-recorder.Events).To(Equal("Normal DryRun create bucket test-bucket"))
+	})
+})
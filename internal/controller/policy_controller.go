@@ -26,25 +26,32 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
 )
 
 // PolicyReconciler reconciles a Policy object
 type PolicyReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all policies
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policies/finalizers,verbs=update
-//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliases;endpoints,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliases,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=endpoints,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -60,7 +67,7 @@ func (r *PolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	logger := log.FromContext(ctx)
 
 	// Fetch the Policy instance
-	policy := &miniov1alpha1.Policy{}
+	policy := &miniov1beta1.Policy{}
 	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
 		if apierrors.IsNotFound(err) {
 			// Object deleted
@@ -69,60 +76,86 @@ func (r *PolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, err
 	}
 
+	// Resources paused by their own annotation or by their alias are left untouched, including their deletion
+	if paused, result, err := checkPaused(ctx, r.Client, policy, &policy.Spec.Connection); paused {
+		return result, err
+	}
+
 	// Handle deletion
 	if policy.DeletionTimestamp != nil {
 		return r.handleDeletion(ctx, policy)
 	}
 
 	// Add finalizer
-	if !controllerutil.ContainsFinalizer(policy, miniov1alpha1.PolicyFinalizer) {
-		controllerutil.AddFinalizer(policy, miniov1alpha1.PolicyFinalizer)
+	if !controllerutil.ContainsFinalizer(policy, miniov1beta1.PolicyFinalizer) {
+		controllerutil.AddFinalizer(policy, miniov1beta1.PolicyFinalizer)
 		if err := r.Update(ctx, policy); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
 	// Mark progressing
-	miniov1alpha1.SetCondition(&policy.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionTrue, "Reconciling", "Reconciling policy")
-	policy.Status.ObservedGeneration = policy.Generation
-	if err := r.Status().Update(ctx, policy); err != nil {
-		if apierrors.IsConflict(err) {
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+	patch := client.MergeFrom(policy.DeepCopy())
+	if markReconciling(policy, "Reconciling policy") {
+		policy.Status.ObservedGeneration = policy.Generation
+		if err := r.Status().Patch(ctx, policy, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, err
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(policy.DeepCopy())
+
+	// Enforce the tenant policies of the namespace
+	if err := tenant.Check(ctx, r.Client, policy); err != nil {
+		logger.Error(err, "Policy is not allowed by the tenant policies")
+		markFailed(policy, errorReason(err, reasonReconcileError), err.Error())
+		policy.Status.Ready = false
+		if err := r.Status().Patch(ctx, policy, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Build MinIO client
-	minioClient, err := minioclient.NewClient(ctx, r.Client, policy.Spec.Connection, policy.Namespace)
+	minioClient, err := newMinIOClient(ctx, r.Client, policy, policy.Spec.Connection)
 	if err != nil {
 		logger.Error(err, "Failed to create MinIO client")
-		miniov1alpha1.SetCondition(&policy.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ClientError", fmt.Sprintf("Failed to create MinIO client: %v", err))
+		markFailed(policy, errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err))
 		policy.Status.Ready = false
-		r.Status().Update(ctx, policy)
+		if err := r.Status().Patch(ctx, policy, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Reconcile external policy
-	result, err := r.reconcilePolicy(ctx, policy, minioClient)
+	plan := newChangePlan(r.DryRun, policy)
+	result, err := r.reconcilePolicy(ctx, policy, minioClient, plan)
 	if err != nil {
 		logger.Error(err, "Failed to reconcile policy")
-		miniov1alpha1.SetCondition(&policy.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ReconcileError", fmt.Sprintf("Failed to reconcile policy: %v", err))
+		markFailed(policy, errorReason(err, reasonReconcileError), fmt.Sprintf("Failed to reconcile policy: %v", err))
 		policy.Status.Ready = false
 		policy.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Update(ctx, policy)
+		if err := r.Status().Patch(ctx, policy, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return result, err
 	}
 
-	// Mark ready
-	miniov1alpha1.SetCondition(&policy.Status.Conditions, miniov1alpha1.ConditionReady, metav1.ConditionTrue, "Ready", "Policy is ready")
-	miniov1alpha1.SetCondition(&policy.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionFalse, "Ready", "Policy reconciliation completed")
-	policy.Status.Ready = true
+	// Mark ready, unless a dry run left changes unapplied
+	policy.Status.PlannedChanges = plan.planned()
+	if plan.pending() {
+		plan.report(r.Recorder, policy)
+		policy.Status.Ready = false
+	} else {
+		markReady(policy, "Policy is ready")
+		policy.Status.Ready = true
+	}
 	policy.Status.PolicyName = policy.Spec.PolicyName
 	policy.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-	if err := r.Status().Update(ctx, policy); err != nil {
-		if apierrors.IsConflict(err) {
-			return ctrl.Result{RequeueAfter: time.Second}, nil
-		}
+	if err := r.Status().Patch(ctx, policy, patch); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -130,18 +163,56 @@ func (r *PolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 }
 
 // handleDeletion handles deletion and finalizer logic for Policy
-func (r *PolicyReconciler) handleDeletion(ctx context.Context, policy *miniov1alpha1.Policy) (ctrl.Result, error) {
+func (r *PolicyReconciler) handleDeletion(ctx context.Context, policy *miniov1beta1.Policy) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	if controllerutil.ContainsFinalizer(policy, miniov1alpha1.PolicyFinalizer) {
+	if controllerutil.ContainsFinalizer(policy, miniov1beta1.PolicyFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, policy)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if violation {
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+			controllerutil.RemoveFinalizer(policy, miniov1beta1.PolicyFinalizer)
+			return ctrl.Result{}, r.Update(ctx, policy)
+		}
+
+		// Only the Full management policy deletes the canned policy in MinIO
+		if !policy.Spec.ManagementPolicy.Deletes() {
+			logger.Info("Keeping canned policy as required by the management policy", "policyName", policy.Spec.PolicyName, "managementPolicy", policy.Spec.ManagementPolicy)
+			controllerutil.RemoveFinalizer(policy, miniov1beta1.PolicyFinalizer)
+			return ctrl.Result{}, r.Update(ctx, policy)
+		}
+
 		// Try to create client to remove external resource
-		minioClient, err := minioclient.NewClient(ctx, r.Client, policy.Spec.Connection, policy.Namespace)
+		minioClient, err := newMinIOClient(ctx, r.Client, policy, policy.Spec.Connection)
 		if err == nil {
+			// Never remove a canned policy managed by another resource
+			existing, exists, err := lookupCannedPolicy(ctx, minioClient, policy.Spec.PolicyName)
+			if err != nil {
+				logger.Error(err, "Failed to look up canned policy for deletion, will retry", "policyName", policy.Spec.PolicyName)
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if exists && !ownedBy(policy, policyOwner(existing), policy.Status.CreationDate != nil) {
+				logger.Info("Canned policy is not owned by this resource, skipping deletion", "policyName", policy.Spec.PolicyName)
+				controllerutil.RemoveFinalizer(policy, miniov1beta1.PolicyFinalizer)
+				return ctrl.Result{}, r.Update(ctx, policy)
+			}
+
 			// Attempt to remove canned policy (ignore not found)
-			if err := minioClient.Admin.RemoveCannedPolicy(ctx, policy.Spec.PolicyName); err != nil {
+			plan := newChangePlan(r.DryRun, policy)
+			err = plan.apply(fmt.Sprintf("remove canned policy %s", policy.Spec.PolicyName), func() error {
+				return minioClient.Admin.RemoveCannedPolicy(ctx, policy.Spec.PolicyName)
+			})
+			if err != nil {
 				logger.Error(err, "Failed to remove canned policy, will retry", "policyName", policy.Spec.PolicyName)
 				return ctrl.Result{RequeueAfter: time.Minute}, nil
 			}
+			if plan.pending() {
+				return reportPlannedDeletion(ctx, r.Client, r.Recorder, policy, &policy.Status.PlannedChanges, plan)
+			}
 			logger.Info("Removed canned policy", "policyName", policy.Spec.PolicyName)
 		} else {
 			logger.Error(err, "Failed to create MinIO client for deletion, retrying")
@@ -149,7 +220,7 @@ func (r *PolicyReconciler) handleDeletion(ctx context.Context, policy *miniov1al
 		}
 
 		// Remove finalizer
-		controllerutil.RemoveFinalizer(policy, miniov1alpha1.PolicyFinalizer)
+		controllerutil.RemoveFinalizer(policy, miniov1beta1.PolicyFinalizer)
 		if err := r.Update(ctx, policy); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -159,33 +230,91 @@ func (r *PolicyReconciler) handleDeletion(ctx context.Context, policy *miniov1al
 }
 
 // reconcilePolicy ensures the MinIO canned policy matches desired spec
-func (r *PolicyReconciler) reconcilePolicy(ctx context.Context, policy *miniov1alpha1.Policy, minioClient *minioclient.Client) (ctrl.Result, error) {
+func (r *PolicyReconciler) reconcilePolicy(ctx context.Context, policy *miniov1beta1.Policy, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	desiredBytes := policy.Spec.Policy
 	if len(desiredBytes) == 0 {
 		// If empty (should not happen because required), mark error
-		return ctrl.Result{}, fmt.Errorf("policy document is empty")
+		return ctrl.Result{}, &invalidSpecError{message: "policy document is empty"}
 	}
 
 	sum := sha256.Sum256(desiredBytes)
 	hash := hex.EncodeToString(sum[:])
 
 	// Get existing policy (if any)
-	existing, err := minioClient.Admin.InfoCannedPolicy(ctx, policy.Spec.PolicyName)
-	exists := err == nil && len(existing) > 0
+	existing, exists, err := lookupCannedPolicy(ctx, minioClient, policy.Spec.PolicyName)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	managementPolicy := policy.Spec.ManagementPolicy
+	switch {
+	case !exists && !managementPolicy.Creates():
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("canned policy %s does not exist and is not created with management policy %s", policy.Spec.PolicyName, managementPolicy)
+	case exists && !correctsDrift(managementPolicy, policy.Spec.DriftPolicy):
+		// Existing canned policies are left untouched, only the hash of the live document and drift are reported
+		drift, err := policyDrift(desiredBytes, existing)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		liveSum := sha256.Sum256(existing)
+		policy.Status.PolicyHash = hex.EncodeToString(liveSum[:])
+		reportDrift(policy, "Policy", &policy.Status.Drift, drift, false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	// Only manage canned policies owned by this resource
+	owner := ""
+	var drift []miniov1beta1.DriftEntry
+	if exists {
+		owner = policyOwner(existing)
+		if err := checkOwner(policy, "canned policy", policy.Spec.PolicyName, owner, policy.Spec.Adopt, policy.Status.CreationDate != nil); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		// Differences to an unchanged spec have been made outside of the controller
+		if policy.Status.PolicyHash == hash {
+			drift, err = policyDrift(desiredBytes, existing)
+			if err != nil {
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+		}
+	}
 
-	// Only update if new or content changed
-	if !exists || policy.Status.PolicyHash != hash {
-		if err := minioClient.Admin.AddCannedPolicy(ctx, policy.Spec.PolicyName, desiredBytes); err != nil {
+	// Only update if new, content changed, drifted or not yet marked as owned by this resource
+	if !exists || policy.Status.PolicyHash != hash || len(drift) > 0 || owner != string(policy.UID) {
+		document, err := withPolicyOwner(desiredBytes, policy)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		change := fmt.Sprintf("create canned policy %s", policy.Spec.PolicyName)
+		if exists {
+			change = fmt.Sprintf("replace canned policy %s", policy.Spec.PolicyName)
+		}
+		err = plan.apply(change, func() error {
+			return minioClient.Admin.AddCannedPolicy(ctx, policy.Spec.PolicyName, document)
+		})
+		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to add/update canned policy: %w", err)
 		}
-		logger.Info("Applied canned policy", "policyName", policy.Spec.PolicyName, "updated", exists)
-		if !exists {
-			policy.Status.CreationDate = &metav1.Time{Time: time.Now()}
+		if !exists && policy.Status.CreationDate != nil {
+			// The policy was created before and has been removed outside of the controller
+			drift = append(drift, removedDrift)
+		}
+		if !plan.dryRun {
+			logger.Info("Applied canned policy", "policyName", policy.Spec.PolicyName, "updated", exists)
+			if !exists {
+				policy.Status.CreationDate = &metav1.Time{Time: time.Now()}
+			}
 		}
 	}
 
+	// A dry run keeps the hash of the applied document, so that the planned changes are applied later
+	reportDrift(policy, "Policy", &policy.Status.Drift, drift, !plan.dryRun)
+	if plan.dryRun {
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
 	policy.Status.PolicyHash = hash
 	return ctrl.Result{RequeueAfter: time.Hour}, nil
 }
@@ -193,6 +322,6 @@ func (r *PolicyReconciler) reconcilePolicy(ctx context.Context, policy *miniov1a
 // SetupWithManager sets up the controller with the Manager.
 func (r *PolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&miniov1alpha1.Policy{}).
+		For(&miniov1beta1.Policy{}).
 		Complete(r)
 }
@@ -19,15 +19,17 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 )
 
 var _ = Describe("Policy Controller", func() {
@@ -40,22 +42,22 @@ var _ = Describe("Policy Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		policy := &miniov1alpha1.Policy{}
+		policy := &miniov1beta1.Policy{}
 
 		BeforeEach(func() {
 			By("creating the custom resource for the Kind Policy")
 			err := k8sClient.Get(ctx, typeNamespacedName, policy)
 			if err != nil && errors.IsNotFound(err) {
 				policy := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::test-bucket/*"]}]}`
-				resource := &miniov1alpha1.Policy{
+				resource := &miniov1beta1.Policy{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
 					},
-					Spec: miniov1alpha1.PolicySpec{
-						Connection: miniov1alpha1.MinIOConnection{
+					Spec: miniov1beta1.PolicySpec{
+						Connection: miniov1beta1.MinIOConnection{
 							URL: &[]string{"http://localhost:9000"}[0],
-							SecretRef: &miniov1alpha1.SecretReference{
+							SecretRef: &miniov1beta1.SecretReference{
 								Name: "minio-secret",
 							},
 						},
@@ -71,7 +73,7 @@ var _ = Describe("Policy Controller", func() {
 
 		AfterEach(func() {
 			// Clean up the resource if it exists
-			resource := &miniov1alpha1.Policy{}
+			resource := &miniov1beta1.Policy{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			if err == nil {
 				By("Cleanup the specific resource instance Policy")
@@ -97,4 +99,24 @@ var _ = Describe("Policy Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When the canned policy cannot be looked up", func() {
+		It("should not create the canned policy with management policy CreateOnly", func() {
+			admin := newFailingAdmin(http.StatusInternalServerError, "XMinioServerNotInitialized")
+			defer admin.server.Close()
+
+			policy := &miniov1beta1.Policy{
+				ObjectMeta: metav1.ObjectMeta{Name: "readers", Namespace: "default", UID: "uid-1"},
+				Spec: miniov1beta1.PolicySpec{
+					PolicyName:       "readers",
+					Policy:           []byte(`{"Version":"2012-10-17","Statement":[]}`),
+					ManagementPolicy: miniov1beta1.ManagementPolicyCreateOnly,
+				},
+			}
+			reconciler := &PolicyReconciler{}
+			_, err := reconciler.reconcilePolicy(context.Background(), policy, admin.client(), newChangePlan(false, policy))
+			Expect(err).To(HaveOccurred())
+			Expect(admin.called()).To(Equal([]string{"info-canned-policy"}))
+		})
+	})
 })
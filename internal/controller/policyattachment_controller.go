@@ -19,36 +19,48 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/minio/madmin-go/v3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
 )
 
 // PolicyAttachmentReconciler reconciles a PolicyAttachment object
 type PolicyAttachmentReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all policy attachments
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policyattachments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policyattachments/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=policyattachments/finalizers,verbs=update
-//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliases;endpoints;users,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliases,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=endpoints,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=users,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=identityproviders,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 func (r *PolicyAttachmentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	attachment := &miniov1alpha1.PolicyAttachment{}
+	attachment := &miniov1beta1.PolicyAttachment{}
 	if err := r.Get(ctx, req.NamespacedName, attachment); err != nil {
 		if apierrors.IsNotFound(err) {
 			return ctrl.Result{}, nil
@@ -56,81 +68,125 @@ func (r *PolicyAttachmentReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	// Resources paused by their own annotation or by their alias are left untouched, including their deletion
+	if paused, result, err := checkPaused(ctx, r.Client, attachment, &attachment.Spec.Connection); paused {
+		return result, err
+	}
+
 	// Deletion handling
 	if attachment.DeletionTimestamp != nil {
 		return r.handleDeletion(ctx, attachment)
 	}
 
 	// Add finalizer
-	if !controllerutil.ContainsFinalizer(attachment, miniov1alpha1.PolicyAttachmentFinalizer) {
-		controllerutil.AddFinalizer(attachment, miniov1alpha1.PolicyAttachmentFinalizer)
+	if !controllerutil.ContainsFinalizer(attachment, miniov1beta1.PolicyAttachmentFinalizer) {
+		controllerutil.AddFinalizer(attachment, miniov1beta1.PolicyAttachmentFinalizer)
 		if err := r.Update(ctx, attachment); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
 	// Progressing status
-	miniov1alpha1.SetCondition(&attachment.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionTrue, "Reconciling", "Reconciling policy attachment")
-	attachment.Status.ObservedGeneration = attachment.Generation
-	if err := r.Status().Update(ctx, attachment); err != nil {
-		if apierrors.IsConflict(err) {
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+	patch := client.MergeFrom(attachment.DeepCopy())
+	if markReconciling(attachment, "Reconciling policy attachment") {
+		attachment.Status.ObservedGeneration = attachment.Generation
+		if err := r.Status().Patch(ctx, attachment, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, err
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(attachment.DeepCopy())
+
+	// Enforce the tenant policies of the namespace
+	if err := tenant.Check(ctx, r.Client, attachment); err != nil {
+		logger.Error(err, "Policy attachment is not allowed by the tenant policies")
+		markFailed(attachment, errorReason(err, reasonReconcileError), err.Error())
+		attachment.Status.Ready = false
+		if err := r.Status().Patch(ctx, attachment, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Build MinIO client
-	minioClient, err := minioclient.NewClient(ctx, r.Client, attachment.Spec.Connection, attachment.Namespace)
+	minioClient, err := newMinIOClient(ctx, r.Client, attachment, attachment.Spec.Connection)
 	if err != nil {
 		logger.Error(err, "Failed to create MinIO client")
-		miniov1alpha1.SetCondition(&attachment.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ClientError", fmt.Sprintf("Failed to create MinIO client: %v", err))
+		markFailed(attachment, errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err))
 		attachment.Status.Ready = false
-		_ = r.Status().Update(ctx, attachment)
+		if err := r.Status().Patch(ctx, attachment, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Reconcile external state
-	result, err := r.reconcileAttachment(ctx, attachment, minioClient)
+	plan := newChangePlan(r.DryRun, attachment)
+	result, err := r.reconcileAttachment(ctx, attachment, minioClient, plan)
 	if err != nil {
 		logger.Error(err, "Failed to reconcile policy attachment")
-		miniov1alpha1.SetCondition(&attachment.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ReconcileError", fmt.Sprintf("Failed to reconcile policy attachment: %v", err))
+		markFailed(attachment, errorReason(err, reasonReconcileError), fmt.Sprintf("Failed to reconcile policy attachment: %v", err))
 		attachment.Status.Ready = false
 		attachment.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-		_ = r.Status().Update(ctx, attachment)
+		if err := r.Status().Patch(ctx, attachment, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return result, err
 	}
 
-	miniov1alpha1.SetCondition(&attachment.Status.Conditions, miniov1alpha1.ConditionReady, metav1.ConditionTrue, "Ready", "Policy attachment is ready")
-	miniov1alpha1.SetCondition(&attachment.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionFalse, "Ready", "Policy attachment reconciliation completed")
-	attachment.Status.Ready = true
+	// Mark ready, unless a dry run left changes unapplied
+	attachment.Status.PlannedChanges = plan.planned()
+	if plan.pending() {
+		plan.report(r.Recorder, attachment)
+		attachment.Status.Ready = false
+	} else {
+		markReady(attachment, "Policy attachment is ready")
+		attachment.Status.Ready = true
+		if attachment.Status.AttachedAt == nil {
+			attachment.Status.AttachedAt = &metav1.Time{Time: time.Now()}
+		}
+	}
 	attachment.Status.PolicyName = attachment.Spec.PolicyName
 	attachment.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-	if attachment.Status.AttachedAt == nil {
-		attachment.Status.AttachedAt = &metav1.Time{Time: time.Now()}
-	}
-	if err := r.Status().Update(ctx, attachment); err != nil {
-		if apierrors.IsConflict(err) {
-			return ctrl.Result{RequeueAfter: time.Second}, nil
-		}
+	if err := r.Status().Patch(ctx, attachment, patch); err != nil {
 		return ctrl.Result{}, err
 	}
 
 	return result, nil
 }
 
-func (r *PolicyAttachmentReconciler) handleDeletion(ctx context.Context, attachment *miniov1alpha1.PolicyAttachment) (ctrl.Result, error) {
+func (r *PolicyAttachmentReconciler) handleDeletion(ctx context.Context, attachment *miniov1beta1.PolicyAttachment) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	if controllerutil.ContainsFinalizer(attachment, miniov1alpha1.PolicyAttachmentFinalizer) {
-		minioClient, err := minioclient.NewClient(ctx, r.Client, attachment.Spec.Connection, attachment.Namespace)
+	if controllerutil.ContainsFinalizer(attachment, miniov1beta1.PolicyAttachmentFinalizer) {
+		// Only the Full management policy detaches the policy in MinIO
+		if !attachment.Spec.ManagementPolicy.Deletes() {
+			logger.Info("Keeping policy attached as required by the management policy", "policy", attachment.Spec.PolicyName, "managementPolicy", attachment.Spec.ManagementPolicy)
+			controllerutil.RemoveFinalizer(attachment, miniov1beta1.PolicyAttachmentFinalizer)
+			return ctrl.Result{}, r.Update(ctx, attachment)
+		}
+
+		minioClient, err := newMinIOClient(ctx, r.Client, attachment, attachment.Spec.Connection)
 		if err == nil {
-			// Detach policy by setting empty policy
-			target, isGroup, err2 := resolveTarget(attachment.Spec.Target)
+			target, kind, err2 := resolveTarget(attachment.Spec)
+			if err2 == nil && kind == attachOpenIDClaim {
+				// Claims are mapped by publishing the policy under the claim value, which is removed again
+				return r.removeClaimPolicy(ctx, attachment, minioClient, target)
+			}
 			if err2 == nil && target != "" {
-				if err3 := minioClient.Admin.SetPolicy(ctx, "", target, isGroup); err3 != nil {
+				plan := newChangePlan(r.DryRun, attachment)
+				err3 := plan.apply(fmt.Sprintf("detach policy %s from %s %s", attachment.Spec.PolicyName, kind, target), func() error {
+					return detachPolicy(ctx, minioClient, attachment.Spec.PolicyName, target, kind)
+				})
+				if err3 != nil {
 					logger.Error(err3, "Failed to detach policy (will retry)", "target", target)
 					return ctrl.Result{RequeueAfter: time.Minute}, nil
 				}
+				if plan.pending() {
+					return reportPlannedDeletion(ctx, r.Client, r.Recorder, attachment, &attachment.Status.PlannedChanges, plan)
+				}
 				logger.Info("Detached policy from target", "target", target)
 			}
 		} else {
@@ -138,7 +194,7 @@ func (r *PolicyAttachmentReconciler) handleDeletion(ctx context.Context, attachm
 			return ctrl.Result{RequeueAfter: time.Minute}, nil
 		}
 
-		controllerutil.RemoveFinalizer(attachment, miniov1alpha1.PolicyAttachmentFinalizer)
+		controllerutil.RemoveFinalizer(attachment, miniov1beta1.PolicyAttachmentFinalizer)
 		if err := r.Update(ctx, attachment); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -147,64 +203,302 @@ func (r *PolicyAttachmentReconciler) handleDeletion(ctx context.Context, attachm
 	return ctrl.Result{}, nil
 }
 
-func (r *PolicyAttachmentReconciler) reconcileAttachment(ctx context.Context, attachment *miniov1alpha1.PolicyAttachment, minioClient *minioclient.Client) (ctrl.Result, error) {
+func (r *PolicyAttachmentReconciler) reconcileAttachment(ctx context.Context, attachment *miniov1beta1.PolicyAttachment, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	target, isGroup, err := resolveTarget(attachment.Spec.Target)
+	target, kind, err := resolveTarget(attachment.Spec)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("invalid target: %w", err)
 	}
 
 	// Validate target existence
-	if isGroup {
+	switch kind {
+	case attachGroup:
 		return ctrl.Result{}, fmt.Errorf("group targets not implemented")
-	} else {
-		_, err := minioClient.Admin.GetUserInfo(ctx, target)
-		if err != nil {
-			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("user %s not found or not ready: %w", target, err)
-		}
+	case attachOpenIDClaim:
+		return r.reconcileClaim(ctx, attachment, minioClient, plan, target)
+	}
+	attached, err := policyAttached(ctx, minioClient, attachment.Spec.PolicyName, target, kind)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	attachment.Status.Target = target
+
+	// A policy attached by an unchanged spec has been detached outside of the controller
+	var drift []miniov1beta1.DriftEntry
+	if !attached && attachment.Status.AttachedAt != nil && specSynced(attachment) {
+		drift = []miniov1beta1.DriftEntry{{Field: "attached", Desired: "true", Actual: "false"}}
+	}
+
+	managementPolicy := attachment.Spec.ManagementPolicy
+	switch {
+	case !attached && !managementPolicy.Creates():
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("policy %s is not attached to %s and is not attached with management policy %s", attachment.Spec.PolicyName, target, managementPolicy)
+	case attached:
+		// Existing attachments are left untouched
+		reportDrift(attachment, "PolicyAttachment", &attachment.Status.Drift, nil, false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	case len(drift) > 0 && !correctsDrift(managementPolicy, attachment.Spec.DriftPolicy):
+		reportDrift(attachment, "PolicyAttachment", &attachment.Status.Drift, drift, false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
 	}
 
 	// Attach policy
-	if err := minioClient.Admin.SetPolicy(ctx, attachment.Spec.PolicyName, target, isGroup); err != nil {
+	err = plan.apply(fmt.Sprintf("attach policy %s to %s %s", attachment.Spec.PolicyName, kind, target), func() error {
+		return attachPolicy(ctx, minioClient, attachment.Spec.PolicyName, target, kind)
+	})
+	if err != nil {
 		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set policy: %w", err)
 	}
 
-	attachment.Status.Target = target
-	logger.Info("Attached policy", "policy", attachment.Spec.PolicyName, "target", target, "group", isGroup)
+	if !plan.dryRun {
+		logger.Info("Attached policy", "policy", attachment.Spec.PolicyName, "target", target, "kind", kind)
+	}
+	reportDrift(attachment, "PolicyAttachment", &attachment.Status.Drift, drift, !plan.dryRun)
 
 	return ctrl.Result{RequeueAfter: time.Hour}, nil
 }
 
-func resolveTarget(t miniov1alpha1.PolicyAttachmentTarget) (string, bool, error) {
+// reconcileClaim maps a value of the policy claim of an OpenID identity provider to the policy. MinIO
+// grants the canned policies named by the claim values, so unless the claim value is the policy name,
+// the policy document is published under the claim value as a canned policy owned by the attachment.
+func (r *PolicyAttachmentReconciler) reconcileClaim(ctx context.Context, attachment *miniov1beta1.PolicyAttachment, minioClient *minioclient.Client, plan *changePlan, claimValue string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	providerName := attachment.Spec.Target.OpenIDClaim.IdentityProviderRef.Name
+	provider := &miniov1beta1.IdentityProvider{}
+	if err := r.Get(ctx, client.ObjectKey{Name: providerName, Namespace: attachment.Namespace}, provider); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get identity provider %s: %w", providerName, err)
+	}
+	if err := checkClaimProvider(provider); err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	attachment.Status.Target = claimTarget(provider, claimValue)
+
+	source, err := minioClient.Admin.InfoCannedPolicy(ctx, attachment.Spec.PolicyName)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("policy %s not found: %w", attachment.Spec.PolicyName, err)
+	}
+	if claimValue == attachment.Spec.PolicyName {
+		// The claim value names the policy itself
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	existing, exists, err := lookupCannedPolicy(ctx, minioClient, claimValue)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	var drift []miniov1beta1.DriftEntry
+	if exists {
+		if err := checkOwner(attachment, "canned policy", claimValue, policyOwner(existing), false, false); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		if drift, err = policyDrift(source, existing); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+	}
+
+	managementPolicy := attachment.Spec.ManagementPolicy
+	switch {
+	case !exists && !managementPolicy.Creates():
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("claim value %s is not mapped to policy %s and is not mapped with management policy %s", claimValue, attachment.Spec.PolicyName, managementPolicy)
+	case exists && len(drift) == 0:
+		reportDrift(attachment, "PolicyAttachment", &attachment.Status.Drift, nil, false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	case exists && !correctsDrift(managementPolicy, attachment.Spec.DriftPolicy):
+		reportDrift(attachment, "PolicyAttachment", &attachment.Status.Drift, drift, false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	document, err := withPolicyOwner(source, attachment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = plan.apply(fmt.Sprintf("map claim value %s to policy %s", claimValue, attachment.Spec.PolicyName), func() error {
+		return minioClient.Admin.AddCannedPolicy(ctx, claimValue, document)
+	})
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to map claim value: %w", err)
+	}
+	if !plan.dryRun {
+		logger.Info("Mapped claim value to policy", "policy", attachment.Spec.PolicyName, "claimValue", claimValue, "identityProvider", providerName)
+	}
+	reportDrift(attachment, "PolicyAttachment", &attachment.Status.Drift, drift, !plan.dryRun)
+
+	// Changes of the source policy are followed
+	return ctrl.Result{RequeueAfter: 10 * time.Minute}, nil
+}
+
+// removeClaimPolicy removes the canned policy publishing the policy under a claim value
+func (r *PolicyAttachmentReconciler) removeClaimPolicy(ctx context.Context, attachment *miniov1beta1.PolicyAttachment, minioClient *minioclient.Client, claimValue string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if claimValue != attachment.Spec.PolicyName {
+		// Never remove a canned policy managed by another resource
+		existing, exists, err := lookupCannedPolicy(ctx, minioClient, claimValue)
+		if err != nil {
+			logger.Error(err, "Failed to look up claim value policy (will retry)", "claimValue", claimValue)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if exists && ownedBy(attachment, policyOwner(existing), false) {
+			plan := newChangePlan(r.DryRun, attachment)
+			err = plan.apply(fmt.Sprintf("unmap claim value %s from policy %s", claimValue, attachment.Spec.PolicyName), func() error {
+				return minioClient.Admin.RemoveCannedPolicy(ctx, claimValue)
+			})
+			if err != nil {
+				logger.Error(err, "Failed to unmap claim value (will retry)", "claimValue", claimValue)
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if plan.pending() {
+				return reportPlannedDeletion(ctx, r.Client, r.Recorder, attachment, &attachment.Status.PlannedChanges, plan)
+			}
+			logger.Info("Unmapped claim value from policy", "claimValue", claimValue)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(attachment, miniov1beta1.PolicyAttachmentFinalizer)
+	return ctrl.Result{}, r.Update(ctx, attachment)
+}
+
+// attachmentTargetKind is the kind of principal a policy is attached to
+type attachmentTargetKind string
+
+const (
+	attachUser        attachmentTargetKind = "user"
+	attachGroup       attachmentTargetKind = "group"
+	attachLDAPUser    attachmentTargetKind = "LDAP user"
+	attachLDAPGroup   attachmentTargetKind = "LDAP group"
+	attachOpenIDClaim attachmentTargetKind = "OpenID claim"
+)
+
+func resolveTarget(spec miniov1beta1.PolicyAttachmentSpec) (string, attachmentTargetKind, error) {
+	t := spec.Target
 	count := 0
 	var name string
-	var isGroup bool
+	var kind attachmentTargetKind
 
 	if t.User != nil && *t.User != "" {
 		count++
-		name = *t.User
-		isGroup = false
+		name, kind = *t.User, attachUser
 	}
 	if t.Group != nil && *t.Group != "" {
 		count++
-		name = *t.Group
-		isGroup = true
+		name, kind = *t.Group, attachGroup
 	}
 	if t.ServiceAccount != nil && *t.ServiceAccount != "" {
 		// ServiceAccount not implemented yet
 		count++
 	}
+	if t.LDAPUser != nil && *t.LDAPUser != "" {
+		count++
+		name, kind = *t.LDAPUser, attachLDAPUser
+	}
+	if t.LDAPGroup != nil && *t.LDAPGroup != "" {
+		count++
+		name, kind = *t.LDAPGroup, attachLDAPGroup
+	}
+	if t.OpenIDClaim != nil {
+		count++
+		name, kind = t.OpenIDClaim.ClaimValue, attachOpenIDClaim
+		if name == "" {
+			name = spec.PolicyName
+		}
+	}
 
 	if count != 1 {
-		return "", false, fmt.Errorf("exactly one of user or group (serviceAccount not supported) must be specified")
+		return "", "", &invalidSpecError{message: "exactly one of user, group, ldapUser, ldapGroup or openIDClaim (serviceAccount not supported) must be specified"}
+	}
+	return name, kind, nil
+}
+
+// policyAttached reports whether the policy is attached to the user or LDAP principal
+func policyAttached(ctx context.Context, minioClient *minioclient.Client, policyName, target string, kind attachmentTargetKind) (bool, error) {
+	if kind == attachUser {
+		userInfo, err := minioClient.Admin.GetUserInfo(ctx, target)
+		if err != nil {
+			return false, fmt.Errorf("user %s not found or not ready: %w", target, err)
+		}
+		return slices.Contains(strings.Split(userInfo.PolicyName, ","), policyName), nil
+	}
+
+	query := madmin.PolicyEntitiesQuery{Users: []string{target}}
+	if kind == attachLDAPGroup {
+		query = madmin.PolicyEntitiesQuery{Groups: []string{target}}
+	}
+	entities, err := minioClient.Admin.GetLDAPPolicyEntities(ctx, query)
+	if err != nil {
+		return false, fmt.Errorf("failed to get policies of %s %s: %w", kind, target, err)
+	}
+	return ldapPolicyAttached(entities, policyName, target, kind), nil
+}
+
+// ldapPolicyAttached reports whether the LDAP policy entities map the policy to the DN. MinIO returns
+// normalized DNs, so they are compared case-insensitively.
+func ldapPolicyAttached(entities madmin.PolicyEntitiesResult, policyName, dn string, kind attachmentTargetKind) bool {
+	if kind == attachLDAPGroup {
+		return slices.ContainsFunc(entities.GroupMappings, func(mapping madmin.GroupPolicyEntities) bool {
+			return strings.EqualFold(mapping.Group, dn) && slices.Contains(mapping.Policies, policyName)
+		})
+	}
+	return slices.ContainsFunc(entities.UserMappings, func(mapping madmin.UserPolicyEntities) bool {
+		return strings.EqualFold(mapping.User, dn) && slices.Contains(mapping.Policies, policyName)
+	})
+}
+
+// attachPolicy attaches the policy to the user or LDAP principal
+func attachPolicy(ctx context.Context, minioClient *minioclient.Client, policyName, target string, kind attachmentTargetKind) error {
+	switch kind {
+	case attachLDAPUser:
+		_, err := minioClient.Admin.AttachPolicyLDAP(ctx, madmin.PolicyAssociationReq{Policies: []string{policyName}, User: target})
+		return err
+	case attachLDAPGroup:
+		_, err := minioClient.Admin.AttachPolicyLDAP(ctx, madmin.PolicyAssociationReq{Policies: []string{policyName}, Group: target})
+		return err
+	}
+	return minioClient.Admin.SetPolicy(ctx, policyName, target, kind == attachGroup)
+}
+
+// detachPolicy detaches the policy from the user or LDAP principal
+func detachPolicy(ctx context.Context, minioClient *minioclient.Client, policyName, target string, kind attachmentTargetKind) error {
+	switch kind {
+	case attachLDAPUser:
+		_, err := minioClient.Admin.DetachPolicyLDAP(ctx, madmin.PolicyAssociationReq{Policies: []string{policyName}, User: target})
+		return err
+	case attachLDAPGroup:
+		_, err := minioClient.Admin.DetachPolicyLDAP(ctx, madmin.PolicyAssociationReq{Policies: []string{policyName}, Group: target})
+		return err
+	}
+	// Detach policy by setting empty policy
+	return minioClient.Admin.SetPolicy(ctx, "", target, kind == attachGroup)
+}
+
+// checkClaimProvider checks that the identity provider grants policies by the values of its policy claim
+func checkClaimProvider(provider *miniov1beta1.IdentityProvider) error {
+	switch {
+	case provider.Spec.Type != miniov1beta1.IdentityProviderTypeOpenID:
+		return fmt.Errorf("identity provider %s is not an OpenID provider", provider.Name)
+	case provider.Spec.Config["role_policy"] != "":
+		return fmt.Errorf("identity provider %s grants its role policy instead of the policies of its claims", provider.Name)
+	case !provider.Status.Ready:
+		return fmt.Errorf("identity provider %s is not ready", provider.Name)
+	}
+	return nil
+}
+
+// claimTarget describes the claim of an identity provider, e.g. openid:_/policy=readonly
+func claimTarget(provider *miniov1beta1.IdentityProvider, claimValue string) string {
+	claimName := provider.Spec.Config["claim_name"]
+	if claimName == "" {
+		// MinIO reads the policies from the policy claim by default
+		claimName = "policy"
 	}
-	return name, isGroup, nil
+	return fmt.Sprintf("openid:%s/%s=%s", identityProviderConfigName(provider), claimName, claimValue)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *PolicyAttachmentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&miniov1alpha1.PolicyAttachment{}).
+		For(&miniov1beta1.PolicyAttachment{}).
 		Complete(r)
 }
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 
+	"github.com/minio/madmin-go/v3"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -27,7 +28,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 )
 
 var _ = Describe("PolicyAttachment Controller", func() {
@@ -40,13 +41,13 @@ var _ = Describe("PolicyAttachment Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		policyattachment := &miniov1alpha1.PolicyAttachment{}
+		policyattachment := &miniov1beta1.PolicyAttachment{}
 
 		BeforeEach(func() {
 			By("creating the custom resource for the Kind PolicyAttachment")
 			err := k8sClient.Get(ctx, typeNamespacedName, policyattachment)
 			if err != nil && errors.IsNotFound(err) {
-				resource := &miniov1alpha1.PolicyAttachment{
+				resource := &miniov1beta1.PolicyAttachment{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
@@ -59,7 +60,7 @@ var _ = Describe("PolicyAttachment Controller", func() {
 
 		AfterEach(func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &miniov1alpha1.PolicyAttachment{}
+			resource := &miniov1beta1.PolicyAttachment{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -81,4 +82,88 @@ var _ = Describe("PolicyAttachment Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When resolving the target", func() {
+		It("should resolve LDAP distinguished names", func() {
+			dn := "cn=developers,ou=groups,dc=example,dc=com"
+			target, kind, err := resolveTarget(miniov1beta1.PolicyAttachmentSpec{
+				PolicyName: "readwrite",
+				Target:     miniov1beta1.PolicyAttachmentTarget{LDAPGroup: &dn},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(Equal(dn))
+			Expect(kind).To(Equal(attachLDAPGroup))
+		})
+
+		It("should default the claim value to the policy name", func() {
+			target, kind, err := resolveTarget(miniov1beta1.PolicyAttachmentSpec{
+				PolicyName: "readwrite",
+				Target: miniov1beta1.PolicyAttachmentTarget{OpenIDClaim: &miniov1beta1.OpenIDClaimTarget{
+					IdentityProviderRef: miniov1beta1.IdentityProviderReference{Name: "keycloak"},
+				}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(Equal("readwrite"))
+			Expect(kind).To(Equal(attachOpenIDClaim))
+		})
+
+		It("should reject multiple targets", func() {
+			user, dn := "app", "uid=app,ou=people,dc=example,dc=com"
+			_, _, err := resolveTarget(miniov1beta1.PolicyAttachmentSpec{
+				PolicyName: "readwrite",
+				Target:     miniov1beta1.PolicyAttachmentTarget{User: &user, LDAPUser: &dn},
+			})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When checking LDAP policy mappings", func() {
+		entities := madmin.PolicyEntitiesResult{
+			UserMappings: []madmin.UserPolicyEntities{
+				{User: "uid=app,ou=people,dc=example,dc=com", Policies: []string{"readonly", "diagnostics"}},
+			},
+			GroupMappings: []madmin.GroupPolicyEntities{
+				{Group: "cn=developers,ou=groups,dc=example,dc=com", Policies: []string{"readwrite"}},
+			},
+		}
+
+		It("should compare distinguished names case-insensitively", func() {
+			Expect(ldapPolicyAttached(entities, "diagnostics", "UID=app,OU=people,DC=example,DC=com", attachLDAPUser)).To(BeTrue())
+			Expect(ldapPolicyAttached(entities, "readwrite", "cn=developers,ou=groups,dc=example,dc=com", attachLDAPGroup)).To(BeTrue())
+		})
+
+		It("should not mix up users and groups", func() {
+			Expect(ldapPolicyAttached(entities, "readwrite", "cn=developers,ou=groups,dc=example,dc=com", attachLDAPUser)).To(BeFalse())
+			Expect(ldapPolicyAttached(entities, "readwrite", "uid=app,ou=people,dc=example,dc=com", attachLDAPUser)).To(BeFalse())
+		})
+	})
+
+	Context("When mapping OpenID claims", func() {
+		provider := &miniov1beta1.IdentityProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "keycloak"},
+			Spec: miniov1beta1.IdentityProviderSpec{
+				Type:       miniov1beta1.IdentityProviderTypeOpenID,
+				ConfigName: "keycloak",
+				Config:     map[string]string{"config_url": "https://keycloak.example.com/.well-known/openid-configuration", "claim_name": "groups"},
+			},
+			Status: miniov1beta1.IdentityProviderStatus{Ready: true},
+		}
+
+		It("should describe the claim of the provider", func() {
+			Expect(claimTarget(provider, "platform")).To(Equal("openid:keycloak/groups=platform"))
+			Expect(checkClaimProvider(provider)).To(Succeed())
+		})
+
+		It("should reject providers granting a role policy", func() {
+			rolePolicy := provider.DeepCopy()
+			rolePolicy.Spec.Config = map[string]string{"role_policy": "readonly"}
+			Expect(checkClaimProvider(rolePolicy)).NotTo(Succeed())
+		})
+
+		It("should reject LDAP providers", func() {
+			ldap := provider.DeepCopy()
+			ldap.Spec.Type = miniov1beta1.IdentityProviderTypeLDAP
+			Expect(checkClaimProvider(ldap)).NotTo(Succeed())
+		})
+	})
 })
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
+)
+
+// resourceStatus points to the status fields the reconcile lifecycle maintains for a resource
+type resourceStatus struct {
+	ready              *bool
+	observedGeneration *int64
+	lastSyncTime       **metav1.Time
+	plannedChanges     *[]string
+}
+
+// resourceLifecycle is the reconcile lifecycle shared by the resources that configure a MinIO server:
+// the resource is fetched, left alone while paused, deleted through its finalizer, checked against the
+// tenant policies, connected to MinIO and reconciled, and its status is patched with the outcome.
+// T is the resource type and C the connection to MinIO the kind needs.
+type resourceLifecycle[T conditionsAccessor, C any] struct {
+	client.Client
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes
+	DryRun bool
+
+	// name names the kind in log and status messages, e.g. "bucket replication"
+	name string
+	// finalizer guards the removal of the resource from MinIO
+	finalizer string
+	// status returns the status fields of the resource
+	status func(obj T) resourceStatus
+	// connections returns the connections whose aliases pause the resource
+	connections func(ctx context.Context, obj T) ([]*miniov1beta1.MinIOConnection, error)
+	// delete removes the resource from MinIO and its finalizer
+	delete func(ctx context.Context, obj T) (ctrl.Result, error)
+	// connect creates the MinIO clients of the resource
+	connect func(ctx context.Context, obj T) (C, error)
+	// reconcile applies the resource to MinIO through plan
+	reconcile func(ctx context.Context, obj T, conn C, plan *changePlan) (ctrl.Result, error)
+	// ready reports whether the applied resource is in effect, and the reason and message of a Ready
+	// condition that is not. All applied resources are ready if it is nil.
+	ready func(obj T) (ready bool, reason, message string)
+}
+
+// run reconciles the resource named by req into obj
+func (l *resourceLifecycle[T, C]) run(ctx context.Context, req ctrl.Request, obj T) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if err := l.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object deleted
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Resources paused by their own annotation or by the alias of any connection are left untouched,
+	// including their deletion
+	connections, err := l.connections(ctx, obj)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(connections) == 0 {
+		connections = append(connections, nil)
+	}
+	for _, conn := range connections {
+		if paused, result, err := checkPaused(ctx, l.Client, obj, conn); paused {
+			return result, err
+		}
+	}
+
+	// Handle deletion
+	if obj.GetDeletionTimestamp() != nil {
+		return l.delete(ctx, obj)
+	}
+
+	// Add finalizer
+	if !controllerutil.ContainsFinalizer(obj, l.finalizer) {
+		controllerutil.AddFinalizer(obj, l.finalizer)
+		if err := l.Update(ctx, obj); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Mark progressing
+	status := l.status(obj)
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if markReconciling(obj, "Reconciling "+l.name) {
+		*status.observedGeneration = obj.GetGeneration()
+		if err := l.Status().Patch(ctx, obj, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	fail := func(reason, message string, synced bool) {
+		markFailed(obj, reason, message)
+		*status.ready = false
+		if synced {
+			*status.lastSyncTime = &metav1.Time{Time: time.Now()}
+		}
+		if err := l.Status().Patch(ctx, obj, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+	}
+
+	// Enforce the tenant policies of the namespace, cluster-scoped resources are not subject to them
+	if obj.GetNamespace() != "" {
+		if err := tenant.Check(ctx, l.Client, obj); err != nil {
+			logger.Error(err, "Resource is not allowed by the tenant policies")
+			fail(errorReason(err, reasonReconcileError), err.Error(), false)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+	}
+
+	// Build the MinIO clients
+	conn, err := l.connect(ctx, obj)
+	if err != nil {
+		logger.Error(err, "Failed to create MinIO client")
+		fail(errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err), false)
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// Reconcile the resource
+	plan := newChangePlan(l.DryRun, obj)
+	result, err := l.reconcile(ctx, obj, conn, plan)
+	if err != nil {
+		logger.Error(err, "Failed to reconcile "+l.name)
+		fail(errorReason(err, reasonReconcileError), fmt.Sprintf("Failed to reconcile %s: %v", l.name, err), true)
+		return result, err
+	}
+
+	// Mark ready once the resource is in effect, unless a dry run left changes unapplied
+	ready, reason, message := true, "", strings.ToUpper(l.name[:1])+l.name[1:]+" is ready"
+	if l.ready != nil {
+		ready, reason, message = l.ready(obj)
+	}
+	*status.plannedChanges = plan.planned()
+	switch {
+	case plan.pending():
+		plan.report(l.Recorder, obj)
+		*status.ready = false
+	case !ready:
+		setCondition(obj, miniov1beta1.ConditionReady, metav1.ConditionFalse, reason, message)
+		removeCondition(obj, miniov1beta1.ConditionReconciling)
+		removeCondition(obj, miniov1beta1.ConditionStalled)
+		*status.ready = false
+	default:
+		markReady(obj, message)
+		*status.ready = true
+	}
+	*status.lastSyncTime = &metav1.Time{Time: time.Now()}
+	if err := l.Status().Patch(ctx, obj, patch); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// singleConnection returns the connections hook of a resource reconciled through the connection conn returns
+func singleConnection[T conditionsAccessor](conn func(obj T) *miniov1beta1.MinIOConnection) func(context.Context, T) ([]*miniov1beta1.MinIOConnection, error) {
+	return func(_ context.Context, obj T) ([]*miniov1beta1.MinIOConnection, error) {
+		return []*miniov1beta1.MinIOConnection{conn(obj)}, nil
+	}
+}
+
+// clientFor returns the connect hook of a resource reconciled through the connection conn returns
+func clientFor[T conditionsAccessor](c client.Client, conn func(obj T) *miniov1beta1.MinIOConnection) func(context.Context, T) (*minioclient.Client, error) {
+	return func(ctx context.Context, obj T) (*minioclient.Client, error) {
+		return newMinIOClient(ctx, c, obj, *conn(obj))
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Resource lifecycle", func() {
+	var (
+		ctx        context.Context
+		c          client.Client
+		tier       *miniov1beta1.Tier
+		calls      []string
+		connectErr error
+		ready      bool
+		lifecycle  *resourceLifecycle[*miniov1beta1.Tier, string]
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+		tier = &miniov1beta1.Tier{
+			ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: "default", Generation: 1},
+			Spec:       miniov1beta1.TierSpec{TierName: "WARM"},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(tier).
+			WithStatusSubresource(&miniov1beta1.Tier{}).Build()
+
+		calls, connectErr, ready = nil, nil, true
+		connection := func(tier *miniov1beta1.Tier) *miniov1beta1.MinIOConnection { return &tier.Spec.Connection }
+		lifecycle = &resourceLifecycle[*miniov1beta1.Tier, string]{
+			Client:    c,
+			name:      "tier",
+			finalizer: miniov1beta1.TierFinalizer,
+			status: func(tier *miniov1beta1.Tier) resourceStatus {
+				return resourceStatus{&tier.Status.Ready, &tier.Status.ObservedGeneration, &tier.Status.LastSyncTime, &tier.Status.PlannedChanges}
+			},
+			connections: singleConnection(connection),
+			delete: func(context.Context, *miniov1beta1.Tier) (ctrl.Result, error) {
+				calls = append(calls, "delete")
+				return ctrl.Result{}, nil
+			},
+			connect: func(context.Context, *miniov1beta1.Tier) (string, error) {
+				calls = append(calls, "connect")
+				return "client", connectErr
+			},
+			reconcile: func(_ context.Context, _ *miniov1beta1.Tier, conn string, _ *changePlan) (ctrl.Result, error) {
+				calls = append(calls, "reconcile with "+conn)
+				return ctrl.Result{RequeueAfter: time.Hour}, nil
+			},
+			ready: func(*miniov1beta1.Tier) (bool, string, string) {
+				if !ready {
+					return false, reasonRestartRequired, "The MinIO server must be restarted"
+				}
+				return true, "", "Tier is active"
+			},
+		}
+	})
+
+	run := func() (ctrl.Result, error) {
+		result, err := lifecycle.run(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tier)}, &miniov1beta1.Tier{})
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(tier), tier)).To(Succeed())
+		return result, err
+	}
+
+	It("should add the finalizer, reconcile the resource and mark it ready", func() {
+		result, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Hour))
+		Expect(calls).To(Equal([]string{"connect", "reconcile with client"}))
+
+		Expect(tier.Finalizers).To(ContainElement(miniov1beta1.TierFinalizer))
+		Expect(tier.Status.Ready).To(BeTrue())
+		Expect(tier.Status.ObservedGeneration).To(Equal(int64(1)))
+		Expect(tier.Status.LastSyncTime).NotTo(BeNil())
+		Expect(meta.IsStatusConditionTrue(tier.Status.Conditions, miniov1beta1.ConditionReady)).To(BeTrue())
+		Expect(meta.FindStatusCondition(tier.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeNil())
+	})
+
+	It("should keep retrying a resource that cannot connect", func() {
+		connectErr = errors.New("connection refused")
+		result, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(time.Minute))
+		Expect(calls).To(Equal([]string{"connect"}))
+
+		Expect(tier.Status.Ready).To(BeFalse())
+		condition := meta.FindStatusCondition(tier.Status.Conditions, miniov1beta1.ConditionReady)
+		Expect(condition.Reason).To(Equal(reasonClientError))
+		Expect(meta.IsStatusConditionTrue(tier.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+	})
+
+	It("should report an applied resource that is not in effect", func() {
+		ready = false
+		_, err := run()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tier.Status.Ready).To(BeFalse())
+		condition := meta.FindStatusCondition(tier.Status.Conditions, miniov1beta1.ConditionReady)
+		Expect(condition.Reason).To(Equal(reasonRestartRequired))
+		Expect(meta.FindStatusCondition(tier.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeNil())
+		Expect(meta.FindStatusCondition(tier.Status.Conditions, miniov1beta1.ConditionStalled)).To(BeNil())
+	})
+
+	It("should only plan the changes of a dry run", func() {
+		lifecycle.DryRun = true
+		lifecycle.reconcile = func(_ context.Context, _ *miniov1beta1.Tier, _ string, plan *changePlan) (ctrl.Result, error) {
+			return ctrl.Result{}, plan.apply("add tier WARM", func() error { return errors.New("must not be applied") })
+		}
+		_, err := run()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tier.Status.Ready).To(BeFalse())
+		Expect(tier.Status.PlannedChanges).To(Equal([]string{"add tier WARM"}))
+		condition := meta.FindStatusCondition(tier.Status.Conditions, miniov1beta1.ConditionReady)
+		Expect(condition.Reason).To(Equal(reasonDryRun))
+	})
+
+	It("should leave a paused resource untouched", func() {
+		tier.Annotations = map[string]string{miniov1beta1.PausedAnnotation: "true"}
+		Expect(c.Update(ctx, tier)).To(Succeed())
+
+		_, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(BeEmpty())
+		Expect(tier.Finalizers).To(BeEmpty())
+	})
+
+	It("should hand a deleted resource to the delete hook", func() {
+		tier.Finalizers = []string{miniov1beta1.TierFinalizer}
+		Expect(c.Update(ctx, tier)).To(Succeed())
+		Expect(c.Delete(ctx, tier)).To(Succeed())
+
+		_, err := lifecycle.run(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tier)}, &miniov1beta1.Tier{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(calls).To(Equal([]string{"delete"}))
+	})
+})
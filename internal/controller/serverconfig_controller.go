@@ -0,0 +1,415 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// ServerConfigReconciler reconciles a ServerConfig object
+type ServerConfigReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all server configs
+	DryRun bool
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=serverconfigs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=serverconfigs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=serverconfigs/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile applies the settings that differ from the live configuration and restarts the server
+// in its maintenance window if the settings require it
+func (r *ServerConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	connection := func(serverConfig *miniov1beta1.ServerConfig) *miniov1beta1.MinIOConnection {
+		return &serverConfig.Spec.Connection
+	}
+	lifecycle := &resourceLifecycle[*miniov1beta1.ServerConfig, *minioclient.Client]{
+		Client:    r.Client,
+		Recorder:  r.Recorder,
+		DryRun:    r.DryRun,
+		name:      "server config",
+		finalizer: miniov1beta1.ServerConfigFinalizer,
+		status: func(serverConfig *miniov1beta1.ServerConfig) resourceStatus {
+			status := &serverConfig.Status
+			return resourceStatus{&status.Ready, &status.ObservedGeneration, &status.LastSyncTime, &status.PlannedChanges}
+		},
+		connections: singleConnection(connection),
+		delete:      r.handleDeletion,
+		connect:     clientFor(r.Client, connection),
+		reconcile:   r.reconcileServerConfig,
+		// The config is applied once all settings are active
+		ready: func(serverConfig *miniov1beta1.ServerConfig) (bool, string, string) {
+			if serverConfig.Status.RestartRequired {
+				return false, reasonRestartRequired, fmt.Sprintf("Settings %s take effect after a restart of the MinIO server",
+					strings.Join(serverConfig.Status.PendingRestart, ", "))
+			}
+			return true, "", "Server config is applied"
+		},
+	}
+	return lifecycle.run(ctx, req, &miniov1beta1.ServerConfig{})
+}
+
+// handleDeletion resets the configured settings if requested. The settings are kept by default.
+func (r *ServerConfigReconciler) handleDeletion(ctx context.Context, serverConfig *miniov1beta1.ServerConfig) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(serverConfig, miniov1beta1.ServerConfigFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, serverConfig)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		switch {
+		case violation:
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+		case !serverConfig.Spec.ResetOnDelete:
+			logger.Info("Keeping server config settings")
+		default:
+			minioClient, err := newMinIOClient(ctx, r.Client, serverConfig, serverConfig.Spec.Connection)
+			if err != nil {
+				logger.Error(err, "Failed to create MinIO client for deletion, retrying")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+
+			plan := newChangePlan(r.DryRun, serverConfig)
+			restart, err := r.resetSettings(ctx, serverConfig, minioClient, plan)
+			if err != nil {
+				logger.Error(err, "Failed to reset server config settings, will retry")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if plan.pending() {
+				return reportPlannedDeletion(ctx, r.Client, r.Recorder, serverConfig, &serverConfig.Status.PlannedChanges, plan)
+			}
+			// Deletion does not wait for a maintenance window, so the restart is left to the administrator
+			logger.Info("Reset server config settings", "restartRequired", restart)
+		}
+
+		// Remove finalizer
+		controllerutil.RemoveFinalizer(serverConfig, miniov1beta1.ServerConfigFinalizer)
+		if err := r.Update(ctx, serverConfig); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// resetSettings resets the configured keys of subsystems to their defaults and removes configured
+// subsystem targets. It reports whether the server must be restarted to apply the reset.
+func (r *ServerConfigReconciler) resetSettings(ctx context.Context, serverConfig *miniov1beta1.ServerConfig, minioClient *minioclient.Client, plan *changePlan) (bool, error) {
+	restartRequired := false
+	for _, key := range serverConfigKeys(serverConfig) {
+		subSystem, target, _ := strings.Cut(key, ":")
+		line := key
+		change := fmt.Sprintf("remove %s", key)
+		if target != "" {
+			live, err := liveConfig(ctx, minioClient.Admin, subSystem, target)
+			if err != nil {
+				return false, err
+			}
+			if live == nil {
+				continue
+			}
+		} else {
+			keys := settingKeys(serverConfig, key)
+			line = key + " " + strings.Join(keys, " ")
+			change = fmt.Sprintf("reset %s %s", key, strings.Join(keys, ", "))
+		}
+
+		err := plan.apply(change, func() error {
+			restart, err := minioClient.Admin.DelConfigKV(ctx, line)
+			restartRequired = restartRequired || restart
+			return err
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to reset %s: %w", key, err)
+		}
+	}
+	return restartRequired, nil
+}
+
+// reconcileServerConfig writes the subsystems whose live settings differ from the spec and
+// restarts the server if applied settings require it
+func (r *ServerConfigReconciler) reconcileServerConfig(ctx context.Context, serverConfig *miniov1beta1.ServerConfig, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	status := &serverConfig.Status
+
+	secretValues := map[string]map[string]string{}
+	allSecretValues := map[string]string{}
+	for key, values := range serverConfig.Spec.SecretConfig {
+		resolved, err := configSecretValues(ctx, r.Client, serverConfig.Namespace, values)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get secret configuration of %s: %w", key, err)
+		}
+		secretValues[key] = resolved
+		for name, value := range resolved {
+			allSecretValues[key+" "+name] = value
+		}
+	}
+	// Secret values may be redacted in the live configuration, so their changes are detected by their digest
+	digest := configSecretsDigest(allSecretValues)
+
+	var applied []string
+	for _, key := range serverConfigKeys(serverConfig) {
+		subSystem, target, _ := strings.Cut(key, ":")
+		live, err := liveConfig(ctx, minioClient.Admin, subSystem, target)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		if live == nil {
+			live = &madmin.SubsysConfig{SubSystem: subSystem, Target: target}
+		}
+
+		desired := serverConfig.Spec.Config[key]
+		if overridden := envOverrides(live, slices.Collect(maps.Keys(desired))); len(overridden) > 0 {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("settings %s of %s are set by environment variables of the MinIO server",
+				strings.Join(overridden, ", "), key)
+		}
+		changed := configMismatch(live, desired)
+		if digest != status.SecretsHash {
+			changed = append(changed, slices.Sorted(maps.Keys(secretValues[key]))...)
+		}
+		if len(changed) == 0 {
+			continue
+		}
+
+		values := map[string]string{}
+		maps.Copy(values, desired)
+		maps.Copy(values, secretValues[key])
+		restart := false
+		err = plan.apply(fmt.Sprintf("set %s %s", key, strings.Join(changed, ", ")), func() error {
+			restart, err = minioClient.Admin.SetConfigKV(ctx, configKVLine(key, values))
+			return err
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set %s: %w", key, err)
+		}
+		if restart {
+			for _, name := range changed {
+				applied = append(applied, key+" "+name)
+			}
+		}
+	}
+
+	if !plan.dryRun {
+		status.SecretsHash = digest
+	}
+	if len(applied) > 0 {
+		logger.Info("Applied settings that require a restart", "settings", applied)
+		if status.RestartRequiredSince == nil {
+			status.RestartRequiredSince = &metav1.Time{Time: time.Now()}
+		}
+		for _, setting := range applied {
+			if !slices.Contains(status.PendingRestart, setting) {
+				status.PendingRestart = append(status.PendingRestart, setting)
+			}
+		}
+	}
+	return r.reconcileRestart(ctx, serverConfig, minioClient, plan)
+}
+
+// reconcileRestart restarts the MinIO server for settings waiting for a restart, if the restart policy
+// allows it and the maintenance window is open. Restarts made outside of the controller are detected
+// by the uptime of the servers.
+func (r *ServerConfigReconciler) reconcileRestart(ctx context.Context, serverConfig *miniov1beta1.ServerConfig, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	status := &serverConfig.Status
+
+	clearRestart := func() {
+		status.PendingRestart = nil
+		status.RestartRequiredSince = nil
+		status.RestartRequired = false
+	}
+	if len(status.PendingRestart) == 0 {
+		clearRestart()
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	if status.RestartRequiredSince != nil {
+		info, err := minioClient.Admin.ServerInfo(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to get server info (non-fatal)")
+		} else if restartedSince(info.Servers, status.RestartRequiredSince.Time, time.Now()) {
+			logger.Info("MinIO server was restarted, pending settings are active", "settings", status.PendingRestart)
+			clearRestart()
+			return ctrl.Result{RequeueAfter: time.Hour}, nil
+		}
+	}
+
+	status.RestartRequired = true
+	if serverConfig.Spec.RestartPolicy != miniov1beta1.RestartPolicyIfRequired {
+		return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	}
+	open, next, err := maintenanceWindowOpen(serverConfig.Spec.MaintenanceWindow, time.Now())
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	if !open {
+		logger.Info("Waiting for the maintenance window to restart the MinIO server", "next", next)
+		return ctrl.Result{RequeueAfter: time.Until(next)}, nil
+	}
+
+	err = plan.apply(fmt.Sprintf("restart MinIO server to apply %s", strings.Join(status.PendingRestart, ", ")), func() error {
+		return minioClient.Admin.ServiceRestartV2(ctx)
+	})
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to restart MinIO server: %w", err)
+	}
+	if !plan.dryRun {
+		logger.Info("Restarted MinIO server", "settings", status.PendingRestart)
+		clearRestart()
+		status.LastRestartTime = &metav1.Time{Time: time.Now()}
+	}
+	return ctrl.Result{RequeueAfter: time.Hour}, nil
+}
+
+// serverConfigKeys returns the sorted subsystems with plain or secret settings
+func serverConfigKeys(serverConfig *miniov1beta1.ServerConfig) []string {
+	keys := slices.Collect(maps.Keys(serverConfig.Spec.Config))
+	for key := range serverConfig.Spec.SecretConfig {
+		if _, ok := serverConfig.Spec.Config[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// settingKeys returns the sorted keys with plain or secret values of a subsystem
+func settingKeys(serverConfig *miniov1beta1.ServerConfig, key string) []string {
+	keys := slices.Collect(maps.Keys(serverConfig.Spec.Config[key]))
+	for _, value := range serverConfig.Spec.SecretConfig[key] {
+		keys = append(keys, value.Key)
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+// envOverrides returns the sorted keys whose live value is set by an environment variable of the server
+func envOverrides(live *madmin.SubsysConfig, keys []string) []string {
+	var overridden []string
+	for _, kv := range live.KV {
+		if kv.EnvOverride != nil && slices.Contains(keys, kv.Key) {
+			overridden = append(overridden, kv.Key)
+		}
+	}
+	slices.Sort(overridden)
+	return overridden
+}
+
+// restartedSince reports whether all servers were started after since, judged by their uptime
+func restartedSince(servers []madmin.ServerProperties, since, now time.Time) bool {
+	if len(servers) == 0 {
+		return false
+	}
+	for _, server := range servers {
+		// Offline servers report no uptime
+		if server.Uptime <= 0 || now.Add(-time.Duration(server.Uptime)*time.Second).Before(since) {
+			return false
+		}
+	}
+	return true
+}
+
+// maintenanceWindowOpen reports whether the maintenance window is open at now, and otherwise when it
+// opens next. Without a window, disruptive operations are always allowed.
+func maintenanceWindowOpen(window *miniov1beta1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if window == nil {
+		return true, now, nil
+	}
+	location := time.UTC
+	if window.TimeZone != "" {
+		var err error
+		if location, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false, now, fmt.Errorf("invalid time zone of maintenance window: %w", err)
+		}
+	}
+	clock, err := time.Parse("15:04", window.Start)
+	if err != nil {
+		return false, now, fmt.Errorf("invalid start of maintenance window: %w", err)
+	}
+
+	// Starting a day early covers windows that opened yesterday and last past midnight
+	local := now.In(location)
+	for offset := -1; offset <= 7; offset++ {
+		day := local.AddDate(0, 0, offset)
+		start := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, location)
+		if len(window.Days) > 0 && !slices.Contains(window.Days, start.Weekday().String()) {
+			continue
+		}
+		if !now.Before(start) && now.Before(start.Add(window.Duration.Duration)) {
+			return true, now, nil
+		}
+		if start.After(now) {
+			return false, start, nil
+		}
+	}
+	return false, now, fmt.Errorf("maintenance window never opens")
+}
+
+// serverConfigsForSecret maps a Secret to the ServerConfigs reading settings from it
+func (r *ServerConfigReconciler) serverConfigsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1beta1.ServerConfigList{}
+	if err := r.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list server configs")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		for _, values := range item.Spec.SecretConfig {
+			if referencesSecret(item.Namespace, values, obj) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ServerConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.ServerConfig{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.serverConfigsForSecret)).
+		Complete(r)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("ServerConfig Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-server-config"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind ServerConfig")
+			err := k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.ServerConfig{})
+			if err != nil && errors.IsNotFound(err) {
+				resource := &miniov1beta1.ServerConfig{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: miniov1beta1.ServerConfigSpec{
+						Connection: miniov1beta1.MinIOConnection{
+							AliasRef: &miniov1beta1.AliasReference{Name: "missing-alias"},
+						},
+						Config: map[string]map[string]string{
+							"scanner": {"speed": "slow"},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.ServerConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance ServerConfig")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should report a connection that cannot be established", func() {
+			controllerReconciler := &ServerConfigReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &miniov1beta1.ServerConfig{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+			Expect(resource.Status.Ready).To(BeFalse())
+		})
+	})
+
+	Context("When collecting the desired settings", func() {
+		serverConfig := &miniov1beta1.ServerConfig{
+			Spec: miniov1beta1.ServerConfigSpec{
+				Config: map[string]map[string]string{
+					"scanner":              {"speed": "slow"},
+					"audit_webhook:splunk": {"enable": "on", "endpoint": "https://splunk.example.com"},
+				},
+				SecretConfig: map[string][]miniov1beta1.ConfigSecretValue{
+					"audit_webhook:splunk": {{Key: "auth_token"}},
+					"identity_openid":      {{Key: "client_secret"}},
+				},
+			},
+		}
+
+		It("should merge plain and secret subsystems", func() {
+			Expect(serverConfigKeys(serverConfig)).To(Equal([]string{"audit_webhook:splunk", "identity_openid", "scanner"}))
+		})
+
+		It("should merge plain and secret keys of a subsystem", func() {
+			Expect(settingKeys(serverConfig, "audit_webhook:splunk")).To(Equal([]string{"auth_token", "enable", "endpoint"}))
+			Expect(settingKeys(serverConfig, "identity_openid")).To(Equal([]string{"client_secret"}))
+		})
+
+		It("should find settings overridden by the environment", func() {
+			live := &madmin.SubsysConfig{
+				SubSystem: "scanner",
+				KV: []madmin.ConfigKV{
+					{Key: "speed", Value: "fast", EnvOverride: &madmin.EnvOverride{Name: "MINIO_SCANNER_SPEED", Value: "fast"}},
+					{Key: "delay", Value: "10", EnvOverride: &madmin.EnvOverride{Name: "MINIO_SCANNER_DELAY", Value: "10"}},
+					{Key: "cycle", Value: "1m"},
+				},
+			}
+			Expect(envOverrides(live, []string{"speed", "cycle"})).To(Equal([]string{"speed"}))
+		})
+	})
+
+	Context("When detecting a restart", func() {
+		now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+		since := now.Add(-time.Hour)
+
+		It("should require all servers to be started since", func() {
+			Expect(restartedSince([]madmin.ServerProperties{{Uptime: 600}, {Uptime: 1200}}, since, now)).To(BeTrue())
+			Expect(restartedSince([]madmin.ServerProperties{{Uptime: 600}, {Uptime: 7200}}, since, now)).To(BeFalse())
+		})
+
+		It("should not count offline servers", func() {
+			Expect(restartedSince([]madmin.ServerProperties{{Uptime: 600}, {}}, since, now)).To(BeFalse())
+			Expect(restartedSince(nil, since, now)).To(BeFalse())
+		})
+	})
+
+	Context("When checking the maintenance window", func() {
+		// 2025-03-10 is a Monday
+		window := &miniov1beta1.MaintenanceWindow{
+			Start:    "23:00",
+			Duration: metav1.Duration{Duration: 2 * time.Hour},
+		}
+
+		It("should always allow restarts without a window", func() {
+			open, _, err := maintenanceWindowOpen(nil, time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(open).To(BeTrue())
+		})
+
+		It("should be open past midnight", func() {
+			open, _, err := maintenanceWindowOpen(window, time.Date(2025, time.March, 11, 0, 30, 0, 0, time.UTC))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(open).To(BeTrue())
+		})
+
+		It("should report the next start when closed", func() {
+			open, next, err := maintenanceWindowOpen(window, time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(open).To(BeFalse())
+			Expect(next).To(Equal(time.Date(2025, time.March, 10, 23, 0, 0, 0, time.UTC)))
+		})
+
+		It("should only open on the given days", func() {
+			restricted := window.DeepCopy()
+			restricted.Days = []string{"Saturday"}
+			open, next, err := maintenanceWindowOpen(restricted, time.Date(2025, time.March, 11, 0, 30, 0, 0, time.UTC))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(open).To(BeFalse())
+			Expect(next).To(Equal(time.Date(2025, time.March, 15, 23, 0, 0, 0, time.UTC)))
+		})
+
+		It("should honour the time zone", func() {
+			zoned := window.DeepCopy()
+			zoned.TimeZone = "Europe/Berlin"
+			open, _, err := maintenanceWindowOpen(zoned, time.Date(2025, time.March, 10, 22, 30, 0, 0, time.UTC))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(open).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,459 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// SiteReplicationReconciler reconciles a SiteReplication object
+type SiteReplicationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all site replications
+	DryRun bool
+}
+
+// replicationPeer is a site of a SiteReplication together with its client and its view of the site replication
+type replicationPeer struct {
+	site     miniov1beta1.ReplicationSite
+	client   *minioclient.Client
+	endpoint string
+	info     madmin.SiteReplicationInfo
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=sitereplications,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=sitereplications/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=sitereplications/finalizers,verbs=update
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=aliases,verbs=get;list;watch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=clusteraliases,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile joins the sites of a SiteReplication, keeps their endpoints up to date and reports whether
+// the sites agree on the replicated metadata
+func (r *SiteReplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	inSync := false
+	lifecycle := &resourceLifecycle[*miniov1beta1.SiteReplication, []replicationPeer]{
+		Client:    r.Client,
+		Recorder:  r.Recorder,
+		DryRun:    r.DryRun,
+		name:      "site replication",
+		finalizer: miniov1beta1.SiteReplicationFinalizer,
+		status: func(siteReplication *miniov1beta1.SiteReplication) resourceStatus {
+			status := &siteReplication.Status
+			return resourceStatus{&status.Ready, &status.ObservedGeneration, &status.LastSyncTime, &status.PlannedChanges}
+		},
+		// The alias of any site pauses the site replication
+		connections: func(_ context.Context, siteReplication *miniov1beta1.SiteReplication) ([]*miniov1beta1.MinIOConnection, error) {
+			connections := make([]*miniov1beta1.MinIOConnection, 0, len(siteReplication.Spec.Sites))
+			for _, site := range siteReplication.Spec.Sites {
+				conn := siteConnection(site)
+				connections = append(connections, &conn)
+			}
+			return connections, nil
+		},
+		delete:  r.handleDeletion,
+		connect: r.connectSites,
+		// Reconcile the site replication and observe how far the sites agree
+		reconcile: func(ctx context.Context, siteReplication *miniov1beta1.SiteReplication, peers []replicationPeer, plan *changePlan) (ctrl.Result, error) {
+			err := r.reconcileSites(ctx, siteReplication, peers, plan)
+			if err == nil && !plan.dryRun {
+				inSync, err = r.observeSites(ctx, siteReplication, peers)
+			}
+			if err != nil || (!inSync && !plan.pending()) {
+				return ctrl.Result{RequeueAfter: time.Minute}, err
+			}
+			return ctrl.Result{RequeueAfter: time.Hour}, nil
+		},
+		// The site replication is ready once all peers agree
+		ready: func(siteReplication *miniov1beta1.SiteReplication) (bool, string, string) {
+			if !inSync {
+				return false, reasonSitesOutOfSync, fmt.Sprintf("%d of %d sites are in sync", siteReplication.Status.SitesInSync, len(siteReplication.Spec.Sites))
+			}
+			return true, "", "All sites are in sync"
+		},
+	}
+	return lifecycle.run(ctx, req, &miniov1beta1.SiteReplication{})
+}
+
+// handleDeletion removes the sites of the SiteReplication from site replication
+func (r *SiteReplicationReconciler) handleDeletion(ctx context.Context, siteReplication *miniov1beta1.SiteReplication) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(siteReplication, miniov1beta1.SiteReplicationFinalizer) {
+		joined := slices.ContainsFunc(siteReplication.Status.Sites, func(site miniov1beta1.ReplicationSiteStatus) bool {
+			return site.DeploymentID != ""
+		})
+		if !joined {
+			logger.Info("Skipping cleanup of a site replication that never joined its sites")
+		} else {
+			peers, err := r.connectSites(ctx, siteReplication)
+			if err != nil {
+				logger.Error(err, "Failed to connect to sites for deletion, retrying")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+
+			plan := newChangePlan(r.DryRun, siteReplication)
+			if err := r.removeSites(ctx, siteReplication, peers, plan); err != nil {
+				logger.Error(err, "Failed to remove site replication, will retry")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if plan.pending() {
+				return reportPlannedDeletion(ctx, r.Client, r.Recorder, siteReplication, &siteReplication.Status.PlannedChanges, plan)
+			}
+			logger.Info("Removed site replication")
+		}
+
+		// Remove finalizer
+		controllerutil.RemoveFinalizer(siteReplication, miniov1beta1.SiteReplicationFinalizer)
+		if err := r.Update(ctx, siteReplication); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// removeSites removes the sites of siteReplication from the site replication they take part in.
+// Sites joined by other means are kept.
+func (r *SiteReplicationReconciler) removeSites(ctx context.Context, siteReplication *miniov1beta1.SiteReplication, peers []replicationPeer, plan *changePlan) error {
+	group := replicationGroup(peers)
+	if group == nil {
+		return nil
+	}
+
+	names := siteNames(siteReplication.Spec.Sites)
+	request := madmin.SRRemoveReq{}
+	for _, peer := range group.info.Sites {
+		if slices.Contains(names, peer.Name) {
+			request.SiteNames = append(request.SiteNames, peer.Name)
+		}
+	}
+	if len(request.SiteNames) == 0 {
+		return nil
+	}
+	change := fmt.Sprintf("remove sites %s from site replication", strings.Join(request.SiteNames, ", "))
+	if len(request.SiteNames) == len(group.info.Sites) {
+		request = madmin.SRRemoveReq{RemoveAll: true}
+	}
+
+	return plan.apply(change, func() error {
+		status, err := group.client.Admin.SiteReplicationRemove(ctx, request)
+		if err != nil {
+			return fmt.Errorf("failed to remove sites: %w", err)
+		}
+		if status.ErrDetail != "" {
+			return fmt.Errorf("failed to remove sites: %s", status.ErrDetail)
+		}
+		return nil
+	})
+}
+
+// connectSites creates the clients of all sites and reads their view of the site replication
+func (r *SiteReplicationReconciler) connectSites(ctx context.Context, siteReplication *miniov1beta1.SiteReplication) ([]replicationPeer, error) {
+	peers := make([]replicationPeer, 0, len(siteReplication.Spec.Sites))
+	for _, site := range siteReplication.Spec.Sites {
+		minioClient, err := minioclient.NewClusterClient(ctx, r.Client, siteConnection(site))
+		if err != nil {
+			return nil, fmt.Errorf("site %s: %w", site.Name, err)
+		}
+		info, err := minioClient.Admin.SiteReplicationInfo(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get site replication info of site %s: %w", site.Name, err)
+		}
+
+		endpoint := site.Endpoint
+		if endpoint == "" {
+			endpoint = clientURL(minioClient)
+		}
+		peers = append(peers, replicationPeer{site: site, client: minioClient, endpoint: endpoint, info: info})
+	}
+	return peers, nil
+}
+
+// reconcileSites adds missing sites to the site replication, removes sites no longer listed and updates
+// changed endpoints and options
+func (r *SiteReplicationReconciler) reconcileSites(ctx context.Context, siteReplication *miniov1beta1.SiteReplication, peers []replicationPeer, plan *changePlan) error {
+	logger := log.FromContext(ctx)
+	group := replicationGroup(peers)
+
+	// Sites are added by listing all sites, including those already replicating
+	var missing []string
+	for _, peer := range peers {
+		if group == nil || !peer.info.Enabled || !slices.ContainsFunc(group.info.Sites, func(live madmin.PeerInfo) bool { return live.Name == peer.site.Name }) {
+			missing = append(missing, peer.site.Name)
+		}
+	}
+	if len(missing) > 0 {
+		adder := &peers[0]
+		if group != nil {
+			adder = group
+		}
+		sites := make([]madmin.PeerSite, 0, len(peers))
+		for _, peer := range peers {
+			accessKey, secretKey, err := peer.client.Credentials()
+			if err != nil {
+				return fmt.Errorf("site %s cannot join the site replication: %w", peer.site.Name, err)
+			}
+			sites = append(sites, madmin.PeerSite{Name: peer.site.Name, Endpoint: peer.endpoint, AccessKey: accessKey, SecretKey: secretKey})
+		}
+
+		err := plan.apply(fmt.Sprintf("add sites %s to site replication", strings.Join(missing, ", ")), func() error {
+			status, err := adder.client.Admin.SiteReplicationAdd(ctx, sites, madmin.SRAddOptions{ReplicateILMExpiry: siteReplication.Spec.ReplicateILMExpiry})
+			if err != nil {
+				return err
+			}
+			if !status.Success {
+				return fmt.Errorf("%s %s", status.Status, status.ErrDetail)
+			}
+			if status.InitialSyncErrorMessage != "" {
+				logger.Info("Initial sync of added sites reported an error", "message", status.InitialSyncErrorMessage)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add sites: %w", err)
+		}
+		if !plan.dryRun {
+			logger.Info("Added sites to site replication", "sites", missing)
+		}
+	}
+	if group == nil {
+		return nil
+	}
+
+	// Remove the sites that are no longer listed
+	names := siteNames(siteReplication.Spec.Sites)
+	var removed []string
+	for _, live := range group.info.Sites {
+		if !slices.Contains(names, live.Name) {
+			removed = append(removed, live.Name)
+		}
+	}
+	if len(removed) > 0 {
+		err := plan.apply(fmt.Sprintf("remove sites %s from site replication", strings.Join(removed, ", ")), func() error {
+			status, err := group.client.Admin.SiteReplicationRemove(ctx, madmin.SRRemoveReq{SiteNames: removed})
+			if err != nil {
+				return err
+			}
+			if status.ErrDetail != "" {
+				return fmt.Errorf("%s", status.ErrDetail)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove sites: %w", err)
+		}
+	}
+
+	// Update the endpoints of sites that moved
+	for _, peer := range peers {
+		index := slices.IndexFunc(group.info.Sites, func(live madmin.PeerInfo) bool { return live.Name == peer.site.Name })
+		if index < 0 || sameEndpoint(group.info.Sites[index].Endpoint, peer.endpoint) {
+			continue
+		}
+		live := group.info.Sites[index]
+		err := plan.apply(fmt.Sprintf("update endpoint of site %s to %s", live.Name, peer.endpoint), func() error {
+			return checkEditStatus(group.client.Admin.SiteReplicationEdit(ctx, madmin.PeerInfo{
+				Name:         live.Name,
+				DeploymentID: live.DeploymentID,
+				Endpoint:     peer.endpoint,
+			}, madmin.SREditOptions{}))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update endpoint of site %s: %w", live.Name, err)
+		}
+	}
+
+	// Replication of ILM expiry rules is a setting of the whole site replication
+	if len(group.info.Sites) > 0 && group.info.Sites[0].ReplicateILMExpiry != siteReplication.Spec.ReplicateILMExpiry {
+		opts := madmin.SREditOptions{
+			EnableILMExpiryReplication:  siteReplication.Spec.ReplicateILMExpiry,
+			DisableILMExpiryReplication: !siteReplication.Spec.ReplicateILMExpiry,
+		}
+		err := plan.apply(fmt.Sprintf("set replication of ILM expiry rules to %t", siteReplication.Spec.ReplicateILMExpiry), func() error {
+			return checkEditStatus(group.client.Admin.SiteReplicationEdit(ctx, madmin.PeerInfo{}, opts))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update replication of ILM expiry rules: %w", err)
+		}
+	}
+	return nil
+}
+
+// observeSites reports the state of each site in status and whether all sites agree with each other
+func (r *SiteReplicationReconciler) observeSites(ctx context.Context, siteReplication *miniov1beta1.SiteReplication, peers []replicationPeer) (bool, error) {
+	names := siteNames(siteReplication.Spec.Sites)
+
+	// Changes made during this reconciliation are only visible in a fresh view of each site
+	for i := range peers {
+		info, err := peers[i].client.Admin.SiteReplicationInfo(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to get site replication info of site %s: %w", peers[i].site.Name, err)
+		}
+		peers[i].info = info
+	}
+
+	status := madmin.SRStatusInfo{}
+	if group := replicationGroup(peers); group != nil {
+		var err error
+		status, err = group.client.Admin.SRStatusInfo(ctx, madmin.SRStatusOptions{Buckets: true, Policies: true, Users: true, Groups: true})
+		if err != nil {
+			return false, fmt.Errorf("failed to get site replication status: %w", err)
+		}
+	}
+
+	sites := make([]miniov1beta1.ReplicationSiteStatus, 0, len(peers))
+	inSync := 0
+	for _, peer := range peers {
+		site := miniov1beta1.ReplicationSiteStatus{Name: peer.site.Name, Endpoint: peer.endpoint}
+		for deploymentID, live := range status.Sites {
+			if live.Name == peer.site.Name {
+				site.DeploymentID = deploymentID
+				site.Endpoint = live.Endpoint
+				site.SyncState = string(live.SyncState)
+			}
+		}
+		if site.DeploymentID != "" {
+			site.PendingIAM, site.PendingBucketMetadata = pendingReplication(status, site.DeploymentID)
+		}
+		site.InSync = site.DeploymentID != "" && peer.info.Enabled && knowsSites(peer.info, names) &&
+			site.PendingIAM == 0 && site.PendingBucketMetadata == 0
+		if site.InSync {
+			inSync++
+		}
+		sites = append(sites, site)
+	}
+
+	siteReplication.Status.Sites = sites
+	siteReplication.Status.SitesInSync = inSync
+	return inSync == len(peers), nil
+}
+
+// siteConnection returns the connection to a site
+func siteConnection(site miniov1beta1.ReplicationSite) miniov1beta1.MinIOConnection {
+	return miniov1beta1.MinIOConnection{AliasRef: site.AliasRef, ClusterAliasRef: site.ClusterAliasRef}
+}
+
+// clientURL returns the URL of the MinIO server of minioClient
+func clientURL(minioClient *minioclient.Client) string {
+	endpoint, secure := minioClient.Endpoint()
+	if secure {
+		return "https://" + endpoint
+	}
+	return "http://" + endpoint
+}
+
+// replicationGroup returns the first peer that already takes part in site replication, or nil if none does
+func replicationGroup(peers []replicationPeer) *replicationPeer {
+	for i := range peers {
+		if peers[i].info.Enabled {
+			return &peers[i]
+		}
+	}
+	return nil
+}
+
+// siteNames returns the names of sites
+func siteNames(sites []miniov1beta1.ReplicationSite) []string {
+	names := make([]string, 0, len(sites))
+	for _, site := range sites {
+		names = append(names, site.Name)
+	}
+	return names
+}
+
+// knowsSites reports whether the site replication info of a site lists exactly the named sites
+func knowsSites(info madmin.SiteReplicationInfo, names []string) bool {
+	if len(info.Sites) != len(names) {
+		return false
+	}
+	for _, live := range info.Sites {
+		if !slices.Contains(names, live.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameEndpoint reports whether two site endpoints are equal, ignoring a trailing slash
+func sameEndpoint(a, b string) bool {
+	return strings.TrimSuffix(a, "/") == strings.TrimSuffix(b, "/")
+}
+
+// checkEditStatus returns an error unless a site replication edit succeeded
+func checkEditStatus(status madmin.ReplicateEditStatus, err error) error {
+	if err != nil {
+		return err
+	}
+	if !status.Success {
+		return fmt.Errorf("%s %s", status.Status, status.ErrDetail)
+	}
+	return nil
+}
+
+// pendingReplication counts the IAM entities and the bucket metadata that are missing on or differ at the
+// deployment according to the site replication status
+func pendingReplication(status madmin.SRStatusInfo, deploymentID string) (iam, bucketMetadata int) {
+	for _, stats := range status.BucketStats {
+		if stat, ok := stats[deploymentID]; ok && ((!stat.HasBucket && !stat.BucketMarkedDeleted) || stat.TagMismatch ||
+			stat.VersioningConfigMismatch || stat.OLockConfigMismatch || stat.PolicyMismatch || stat.SSEConfigMismatch ||
+			stat.ReplicationCfgMismatch || stat.QuotaCfgMismatch || stat.CorsCfgMismatch) {
+			bucketMetadata++
+		}
+	}
+	for _, stats := range status.PolicyStats {
+		if stat, ok := stats[deploymentID]; ok && (!stat.HasPolicy || stat.PolicyMismatch) {
+			iam++
+		}
+	}
+	for _, stats := range status.UserStats {
+		if stat, ok := stats[deploymentID]; ok && (!stat.HasUser || stat.PolicyMismatch || stat.UserInfoMismatch) {
+			iam++
+		}
+	}
+	for _, stats := range status.GroupStats {
+		if stat, ok := stats[deploymentID]; ok && (!stat.HasGroup || stat.PolicyMismatch || stat.GroupDescMismatch) {
+			iam++
+		}
+	}
+	return iam, bucketMetadata
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SiteReplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.SiteReplication{}).
+		Complete(r)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"github.com/minio/madmin-go/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("SiteReplication Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-site-replication"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind SiteReplication")
+			err := k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.SiteReplication{})
+			if err != nil && errors.IsNotFound(err) {
+				resource := &miniov1beta1.SiteReplication{
+					ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+					Spec: miniov1beta1.SiteReplicationSpec{
+						Sites: []miniov1beta1.ReplicationSite{
+							{Name: "eu", ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "minio-eu"}},
+							{Name: "us", ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "minio-us"}},
+						},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.SiteReplication{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance SiteReplication")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should report sites that cannot be reached", func() {
+			controllerReconciler := &SiteReplicationReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &miniov1beta1.SiteReplication{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+			Expect(resource.Status.Ready).To(BeFalse())
+		})
+	})
+
+	Context("When observing site replication status", func() {
+		It("should count missing and differing metadata of a deployment", func() {
+			status := madmin.SRStatusInfo{
+				BucketStats: map[string]map[string]madmin.SRBucketStatsSummary{
+					"photos": {
+						"dep-eu": {DeploymentID: "dep-eu", HasBucket: true},
+						"dep-us": {DeploymentID: "dep-us", HasBucket: false},
+					},
+					"logs": {
+						"dep-eu": {DeploymentID: "dep-eu", HasBucket: true, PolicyMismatch: true},
+						"dep-us": {DeploymentID: "dep-us", HasBucket: true, PolicyMismatch: true},
+					},
+					"deleted": {
+						"dep-us": {DeploymentID: "dep-us", BucketMarkedDeleted: true},
+					},
+				},
+				UserStats: map[string]map[string]madmin.SRUserStatsSummary{
+					"alice": {
+						"dep-eu": {DeploymentID: "dep-eu", HasUser: true},
+						"dep-us": {DeploymentID: "dep-us", HasUser: false},
+					},
+				},
+				PolicyStats: map[string]map[string]madmin.SRPolicyStatsSummary{
+					"readonly": {
+						"dep-us": {DeploymentID: "dep-us", HasPolicy: true, PolicyMismatch: true},
+					},
+				},
+				GroupStats: map[string]map[string]madmin.SRGroupStatsSummary{
+					"admins": {
+						"dep-us": {DeploymentID: "dep-us", HasGroup: true, GroupDescMismatch: true},
+					},
+				},
+			}
+
+			iam, bucketMetadata := pendingReplication(status, "dep-us")
+			Expect(iam).To(Equal(3))
+			Expect(bucketMetadata).To(Equal(2))
+
+			iam, bucketMetadata = pendingReplication(status, "dep-eu")
+			Expect(iam).To(BeZero())
+			Expect(bucketMetadata).To(Equal(1))
+		})
+
+		It("should only accept a site that knows exactly the listed sites", func() {
+			info := madmin.SiteReplicationInfo{
+				Enabled: true,
+				Sites:   []madmin.PeerInfo{{Name: "eu"}, {Name: "us"}},
+			}
+			Expect(knowsSites(info, []string{"us", "eu"})).To(BeTrue())
+			Expect(knowsSites(info, []string{"eu", "us", "ap"})).To(BeFalse())
+			Expect(knowsSites(info, []string{"eu", "ap"})).To(BeFalse())
+		})
+
+		It("should compare endpoints ignoring a trailing slash", func() {
+			Expect(sameEndpoint("https://minio-eu.example.com/", "https://minio-eu.example.com")).To(BeTrue())
+			Expect(sameEndpoint("https://minio-eu.example.com", "https://minio-us.example.com")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/tenant"
+)
+
+// Reasons used for the status conditions
+const (
+	reasonReconciling             = "Reconciling"
+	reasonReady                   = "Ready"
+	reasonClientError             = "ClientError"
+	reasonReconcileError          = "ReconcileError"
+	reasonReferenceNotPermitted   = "ReferenceNotPermitted"
+	reasonConflict                = "Conflict"
+	reasonDriftDetected           = "DriftDetected"
+	reasonDriftCorrected          = "DriftCorrected"
+	reasonPaused                  = "Paused"
+	reasonDryRun                  = "DryRun"
+	reasonKMSNotConfigured        = "KMSNotConfigured"
+	reasonSitesOutOfSync          = "SitesOutOfSync"
+	reasonRestartRequired         = "RestartRequired"
+	reasonTargetInactive          = "TargetInactive"
+	reasonProviderInactive        = "ProviderInactive"
+	reasonResourcesNotReady       = "ResourcesNotReady"
+	reasonCredentialsNotPermitted = "CredentialsNotPermitted"
+	reasonInvalidSpec             = "InvalidSpec"
+	reasonTiersAvailable          = "TiersAvailable"
+	reasonTierNotFound            = "TierNotFound"
+)
+
+// terminalReasons are the reasons of failures that retrying cannot resolve until the spec, a grant,
+// the tenant policies or the owner of the MinIO resource change
+var terminalReasons = []string{
+	reasonReferenceNotPermitted,
+	reasonCredentialsNotPermitted,
+	reasonConflict,
+	reasonKMSNotConfigured,
+	reasonInvalidSpec,
+	tenant.ReasonPolicyViolation,
+	tenant.ReasonBucketConflict,
+}
+
+// legacyConditionTypes are condition types written by earlier versions of the controller
+var legacyConditionTypes = []string{"Progressing", "Degraded", "Error"}
+
+// invalidSpecError is returned when the spec cannot be applied to MinIO as it is
+type invalidSpecError struct {
+	message string
+}
+
+// Error implements error
+func (e *invalidSpecError) Error() string {
+	return e.message
+}
+
+// conditionsAccessor is implemented by all resources with status conditions
+type conditionsAccessor interface {
+	client.Object
+	GetConditions() []metav1.Condition
+	SetConditions(conditions []metav1.Condition)
+}
+
+// setCondition sets a condition on obj for its current generation
+func setCondition(obj conditionsAccessor, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	conditions := obj.GetConditions()
+	meta.SetStatusCondition(&conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	obj.SetConditions(conditions)
+}
+
+// removeCondition removes a condition from obj
+func removeCondition(obj conditionsAccessor, conditionType string) {
+	conditions := obj.GetConditions()
+	meta.RemoveStatusCondition(&conditions, conditionType)
+	obj.SetConditions(conditions)
+}
+
+// markReconciling marks obj as being reconciled and no longer paused, and reports whether the conditions
+// changed. Ready is set to Unknown if it has not been reported yet; an existing Ready condition is kept
+// until the outcome of the reconciliation is known. Resyncs of a resource that is ready at its current
+// generation leave the conditions untouched.
+func markReconciling(obj conditionsAccessor, message string) bool {
+	conditions := obj.GetConditions()
+	ready := meta.FindStatusCondition(conditions, miniov1beta1.ConditionReady)
+	if ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == obj.GetGeneration() &&
+		meta.FindStatusCondition(conditions, miniov1beta1.ConditionPaused) == nil {
+		return false
+	}
+
+	for _, conditionType := range legacyConditionTypes {
+		removeCondition(obj, conditionType)
+	}
+	removeCondition(obj, miniov1beta1.ConditionPaused)
+	setCondition(obj, miniov1beta1.ConditionReconciling, metav1.ConditionTrue, reasonReconciling, message)
+	if ready == nil {
+		setCondition(obj, miniov1beta1.ConditionReady, metav1.ConditionUnknown, reasonReconciling, message)
+	}
+	return true
+}
+
+// markReady marks obj as ready and clears the Reconciling, Stalled and Conflict conditions
+func markReady(obj conditionsAccessor, message string) {
+	setCondition(obj, miniov1beta1.ConditionReady, metav1.ConditionTrue, reasonReady, message)
+	removeCondition(obj, miniov1beta1.ConditionReconciling)
+	removeCondition(obj, miniov1beta1.ConditionStalled)
+	removeCondition(obj, miniov1beta1.ConditionConflict)
+}
+
+// markFailed marks obj as not ready because reconciliation failed. Failures with a terminal reason
+// stall obj, all others are retried and keep it Reconciling. The Conflict condition is set if the
+// failure is caused by an ownership conflict.
+func markFailed(obj conditionsAccessor, reason, message string) {
+	setCondition(obj, miniov1beta1.ConditionReady, metav1.ConditionFalse, reason, message)
+	if slices.Contains(terminalReasons, reason) {
+		setCondition(obj, miniov1beta1.ConditionStalled, metav1.ConditionTrue, reason, message)
+		removeCondition(obj, miniov1beta1.ConditionReconciling)
+	} else {
+		setCondition(obj, miniov1beta1.ConditionReconciling, metav1.ConditionTrue, reason, message)
+		removeCondition(obj, miniov1beta1.ConditionStalled)
+	}
+	if reason == reasonConflict {
+		setCondition(obj, miniov1beta1.ConditionConflict, metav1.ConditionTrue, reason, message)
+	} else {
+		removeCondition(obj, miniov1beta1.ConditionConflict)
+	}
+}
+
+// markPaused marks obj as paused. Ready keeps the outcome of the last reconciliation.
+func markPaused(obj conditionsAccessor, message string) {
+	setCondition(obj, miniov1beta1.ConditionPaused, metav1.ConditionTrue, reasonPaused, message)
+	removeCondition(obj, miniov1beta1.ConditionReconciling)
+}
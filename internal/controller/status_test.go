@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Status conditions", func() {
+	var bucket *miniov1beta1.Bucket
+
+	BeforeEach(func() {
+		bucket = &miniov1beta1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default", Generation: 3},
+		}
+	})
+
+	It("should report Ready as Unknown while reconciling for the first time", func() {
+		markReconciling(bucket, "Reconciling bucket")
+
+		ready := meta.FindStatusCondition(bucket.Status.Conditions, miniov1beta1.ConditionReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(ready.ObservedGeneration).To(Equal(int64(3)))
+		Expect(meta.IsStatusConditionTrue(bucket.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+	})
+
+	It("should clear Stalled and Reconciling once the resource is ready", func() {
+		markReconciling(bucket, "Reconciling bucket")
+		markFailed(bucket, reasonConflict, "Bucket data is owned by another resource")
+		Expect(meta.IsStatusConditionFalse(bucket.Status.Conditions, miniov1beta1.ConditionReady)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(bucket.Status.Conditions, miniov1beta1.ConditionStalled)).To(BeTrue())
+
+		markReconciling(bucket, "Reconciling bucket")
+		markReady(bucket, "Bucket is ready")
+
+		Expect(bucket.Status.Conditions).To(HaveLen(1))
+		Expect(meta.IsStatusConditionTrue(bucket.Status.Conditions, miniov1beta1.ConditionReady)).To(BeTrue())
+	})
+
+	It("should keep reconciling after transient failures", func() {
+		markReconciling(bucket, "Reconciling bucket")
+		markFailed(bucket, reasonClientError, "Failed to create MinIO client")
+
+		Expect(meta.IsStatusConditionFalse(bucket.Status.Conditions, miniov1beta1.ConditionReady)).To(BeTrue())
+		reconciling := meta.FindStatusCondition(bucket.Status.Conditions, miniov1beta1.ConditionReconciling)
+		Expect(reconciling).NotTo(BeNil())
+		Expect(reconciling.Status).To(Equal(metav1.ConditionTrue))
+		Expect(reconciling.Reason).To(Equal(reasonClientError))
+		Expect(meta.FindStatusCondition(bucket.Status.Conditions, miniov1beta1.ConditionStalled)).To(BeNil())
+	})
+
+	It("should stall on terminal failures", func() {
+		markReconciling(bucket, "Reconciling bucket")
+		markFailed(bucket, errorReason(&invalidSpecError{message: "invalid"}, reasonReconcileError), "invalid")
+
+		Expect(meta.IsStatusConditionTrue(bucket.Status.Conditions, miniov1beta1.ConditionStalled)).To(BeTrue())
+		Expect(meta.FindStatusCondition(bucket.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeNil())
+	})
+
+	It("should leave the conditions of a ready resource untouched on resync", func() {
+		markReconciling(bucket, "Reconciling bucket")
+		markReady(bucket, "Bucket is ready")
+		Expect(markReconciling(bucket, "Reconciling bucket")).To(BeFalse())
+		Expect(bucket.Status.Conditions).To(HaveLen(1))
+
+		bucket.Generation++
+		Expect(markReconciling(bucket, "Reconciling bucket")).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(bucket.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+	})
+
+	It("should remove conditions written by earlier versions", func() {
+		bucket.Status.Conditions = []metav1.Condition{
+			{Type: "Error", Status: metav1.ConditionTrue, Reason: "ReconcileError"},
+			{Type: "Progressing", Status: metav1.ConditionFalse, Reason: "Ready"},
+		}
+
+		markReconciling(bucket, "Reconciling bucket")
+
+		Expect(meta.FindStatusCondition(bucket.Status.Conditions, "Error")).To(BeNil())
+		Expect(meta.FindStatusCondition(bucket.Status.Conditions, "Progressing")).To(BeNil())
+	})
+})
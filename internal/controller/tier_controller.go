@@ -0,0 +1,412 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// TierReconciler reconciles a Tier object
+type TierReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all tiers
+	DryRun bool
+}
+
+// tierCredentials holds the credentials of a remote tier read from its Secret
+type tierCredentials struct {
+	accessKey       string
+	secretKey       string
+	credentialsJSON []byte
+}
+
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=tiers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=tiers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=tiers/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile adds the remote tier to MinIO, applies rotated credentials and reports the tier stats
+func (r *TierReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	connection := func(tier *miniov1beta1.Tier) *miniov1beta1.MinIOConnection { return &tier.Spec.Connection }
+	lifecycle := &resourceLifecycle[*miniov1beta1.Tier, *minioclient.Client]{
+		Client:    r.Client,
+		Recorder:  r.Recorder,
+		DryRun:    r.DryRun,
+		name:      "tier",
+		finalizer: miniov1beta1.TierFinalizer,
+		status: func(tier *miniov1beta1.Tier) resourceStatus {
+			return resourceStatus{&tier.Status.Ready, &tier.Status.ObservedGeneration, &tier.Status.LastSyncTime, &tier.Status.PlannedChanges}
+		},
+		connections: singleConnection(connection),
+		delete:      r.handleDeletion,
+		connect:     clientFor(r.Client, connection),
+		reconcile: func(ctx context.Context, tier *miniov1beta1.Tier, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+			result, err := r.reconcileTier(ctx, tier, minioClient, plan)
+			if err == nil {
+				tier.Status.TierName = tier.Spec.TierName
+			}
+			return result, err
+		},
+	}
+	return lifecycle.run(ctx, req, &miniov1beta1.Tier{})
+}
+
+// handleDeletion removes the tier from MinIO if it was created by the controller
+func (r *TierReconciler) handleDeletion(ctx context.Context, tier *miniov1beta1.Tier) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if controllerutil.ContainsFinalizer(tier, miniov1beta1.TierFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, tier)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+
+		switch {
+		case violation:
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+		case tier.Status.CreationDate == nil:
+			// Tiers carry no owner marker, so adopted tiers are kept
+			logger.Info("Keeping tier that was not created by this resource", "tierName", tier.Spec.TierName)
+		default:
+			minioClient, err := newMinIOClient(ctx, r.Client, tier, tier.Spec.Connection)
+			if err != nil {
+				logger.Error(err, "Failed to create MinIO client for deletion, retrying")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+
+			tiers, err := minioClient.Admin.ListTiers(ctx)
+			if err != nil {
+				logger.Error(err, "Failed to list tiers, will retry")
+				return ctrl.Result{RequeueAfter: time.Minute}, nil
+			}
+			if findTier(tiers, tier.Spec.TierName) != nil {
+				// MinIO refuses to remove tiers that still hold transitioned objects
+				plan := newChangePlan(r.DryRun, tier)
+				err = plan.apply(fmt.Sprintf("remove tier %s", tier.Spec.TierName), func() error {
+					return minioClient.Admin.RemoveTier(ctx, tier.Spec.TierName)
+				})
+				if err != nil {
+					logger.Error(err, "Failed to remove tier, will retry", "tierName", tier.Spec.TierName)
+					return ctrl.Result{RequeueAfter: time.Minute}, nil
+				}
+				if plan.pending() {
+					return reportPlannedDeletion(ctx, r.Client, r.Recorder, tier, &tier.Status.PlannedChanges, plan)
+				}
+				logger.Info("Removed tier", "tierName", tier.Spec.TierName)
+			}
+		}
+
+		// Remove finalizer
+		controllerutil.RemoveFinalizer(tier, miniov1beta1.TierFinalizer)
+		if err := r.Update(ctx, tier); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// reconcileTier ensures the remote tier exists with the configuration of the spec and the current credentials
+func (r *TierReconciler) reconcileTier(ctx context.Context, tier *miniov1beta1.Tier, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	creds, err := r.getCredentials(ctx, tier)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	desired, err := tierConfig(tier, creds)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+	digest := credentialsDigest(creds)
+
+	tiers, err := minioClient.Admin.ListTiers(ctx)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to list tiers: %w", err)
+	}
+
+	live := findTier(tiers, tier.Spec.TierName)
+	if live == nil {
+		err = plan.apply(fmt.Sprintf("add %s tier %s", tier.Spec.Type, tier.Spec.TierName), func() error {
+			return minioClient.Admin.AddTier(ctx, desired)
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to add tier: %w", err)
+		}
+		if !plan.dryRun {
+			logger.Info("Tier added successfully", "tierName", tier.Spec.TierName)
+			tier.Status.CreationDate = &metav1.Time{Time: time.Now()}
+		}
+	} else {
+		// Only tiers created by this resource or adopted ones are managed
+		createdByController := tierCreatedByController(tier, live, desired)
+		if err := checkOwner(tier, "tier", tier.Spec.TierName, "", tier.Spec.Adopt, createdByController); err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+
+		// Apart from the credentials, MinIO cannot change a tier in place
+		if fields := tierMismatch(live, desired); len(fields) > 0 {
+			return ctrl.Result{RequeueAfter: time.Minute}, &invalidSpecError{message: fmt.Sprintf("tier %s exists with a different %s, tiers can only be recreated",
+				tier.Spec.TierName, strings.Join(fields, ", "))}
+		}
+
+		// A tier added before its creation could be recorded is removed with this resource like any other
+		if createdByController && tier.Status.CreationDate == nil && !plan.dryRun {
+			tier.Status.CreationDate = &metav1.Time{Time: time.Now()}
+		}
+
+		// Secrets cannot be read back, so rotation is detected by the digest of the applied credentials
+		if digest != tier.Status.CredentialsHash {
+			err = plan.apply(fmt.Sprintf("update credentials of tier %s", tier.Spec.TierName), func() error {
+				return minioClient.Admin.EditTier(ctx, tier.Spec.TierName, madmin.TierCreds{
+					AccessKey: creds.accessKey,
+					SecretKey: creds.secretKey,
+					CredsJSON: creds.credentialsJSON,
+				})
+			})
+			if err != nil {
+				return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to update tier credentials: %w", err)
+			}
+		}
+	}
+
+	if !plan.dryRun {
+		tier.Status.CredentialsHash = digest
+		r.recordStats(ctx, tier, minioClient)
+	}
+	return ctrl.Result{RequeueAfter: time.Hour}, nil
+}
+
+// recordStats reports the objects transitioned to the tier in status.
+// Failures are logged only, since stats reporting must not block reconciliation.
+func (r *TierReconciler) recordStats(ctx context.Context, tier *miniov1beta1.Tier, minioClient *minioclient.Client) {
+	stats, err := minioClient.Admin.TierStats(ctx)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get tier stats (non-fatal)")
+		return
+	}
+	for _, info := range stats {
+		if info.Name == tier.Spec.TierName {
+			tier.Status.Objects = int64(info.Stats.NumObjects)
+			tier.Status.Versions = int64(info.Stats.NumVersions)
+			tier.Status.Size = int64(info.Stats.TotalSize)
+		}
+	}
+}
+
+// getCredentials reads the credentials of the remote tier from its secret
+func (r *TierReconciler) getCredentials(ctx context.Context, tier *miniov1beta1.Tier) (tierCredentials, error) {
+	ref := tier.Spec.CredentialsSecretRef
+	secretNamespace := tier.Namespace
+	if ref.Namespace != nil {
+		secretNamespace = *ref.Namespace
+	}
+
+	if err := minioclient.CheckSecretReference(ctx, r.Client, tier.Namespace, secretNamespace, ref.Name); err != nil {
+		return tierCredentials{}, err
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: secretNamespace}, secret); err != nil {
+		return tierCredentials{}, fmt.Errorf("failed to get secret %s/%s: %w", secretNamespace, ref.Name, err)
+	}
+
+	value := func(key, defaultKey string) ([]byte, error) {
+		if key == "" {
+			key = defaultKey
+		}
+		data, ok := secret.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s/%s", key, secretNamespace, ref.Name)
+		}
+		return data, nil
+	}
+
+	// GCS authenticates with a service account key, all other backends with a key pair
+	if tier.Spec.Type == miniov1beta1.TierTypeGCS {
+		credentialsJSON, err := value(ref.CredentialsKey, miniov1beta1.DefaultCredentialsKey)
+		if err != nil {
+			return tierCredentials{}, err
+		}
+		return tierCredentials{credentialsJSON: credentialsJSON}, nil
+	}
+	accessKey, err := value(ref.AccessKeyIDKey, miniov1beta1.DefaultAccessKeyIDKey)
+	if err != nil {
+		return tierCredentials{}, err
+	}
+	secretKey, err := value(ref.SecretAccessKeyKey, miniov1beta1.DefaultSecretAccessKeyKey)
+	if err != nil {
+		return tierCredentials{}, err
+	}
+	return tierCredentials{accessKey: string(accessKey), secretKey: string(secretKey)}, nil
+}
+
+// tierConfig returns the MinIO configuration of the remote tier
+func tierConfig(tier *miniov1beta1.Tier, creds tierCredentials) (*madmin.TierConfig, error) {
+	spec := tier.Spec
+	switch spec.Type {
+	case miniov1beta1.TierTypeMinIO:
+		return madmin.NewTierMinIO(spec.TierName, spec.Endpoint, creds.accessKey, creds.secretKey, spec.Bucket,
+			madmin.MinIORegion(spec.Region), madmin.MinIOPrefix(spec.Prefix))
+	case miniov1beta1.TierTypeS3:
+		options := []madmin.S3Options{madmin.S3Region(spec.Region), madmin.S3Prefix(spec.Prefix)}
+		if spec.Endpoint != "" {
+			options = append(options, madmin.S3Endpoint(spec.Endpoint))
+		}
+		if spec.StorageClass != "" {
+			options = append(options, madmin.S3StorageClass(spec.StorageClass))
+		}
+		return madmin.NewTierS3(spec.TierName, creds.accessKey, creds.secretKey, spec.Bucket, options...)
+	case miniov1beta1.TierTypeAzure:
+		options := []madmin.AzureOptions{madmin.AzureRegion(spec.Region), madmin.AzurePrefix(spec.Prefix)}
+		if spec.Endpoint != "" {
+			options = append(options, madmin.AzureEndpoint(spec.Endpoint))
+		}
+		if spec.StorageClass != "" {
+			options = append(options, madmin.AzureStorageClass(spec.StorageClass))
+		}
+		return madmin.NewTierAzure(spec.TierName, creds.accessKey, creds.secretKey, spec.Bucket, options...)
+	case miniov1beta1.TierTypeGCS:
+		options := []madmin.GCSOptions{madmin.GCSRegion(spec.Region), madmin.GCSPrefix(spec.Prefix)}
+		if spec.StorageClass != "" {
+			options = append(options, madmin.GCSStorageClass(spec.StorageClass))
+		}
+		return madmin.NewTierGCS(spec.TierName, creds.credentialsJSON, spec.Bucket, options...)
+	}
+	return nil, &invalidSpecError{message: fmt.Sprintf("unsupported tier type %s", spec.Type)}
+}
+
+// findTier returns the tier named name, or nil if it does not exist
+func findTier(tiers []*madmin.TierConfig, name string) *madmin.TierConfig {
+	index := slices.IndexFunc(tiers, func(tier *madmin.TierConfig) bool { return tier.Name == name })
+	if index < 0 {
+		return nil
+	}
+	return tiers[index]
+}
+
+// tierMismatch returns the settings in which the live tier differs from the desired one. Credentials are
+// redacted in listed tiers and are not compared, neither are endpoint and region left to MinIO's defaults.
+func tierMismatch(live, desired *madmin.TierConfig) []string {
+	var fields []string
+	if live.Type != desired.Type {
+		return []string{"type"}
+	}
+	if desired.Endpoint() != "" && !sameEndpoint(live.Endpoint(), desired.Endpoint()) {
+		fields = append(fields, "endpoint")
+	}
+	if live.Bucket() != desired.Bucket() {
+		fields = append(fields, "bucket")
+	}
+	if strings.TrimSuffix(live.Prefix(), "/") != strings.TrimSuffix(desired.Prefix(), "/") {
+		fields = append(fields, "prefix")
+	}
+	if desired.Region() != "" && live.Region() != desired.Region() {
+		fields = append(fields, "region")
+	}
+	return fields
+}
+
+// tierCreatedByController reports whether the live tier was added for tier. Tiers carry no owner marker, so
+// besides a creation recorded in status, a tier with the configuration of the spec is recognised as
+// the one this resource added, since recording the creation may have failed after the tier was added.
+func tierCreatedByController(tier *miniov1beta1.Tier, live, desired *madmin.TierConfig) bool {
+	if tier.Status.CreationDate != nil {
+		return true
+	}
+	return !tier.Spec.Adopt && len(tierMismatch(live, desired)) == 0
+}
+
+// credentialsDigest returns the SHA-256 digest of the credentials of a tier
+func credentialsDigest(creds tierCredentials) string {
+	hash := sha256.New()
+	for _, value := range [][]byte{[]byte(creds.accessKey), []byte(creds.secretKey), creds.credentialsJSON} {
+		// Length prefixes keep the digests of different splits of the same bytes apart
+		fmt.Fprintf(hash, "%d:", len(value))
+		hash.Write(value)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// tierSecretField indexes Tiers by the namespace/name of the Secret holding their credentials
+const tierSecretField = "spec.credentialsSecretRef"
+
+// indexTierSecret returns the namespace/name of the Secret holding the credentials of a Tier
+func indexTierSecret(obj client.Object) []string {
+	tier, ok := obj.(*miniov1beta1.Tier)
+	if !ok || tier.Spec.CredentialsSecretRef.Name == "" {
+		return nil
+	}
+	namespace := tier.Namespace
+	if tier.Spec.CredentialsSecretRef.Namespace != nil {
+		namespace = *tier.Spec.CredentialsSecretRef.Namespace
+	}
+	return []string{types.NamespacedName{Namespace: namespace, Name: tier.Spec.CredentialsSecretRef.Name}.String()}
+}
+
+// tiersForSecret maps a Secret to the Tiers reading their credentials from it
+func (r *TierReconciler) tiersForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &miniov1beta1.TierList{}
+	if err := r.List(ctx, list, client.MatchingFields{tierSecretField: client.ObjectKeyFromObject(obj).String()}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list tiers")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TierReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Secrets are only mapped to the Tiers referencing them, without listing all Tiers on every Secret change
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &miniov1beta1.Tier{}, tierSecretField, indexTierSecret); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&miniov1beta1.Tier{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.tiersForSecret)).
+		Complete(r)
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Tier Controller", func() {
+	Context("When reconciling a resource", func() {
+		const resourceName = "test-tier"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind Tier")
+			err := k8sClient.Get(ctx, typeNamespacedName, &miniov1beta1.Tier{})
+			if err != nil && errors.IsNotFound(err) {
+				resource := &miniov1beta1.Tier{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: miniov1beta1.TierSpec{
+						Connection: miniov1beta1.MinIOConnection{
+							AliasRef: &miniov1beta1.AliasReference{Name: "missing-alias"},
+						},
+						TierName:             "WARM",
+						Type:                 miniov1beta1.TierTypeMinIO,
+						Endpoint:             "https://minio-warm.example.com",
+						Bucket:               "transitions",
+						CredentialsSecretRef: miniov1beta1.TierCredentialsReference{Name: "warm-credentials"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			}
+		})
+
+		AfterEach(func() {
+			resource := &miniov1beta1.Tier{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+
+			By("Cleanup the specific resource instance Tier")
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should report a connection that cannot be established", func() {
+			controllerReconciler := &TierReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			resource := &miniov1beta1.Tier{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(resource.Status.Conditions, miniov1beta1.ConditionReconciling)).To(BeTrue())
+			Expect(resource.Status.Ready).To(BeFalse())
+		})
+	})
+
+	Context("When comparing tiers", func() {
+		tier := func(spec miniov1beta1.TierSpec) *madmin.TierConfig {
+			config, err := tierConfig(&miniov1beta1.Tier{Spec: spec}, tierCredentials{accessKey: "access", secretKey: "secret"})
+			Expect(err).NotTo(HaveOccurred())
+			return config
+		}
+		spec := miniov1beta1.TierSpec{
+			TierName: "WARM",
+			Type:     miniov1beta1.TierTypeMinIO,
+			Endpoint: "https://minio-warm.example.com",
+			Bucket:   "transitions",
+			Prefix:   "archive",
+		}
+
+		It("should accept a tier with the same settings", func() {
+			live := spec
+			live.Endpoint = "https://minio-warm.example.com/"
+			live.Prefix = "archive/"
+			Expect(tierMismatch(tier(live), tier(spec))).To(BeEmpty())
+		})
+
+		It("should report settings that cannot be changed in place", func() {
+			live := spec
+			live.Bucket = "other"
+			live.Region = "eu-west-1"
+			desired := spec
+			desired.Region = "us-east-1"
+			Expect(tierMismatch(tier(live), tier(desired))).To(ConsistOf("bucket", "region"))
+		})
+
+		It("should report a different backend type only", func() {
+			live := spec
+			live.Type = miniov1beta1.TierTypeS3
+			Expect(tierMismatch(tier(live), tier(spec))).To(ConsistOf("type"))
+		})
+
+		It("should find tiers by name", func() {
+			tiers := []*madmin.TierConfig{tier(spec)}
+			Expect(findTier(tiers, "WARM")).To(Equal(tiers[0]))
+			Expect(findTier(tiers, "COLD")).To(BeNil())
+		})
+
+		It("should recognise a tier added before its creation was recorded", func() {
+			owned := &miniov1beta1.Tier{Spec: spec}
+			Expect(tierCreatedByController(owned, tier(spec), tier(spec))).To(BeTrue())
+
+			live := spec
+			live.Bucket = "other"
+			Expect(tierCreatedByController(owned, tier(live), tier(spec))).To(BeFalse())
+
+			owned.Status.CreationDate = &metav1.Time{Time: time.Now()}
+			Expect(tierCreatedByController(owned, tier(live), tier(spec))).To(BeTrue())
+		})
+
+		It("should keep adopted tiers when they match the spec", func() {
+			adopted := &miniov1beta1.Tier{Spec: spec}
+			adopted.Spec.Adopt = true
+			Expect(tierCreatedByController(adopted, tier(spec), tier(spec))).To(BeFalse())
+		})
+
+		It("should tell apart credentials split differently", func() {
+			Expect(credentialsDigest(tierCredentials{accessKey: "ab", secretKey: "c"})).
+				NotTo(Equal(credentialsDigest(tierCredentials{accessKey: "a", secretKey: "bc"})))
+			Expect(credentialsDigest(tierCredentials{accessKey: "a", secretKey: "b"})).
+				To(Equal(credentialsDigest(tierCredentials{accessKey: "a", secretKey: "b"})))
+		})
+	})
+
+	Context("When mapping Secrets to Tiers", func() {
+		It("should only enqueue the Tiers referencing the Secret", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+			shared := "shared"
+			warm := &miniov1beta1.Tier{
+				ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: "team-a"},
+				Spec:       miniov1beta1.TierSpec{CredentialsSecretRef: miniov1beta1.TierCredentialsReference{Name: "credentials"}},
+			}
+			cold := &miniov1beta1.Tier{
+				ObjectMeta: metav1.ObjectMeta{Name: "cold", Namespace: "team-b"},
+				Spec:       miniov1beta1.TierSpec{CredentialsSecretRef: miniov1beta1.TierCredentialsReference{Name: "credentials"}},
+			}
+			archive := &miniov1beta1.Tier{
+				ObjectMeta: metav1.ObjectMeta{Name: "archive", Namespace: "team-b"},
+				Spec:       miniov1beta1.TierSpec{CredentialsSecretRef: miniov1beta1.TierCredentialsReference{Name: "credentials", Namespace: &shared}},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(warm, cold, archive).
+				WithIndex(&miniov1beta1.Tier{}, tierSecretField, indexTierSecret).Build()
+			reconciler := &TierReconciler{Client: c, Scheme: scheme}
+
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "team-a"}}
+			Expect(reconciler.tiersForSecret(context.Background(), secret)).To(ConsistOf(
+				reconcile.Request{NamespacedName: client.ObjectKeyFromObject(warm)}))
+
+			secret.Namespace = "shared"
+			Expect(reconciler.tiersForSecret(context.Background(), secret)).To(ConsistOf(
+				reconcile.Request{NamespacedName: client.ObjectKeyFromObject(archive)}))
+
+			secret.Name = "other"
+			Expect(reconciler.tiersForSecret(context.Background(), secret)).To(BeEmpty())
+		})
+	})
+})
@@ -18,7 +18,10 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/minio/madmin-go/v3"
@@ -26,26 +29,32 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 	minioclient "github.com/mxcd/mc-controller/internal/minio"
+	"github.com/mxcd/mc-controller/internal/tenant"
 )
 
 // UserReconciler reconciles a User object
 type UserReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Recorder emits the planned changes of dry runs as events
+	Recorder record.EventRecorder
+	// DryRun only plans the MinIO changes of all users
+	DryRun bool
 }
 
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=users,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=users/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=users/finalizers,verbs=update
 //+kubebuilder:rbac:groups=mc-controller.mxcd.de,resources=endpoints,verbs=get;list;watch
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -53,7 +62,7 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	logger := log.FromContext(ctx)
 
 	// Fetch the User instance
-	user := &miniov1alpha1.User{}
+	user := &miniov1beta1.User{}
 	err := r.Get(ctx, req.NamespacedName, user)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -64,54 +73,114 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
+	// Resources paused by their own annotation or by their alias are left untouched, including their deletion
+	if paused, result, err := checkPaused(ctx, r.Client, user, &user.Spec.Connection); paused {
+		return result, err
+	}
+
 	// Handle deletion
 	if user.DeletionTimestamp != nil {
 		return r.handleDeletion(ctx, user)
 	}
 
 	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(user, miniov1alpha1.UserFinalizer) {
-		controllerutil.AddFinalizer(user, miniov1alpha1.UserFinalizer)
+	if !controllerutil.ContainsFinalizer(user, miniov1beta1.UserFinalizer) {
+		controllerutil.AddFinalizer(user, miniov1beta1.UserFinalizer)
 		return ctrl.Result{}, r.Update(ctx, user)
 	}
 
+	// Move a plaintext password set through v1alpha1 into a secret
+	if _, ok := user.Annotations[miniov1beta1.LegacyPasswordAnnotation]; ok {
+		plan := newChangePlan(r.DryRun, user)
+		err := plan.apply(fmt.Sprintf("move password of user %s into secret %s", user.Name, passwordSecretName(user)), func() error {
+			return r.migratePassword(ctx, user)
+		})
+		if err != nil {
+			logger.Error(err, "Failed to migrate password")
+			var conflict *conflictError
+			if !errors.As(err, &conflict) {
+				return ctrl.Result{}, err
+			}
+			// A secret of the same name that the User does not control is never written
+			patch := client.MergeFrom(user.DeepCopy())
+			markFailed(user, reasonConflict, err.Error())
+			user.Status.Ready = false
+			if err := r.Status().Patch(ctx, user, patch); err != nil {
+				logger.Error(err, "Failed to update status")
+			}
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if plan.pending() {
+			// The user cannot be reconciled before its password is in a secret
+			return reportPlannedChanges(ctx, r.Client, r.Recorder, user, &user.Status.PlannedChanges, plan)
+		}
+		logger.Info("Moved plaintext password into secret", "secret", user.Spec.SecretRef.Name)
+		return ctrl.Result{}, nil
+	}
+
 	// Update status to indicate reconciliation is in progress
-	miniov1alpha1.SetCondition(&user.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionTrue, "Reconciling", "Reconciling user")
-	user.Status.ObservedGeneration = user.Generation
-	if err := r.Status().Update(ctx, user); err != nil {
-		logger.Error(err, "Failed to update status")
-		return ctrl.Result{}, err
+	patch := client.MergeFrom(user.DeepCopy())
+	if markReconciling(user, "Reconciling user") {
+		user.Status.ObservedGeneration = user.Generation
+		if err := r.Status().Patch(ctx, user, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Status changes made from here on are patched with the outcome of the reconciliation
+	patch = client.MergeFrom(user.DeepCopy())
+
+	// Enforce the tenant policies of the namespace
+	if err := tenant.Check(ctx, r.Client, user); err != nil {
+		logger.Error(err, "User is not allowed by the tenant policies")
+		markFailed(user, errorReason(err, reasonReconcileError), err.Error())
+		user.Status.Ready = false
+		if err := r.Status().Patch(ctx, user, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Create MinIO client
-	minioClient, err := minioclient.NewClient(ctx, r.Client, user.Spec.Connection, user.Namespace)
+	minioClient, err := newMinIOClient(ctx, r.Client, user, user.Spec.Connection)
 	if err != nil {
 		logger.Error(err, "Failed to create MinIO client")
-		miniov1alpha1.SetCondition(&user.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ClientError", fmt.Sprintf("Failed to create MinIO client: %v", err))
+		markFailed(user, errorReason(err, reasonClientError), fmt.Sprintf("Failed to create MinIO client: %v", err))
 		user.Status.Ready = false
-		r.Status().Update(ctx, user)
+		if err := r.Status().Patch(ctx, user, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return ctrl.Result{RequeueAfter: time.Minute}, nil
 	}
 
 	// Reconcile the user
-	result, err := r.reconcileUser(ctx, user, minioClient)
+	plan := newChangePlan(r.DryRun, user)
+	result, err := r.reconcileUser(ctx, user, minioClient, plan)
 	if err != nil {
 		logger.Error(err, "Failed to reconcile user")
-		miniov1alpha1.SetCondition(&user.Status.Conditions, miniov1alpha1.ConditionError, metav1.ConditionTrue, "ReconcileError", fmt.Sprintf("Failed to reconcile user: %v", err))
+		markFailed(user, errorReason(err, reasonReconcileError), fmt.Sprintf("Failed to reconcile user: %v", err))
 		user.Status.Ready = false
 		user.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
-		r.Status().Update(ctx, user)
+		if err := r.Status().Patch(ctx, user, patch); err != nil {
+			logger.Error(err, "Failed to update status")
+		}
 		return result, err
 	}
 
-	// Update status to ready
-	miniov1alpha1.SetCondition(&user.Status.Conditions, miniov1alpha1.ConditionReady, metav1.ConditionTrue, "Ready", "User is ready")
-	miniov1alpha1.SetCondition(&user.Status.Conditions, miniov1alpha1.ConditionProgressing, metav1.ConditionFalse, "Ready", "User reconciliation completed")
-	user.Status.Ready = true
+	// Update status to ready, unless a dry run left changes unapplied
+	user.Status.PlannedChanges = plan.planned()
+	if plan.pending() {
+		plan.report(r.Recorder, user)
+		user.Status.Ready = false
+	} else {
+		markReady(user, "User is ready")
+		user.Status.Ready = true
+	}
 	user.Status.Username = user.Spec.Username
 	user.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
 
-	if err := r.Status().Update(ctx, user); err != nil {
+	if err := r.Status().Patch(ctx, user, patch); err != nil {
 		logger.Error(err, "Failed to update status to ready")
 		return ctrl.Result{}, err
 	}
@@ -120,12 +189,31 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 }
 
 // handleDeletion handles the deletion of a User resource
-func (r *UserReconciler) handleDeletion(ctx context.Context, user *miniov1alpha1.User) (ctrl.Result, error) {
+func (r *UserReconciler) handleDeletion(ctx context.Context, user *miniov1beta1.User) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	if controllerutil.ContainsFinalizer(user, miniov1alpha1.UserFinalizer) {
+	if controllerutil.ContainsFinalizer(user, miniov1beta1.UserFinalizer) {
+		// Resources rejected by the tenant policies never managed the MinIO objects they name
+		violation, err := tenantViolation(ctx, r.Client, user)
+		if err != nil {
+			logger.Error(err, "Failed to check tenant policies during deletion")
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if violation {
+			logger.Info("Skipping cleanup of a resource rejected by the tenant policies")
+			controllerutil.RemoveFinalizer(user, miniov1beta1.UserFinalizer)
+			return ctrl.Result{}, r.Update(ctx, user)
+		}
+
+		// Only the Full management policy deletes the user in MinIO
+		if !user.Spec.ManagementPolicy.Deletes() {
+			logger.Info("Keeping user as required by the management policy", "username", user.Spec.Username, "managementPolicy", user.Spec.ManagementPolicy)
+			controllerutil.RemoveFinalizer(user, miniov1beta1.UserFinalizer)
+			return ctrl.Result{}, r.Update(ctx, user)
+		}
+
 		// Create MinIO client for cleanup
-		minioClient, err := minioclient.NewClient(ctx, r.Client, user.Spec.Connection, user.Namespace)
+		minioClient, err := newMinIOClient(ctx, r.Client, user, user.Spec.Connection)
 		if err != nil {
 			logger.Error(err, "Failed to create MinIO client for deletion")
 			// If we can't connect to MinIO, we'll remove the finalizer anyway after a grace period
@@ -133,19 +221,45 @@ func (r *UserReconciler) handleDeletion(ctx context.Context, user *miniov1alpha1
 		}
 
 		// Check if user exists and delete it
-		_, err = minioClient.Admin.GetUserInfo(ctx, user.Spec.Username)
-		if err == nil {
+		userInfo, userExists, err := lookupUser(ctx, minioClient, user.Spec.Username)
+		if err != nil {
+			logger.Error(err, "Failed to look up user for deletion, will retry", "username", user.Spec.Username)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
+		if userExists && !ownedBy(user, userOwner(userInfo.MemberOf), user.Status.CreationDate != nil) {
+			// Never remove a user managed by another resource
+			logger.Info("User is not owned by this resource, skipping deletion", "username", user.Spec.Username)
+		} else if userExists {
 			// User exists, delete it
-			err = minioClient.Admin.RemoveUser(ctx, user.Spec.Username)
+			plan := newChangePlan(r.DryRun, user)
+			err = plan.apply(fmt.Sprintf("remove user %s", user.Spec.Username), func() error {
+				return minioClient.Admin.RemoveUser(ctx, user.Spec.Username)
+			})
 			if err != nil {
 				logger.Error(err, "Failed to delete user")
 				return ctrl.Result{RequeueAfter: time.Minute}, nil
 			}
+
+			// The owner group is empty once the user is removed
+			ownerGroup := miniov1beta1.OwnerGroupPrefix + string(user.UID)
+			err = plan.apply(fmt.Sprintf("remove owner group %s", ownerGroup), func() error {
+				return minioClient.Admin.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+					Group:    ownerGroup,
+					IsRemove: true,
+				})
+			})
+			if err != nil {
+				logger.Error(err, "Failed to remove owner group (non-fatal)")
+			}
+
+			if plan.pending() {
+				return reportPlannedDeletion(ctx, r.Client, r.Recorder, user, &user.Status.PlannedChanges, plan)
+			}
 			logger.Info("User deleted successfully", "username", user.Spec.Username)
 		}
 
 		// Remove the finalizer
-		controllerutil.RemoveFinalizer(user, miniov1alpha1.UserFinalizer)
+		controllerutil.RemoveFinalizer(user, miniov1beta1.UserFinalizer)
 		return ctrl.Result{}, r.Update(ctx, user)
 	}
 
@@ -153,70 +267,208 @@ func (r *UserReconciler) handleDeletion(ctx context.Context, user *miniov1alpha1
 }
 
 // reconcileUser reconciles the user state
-func (r *UserReconciler) reconcileUser(ctx context.Context, user *miniov1alpha1.User, minioClient *minioclient.Client) (ctrl.Result, error) {
+func (r *UserReconciler) reconcileUser(ctx context.Context, user *miniov1beta1.User, minioClient *minioclient.Client, plan *changePlan) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	// Get password from secret or spec
-	password, err := r.getPassword(ctx, user)
+	// Check if user exists
+	userInfo, userExists, err := lookupUser(ctx, minioClient, user.Spec.Username)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
+	managementPolicy := user.Spec.ManagementPolicy
+	switch {
+	case !userExists && !managementPolicy.Creates():
+		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("user %s does not exist and is not created with management policy %s", user.Spec.Username, managementPolicy)
+	case userExists && !correctsDrift(managementPolicy, user.Spec.DriftPolicy):
+		// Existing users are left untouched, only their live state and drift are reported
+		observeUser(user, userInfo)
+		reportDrift(user, "User", &user.Status.Drift, userDrift(user, userInfo), false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
+	}
+
+	// Get password from secret
+	password, secretVersion, err := r.getPassword(ctx, user)
 	if err != nil {
 		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to get password: %w", err)
 	}
 
-	// Check if user exists
-	_, err = minioClient.Admin.GetUserInfo(ctx, user.Spec.Username)
-	userExists := err == nil
+	var drift []miniov1beta1.DriftEntry
+	if userExists {
+		// Only manage users owned by this resource
+		err := checkOwner(user, "user", user.Spec.Username, userOwner(userInfo.MemberOf), user.Spec.Adopt, user.Status.CreationDate != nil)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, err
+		}
+		// Differences to an unchanged spec have been made outside of the controller
+		if specSynced(user) {
+			drift = userDrift(user, userInfo)
+		}
+	}
+
+	desiredStatus := madmin.AccountEnabled
+	if user.Spec.Status == miniov1beta1.UserStatusDisabled {
+		desiredStatus = madmin.AccountDisabled
+	}
 
 	if !userExists {
 		// Create the user
-		err = minioClient.Admin.AddUser(ctx, user.Spec.Username, password)
+		err = plan.apply(fmt.Sprintf("create user %s", user.Spec.Username), func() error {
+			return minioClient.Admin.AddUser(ctx, user.Spec.Username, password)
+		})
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to create user: %w", err)
 		}
-		logger.Info("User created successfully", "username", user.Spec.Username)
-		user.Status.CreationDate = &metav1.Time{Time: time.Now()}
-	} else {
-		// Update password if needed (we can't compare current password)
-		err = minioClient.Admin.SetUser(ctx, user.Spec.Username, password, madmin.AccountEnabled)
+		if user.Status.CreationDate != nil {
+			// The user was created before and has been removed outside of the controller
+			drift = append(drift, removedDrift)
+		}
+		if !plan.dryRun {
+			logger.Info("User created successfully", "username", user.Spec.Username)
+			user.Status.CreationDate = &metav1.Time{Time: time.Now()}
+			user.Status.PasswordSecretVersion = secretVersion
+		}
+
+		// New users are enabled
+		if desiredStatus == madmin.AccountDisabled {
+			err = plan.apply(fmt.Sprintf("disable user %s", user.Spec.Username), func() error {
+				return minioClient.Admin.SetUserStatus(ctx, user.Spec.Username, desiredStatus)
+			})
+			if err != nil {
+				return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set user status: %w", err)
+			}
+		}
+	} else if secretVersion != user.Status.PasswordSecretVersion {
+		// Passwords cannot be read back, so changes are detected by the version of the applied secret
+		err = plan.apply(fmt.Sprintf("update password and status of user %s", user.Spec.Username), func() error {
+			return minioClient.Admin.SetUser(ctx, user.Spec.Username, password, desiredStatus)
+		})
 		if err != nil {
 			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to update user: %w", err)
 		}
+		if !plan.dryRun {
+			user.Status.PasswordSecretVersion = secretVersion
+		}
+	} else if userInfo.Status != desiredStatus {
+		err = plan.apply(fmt.Sprintf("set status of user %s to %s", user.Spec.Username, desiredStatus), func() error {
+			return minioClient.Admin.SetUserStatus(ctx, user.Spec.Username, desiredStatus)
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set user status: %w", err)
+		}
 	}
 
-	// Set user status
-	status := madmin.AccountEnabled
-	if user.Spec.Status == miniov1alpha1.UserStatusDisabled {
-		status = madmin.AccountDisabled
+	// Mark the user as managed by this resource
+	if userOwner(userInfo.MemberOf) != string(user.UID) {
+		ownerGroup := miniov1beta1.OwnerGroupPrefix + string(user.UID)
+		err = plan.apply(fmt.Sprintf("add user %s to owner group %s", user.Spec.Username, ownerGroup), func() error {
+			return minioClient.Admin.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+				Group:   ownerGroup,
+				Members: []string{user.Spec.Username},
+			})
+		})
+		if err != nil {
+			return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to mark user as owned: %w", err)
+		}
 	}
 
-	err = minioClient.Admin.SetUserStatus(ctx, user.Spec.Username, status)
-	if err != nil {
-		return ctrl.Result{RequeueAfter: time.Minute}, fmt.Errorf("failed to set user status: %w", err)
+	// Set user policies
+	if len(user.Spec.Policies) > 0 && !slices.Contains(strings.Split(userInfo.PolicyName, ","), user.Spec.Policies[0]) {
+		err = plan.apply(fmt.Sprintf("attach policy %s to user %s", user.Spec.Policies[0], user.Spec.Username), func() error {
+			return minioClient.Admin.SetPolicy(ctx, user.Spec.Policies[0], user.Spec.Username, false)
+		})
+		if err != nil {
+			logger.Error(err, "Failed to set user policy (non-fatal)")
+		}
+	}
+
+	// A dry run reports the live state before the planned changes
+	if plan.dryRun {
+		if userExists {
+			observeUser(user, userInfo)
+		}
+		reportDrift(user, "User", &user.Status.Drift, drift, false)
+		return ctrl.Result{RequeueAfter: time.Hour}, nil
 	}
 
 	// Update status fields
 	user.Status.Status = user.Spec.Status
 	user.Status.Groups = user.Spec.Groups
 	user.Status.Policies = user.Spec.Policies
+	reportDrift(user, "User", &user.Status.Drift, drift, true)
 
-	// Set user policies
-	if len(user.Spec.Policies) > 0 {
-		err = minioClient.Admin.SetPolicy(ctx, user.Spec.Policies[0], user.Spec.Username, false)
-		if err != nil {
-			logger.Error(err, "Failed to set user policy (non-fatal)")
+	return ctrl.Result{RequeueAfter: time.Hour}, nil
+}
+
+// observeUser reports the live state of the user in its status
+func observeUser(user *miniov1beta1.User, userInfo madmin.UserInfo) {
+	user.Status.Status = miniov1beta1.UserStatusType(userInfo.Status)
+	user.Status.Groups = nil
+	for _, group := range userInfo.MemberOf {
+		if !strings.HasPrefix(group, miniov1beta1.OwnerGroupPrefix) {
+			user.Status.Groups = append(user.Status.Groups, group)
 		}
 	}
+	user.Status.Policies = nil
+	if userInfo.PolicyName != "" {
+		user.Status.Policies = strings.Split(userInfo.PolicyName, ",")
+	}
+}
 
-	return ctrl.Result{RequeueAfter: time.Hour}, nil
+// userDrift returns the drift between the spec and the live state of a user
+func userDrift(user *miniov1beta1.User, userInfo madmin.UserInfo) []miniov1beta1.DriftEntry {
+	desired := madmin.AccountEnabled
+	if user.Spec.Status == miniov1beta1.UserStatusDisabled {
+		desired = madmin.AccountDisabled
+	}
+	if userInfo.Status != desired {
+		return []miniov1beta1.DriftEntry{{Field: "status", Desired: string(desired), Actual: string(userInfo.Status)}}
+	}
+	return nil
 }
 
-// getPassword retrieves the password from secret or spec
-func (r *UserReconciler) getPassword(ctx context.Context, user *miniov1alpha1.User) (string, error) {
-	if user.Spec.Password != nil {
-		return *user.Spec.Password, nil
+// migratePassword moves the plaintext password of a User created through v1alpha1 into a
+// secret owned by the User and points the User's secretRef at it. An existing secret is only
+// written if it is controlled by the User.
+func (r *UserReconciler) migratePassword(ctx context.Context, user *miniov1beta1.User) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      passwordSecretName(user),
+			Namespace: user.Namespace,
+		},
 	}
 
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.ResourceVersion != "" && !metav1.IsControlledBy(secret, user) {
+			return &conflictError{message: fmt.Sprintf("secret %s already exists and is not owned by the user", secret.Name)}
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[miniov1beta1.DefaultPasswordKey] = []byte(user.Annotations[miniov1beta1.LegacyPasswordAnnotation])
+		return controllerutil.SetControllerReference(user, secret, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write password secret: %w", err)
+	}
+
+	delete(user.Annotations, miniov1beta1.LegacyPasswordAnnotation)
+	user.Spec.SecretRef = &miniov1beta1.UserSecretReference{
+		Name:        secret.Name,
+		PasswordKey: miniov1beta1.DefaultPasswordKey,
+	}
+	return r.Update(ctx, user)
+}
+
+// passwordSecretName returns the name of the secret the plaintext password of a v1alpha1 User is moved into
+func passwordSecretName(user *miniov1beta1.User) string {
+	return user.Name + "-password"
+}
+
+// getPassword retrieves the password from the user's secret, along with the UID and resource version of the secret
+func (r *UserReconciler) getPassword(ctx context.Context, user *miniov1beta1.User) (string, string, error) {
 	if user.Spec.SecretRef == nil {
-		return "", fmt.Errorf("either password or secretRef must be specified")
+		return "", "", fmt.Errorf("secretRef must be specified")
 	}
 
 	secretRef := user.Spec.SecretRef
@@ -225,32 +477,36 @@ func (r *UserReconciler) getPassword(ctx context.Context, user *miniov1alpha1.Us
 		secretNamespace = *secretRef.Namespace
 	}
 
+	if err := minioclient.CheckSecretReference(ctx, r.Client, user.Namespace, secretNamespace, secretRef.Name); err != nil {
+		return "", "", err
+	}
+
 	secret := &corev1.Secret{}
 	err := r.Get(ctx, client.ObjectKey{
 		Name:      secretRef.Name,
 		Namespace: secretNamespace,
 	}, secret)
 	if err != nil {
-		return "", fmt.Errorf("failed to get password secret: %w", err)
+		return "", "", fmt.Errorf("failed to get password secret: %w", err)
 	}
 
 	// Use default key "password" if not specified
-	passwordKey := "password"
-	if secretRef.SecretAccessKeyKey != "" {
-		passwordKey = secretRef.SecretAccessKeyKey
+	passwordKey := miniov1beta1.DefaultPasswordKey
+	if secretRef.PasswordKey != "" {
+		passwordKey = secretRef.PasswordKey
 	}
 
 	passwordBytes, ok := secret.Data[passwordKey]
 	if !ok {
-		return "", fmt.Errorf("password not found in secret with key %s", passwordKey)
+		return "", "", fmt.Errorf("password not found in secret with key %s", passwordKey)
 	}
 
-	return string(passwordBytes), nil
+	return string(passwordBytes), fmt.Sprintf("%s/%s", secret.UID, secret.ResourceVersion), nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *UserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&miniov1alpha1.User{}).
+		For(&miniov1beta1.User{}).
 		Complete(r)
 }
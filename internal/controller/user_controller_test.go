@@ -18,16 +18,24 @@ package controller
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	"github.com/minio/madmin-go/v3"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 )
 
 var _ = Describe("User Controller", func() {
@@ -40,13 +48,13 @@ var _ = Describe("User Controller", func() {
 			Name:      resourceName,
 			Namespace: "default", // TODO(user):Modify as needed
 		}
-		user := &miniov1alpha1.User{}
+		user := &miniov1beta1.User{}
 
 		BeforeEach(func() {
 			By("creating the custom resource for the Kind User")
 			err := k8sClient.Get(ctx, typeNamespacedName, user)
 			if err != nil && errors.IsNotFound(err) {
-				resource := &miniov1alpha1.User{
+				resource := &miniov1beta1.User{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      resourceName,
 						Namespace: "default",
@@ -59,7 +67,7 @@ var _ = Describe("User Controller", func() {
 
 		AfterEach(func() {
 			// TODO(user): Cleanup logic after each test, like removing the resource instance.
-			resource := &miniov1alpha1.User{}
+			resource := &miniov1beta1.User{}
 			err := k8sClient.Get(ctx, typeNamespacedName, resource)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -81,4 +89,196 @@ var _ = Describe("User Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When observing a user", func() {
+		It("should report the live state without the owner group", func() {
+			user := &miniov1beta1.User{}
+			observeUser(user, madmin.UserInfo{
+				Status:     madmin.AccountDisabled,
+				MemberOf:   []string{"developers", miniov1beta1.OwnerGroupPrefix + "uid-1"},
+				PolicyName: "readonly,diagnostics",
+			})
+			Expect(user.Status.Status).To(Equal(miniov1beta1.UserStatusDisabled))
+			Expect(user.Status.Groups).To(Equal([]string{"developers"}))
+			Expect(user.Status.Policies).To(Equal([]string{"readonly", "diagnostics"}))
+		})
+
+		It("should only write to MinIO as allowed by the management policy", func() {
+			Expect(miniov1beta1.ManagementPolicy("").Updates()).To(BeTrue())
+			Expect(miniov1beta1.ManagementPolicy("").Deletes()).To(BeTrue())
+			Expect(miniov1beta1.ManagementPolicyObserve.Creates()).To(BeFalse())
+			Expect(miniov1beta1.ManagementPolicyCreateOnly.Creates()).To(BeTrue())
+			Expect(miniov1beta1.ManagementPolicyCreateOnly.Updates()).To(BeFalse())
+			Expect(miniov1beta1.ManagementPolicyFullNoDelete.Updates()).To(BeTrue())
+			Expect(miniov1beta1.ManagementPolicyFullNoDelete.Deletes()).To(BeFalse())
+		})
+	})
+
+	Context("When the user cannot be looked up", func() {
+		It("should not create the user with management policy CreateOnly", func() {
+			admin := newFailingAdmin(http.StatusInternalServerError, "XMinioServerNotInitialized")
+			defer admin.server.Close()
+
+			user := &miniov1beta1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "default", UID: "uid-1"},
+				Spec: miniov1beta1.UserSpec{
+					Username:         "alice",
+					ManagementPolicy: miniov1beta1.ManagementPolicyCreateOnly,
+				},
+			}
+			reconciler := &UserReconciler{}
+			_, err := reconciler.reconcileUser(context.Background(), user, admin.client(), newChangePlan(false, user))
+			Expect(err).To(HaveOccurred())
+			Expect(admin.called()).To(Equal([]string{"user-info"}))
+		})
+	})
+
+	Context("When the password secret is unchanged", func() {
+		var (
+			ctx    context.Context
+			scheme *runtime.Scheme
+			secret *corev1.Secret
+			user   *miniov1beta1.User
+			admin  *fakeAdmin
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme = runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "alice-password", Namespace: "default", UID: "secret-uid"},
+				Data:       map[string][]byte{miniov1beta1.DefaultPasswordKey: []byte("password")},
+			}
+			user = &miniov1beta1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "alice", Namespace: "default", UID: "uid-1"},
+				Spec: miniov1beta1.UserSpec{
+					Username:  "alice",
+					SecretRef: &miniov1beta1.UserSecretReference{Name: "alice-password"},
+				},
+				Status: miniov1beta1.UserStatus{CreationDate: &metav1.Time{Time: time.Now()}},
+			}
+			admin = newFakeAdmin(map[string]interface{}{
+				"user-info": madmin.UserInfo{
+					Status:   madmin.AccountEnabled,
+					MemberOf: []string{miniov1beta1.OwnerGroupPrefix + "uid-1"},
+				},
+			})
+			DeferCleanup(admin.server.Close)
+		})
+
+		reconcileUser := func(dryRun bool) *changePlan {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+			reconciler := &UserReconciler{Client: c, Scheme: scheme}
+			plan := newChangePlan(dryRun, user)
+			_, err := reconciler.reconcileUser(ctx, user, admin.client(), plan)
+			Expect(err).NotTo(HaveOccurred())
+			return plan
+		}
+
+		It("should only set the password once per secret version", func() {
+			reconcileUser(false)
+			Expect(admin.called()).To(ContainElement("add-user"))
+			Expect(user.Status.PasswordSecretVersion).To(Equal("secret-uid/" + secret.ResourceVersion))
+
+			admin.reset()
+			reconcileUser(false)
+			Expect(admin.called()).To(Equal([]string{"user-info"}))
+		})
+
+		It("should not plan changes in a dry run", func() {
+			reconcileUser(false)
+			Expect(reconcileUser(true).pending()).To(BeFalse())
+		})
+
+		It("should only correct the status if the password is unchanged", func() {
+			reconcileUser(false)
+			admin.reset()
+			user.Spec.Status = miniov1beta1.UserStatusDisabled
+			reconcileUser(false)
+			Expect(admin.called()).To(Equal([]string{"user-info", "set-user-status"}))
+		})
+	})
+
+	Context("When moving a v1alpha1 password in a dry run", func() {
+		It("should only plan writing the secret", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+			url := "http://minio.example.com"
+			user := &miniov1beta1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "alice",
+					Namespace:   "default",
+					Finalizers:  []string{miniov1beta1.UserFinalizer},
+					Annotations: map[string]string{miniov1beta1.LegacyPasswordAnnotation: "password"},
+				},
+				Spec: miniov1beta1.UserSpec{
+					Connection: miniov1beta1.MinIOConnection{URL: &url},
+					Username:   "alice",
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(user).
+				WithStatusSubresource(&miniov1beta1.User{}).Build()
+			reconciler := &UserReconciler{Client: c, Scheme: scheme, DryRun: true}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(user)})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(user), user)).To(Succeed())
+			Expect(user.Annotations).To(HaveKey(miniov1beta1.LegacyPasswordAnnotation))
+			Expect(user.Spec.SecretRef).To(BeNil())
+			Expect(user.Status.PlannedChanges).To(HaveLen(1))
+			err = c.Get(ctx, client.ObjectKey{Name: "alice-password", Namespace: "default"}, &corev1.Secret{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Context("When moving a v1alpha1 password into a secret of another owner", func() {
+		It("should leave the secret untouched and report a conflict", func() {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+			url := "http://minio.example.com"
+			user := &miniov1beta1.User{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "alice",
+					Namespace:   "default",
+					Finalizers:  []string{miniov1beta1.UserFinalizer},
+					Annotations: map[string]string{miniov1beta1.LegacyPasswordAnnotation: "password"},
+				},
+				Spec: miniov1beta1.UserSpec{
+					Connection: miniov1beta1.MinIOConnection{URL: &url},
+					Username:   "alice",
+				},
+			}
+			foreign := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "alice-password", Namespace: "default"},
+				Data:       map[string][]byte{"password": []byte("unrelated")},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(user, foreign).
+				WithStatusSubresource(&miniov1beta1.User{}).Build()
+			reconciler := &UserReconciler{Client: c, Scheme: scheme}
+			_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(user)})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(foreign), foreign)).To(Succeed())
+			Expect(foreign.Data).To(Equal(map[string][]byte{"password": []byte("unrelated")}))
+			Expect(foreign.OwnerReferences).To(BeEmpty())
+
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(user), user)).To(Succeed())
+			Expect(user.Annotations).To(HaveKey(miniov1beta1.LegacyPasswordAnnotation))
+			Expect(user.Spec.SecretRef).To(BeNil())
+			Expect(user.Status.Conditions).To(ContainElement(And(
+				HaveField("Type", miniov1beta1.ConditionConflict),
+				HaveField("Status", metav1.ConditionTrue),
+			)))
+		})
+	})
 })
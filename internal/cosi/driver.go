@@ -0,0 +1,400 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cosi implements a Container Object Storage Interface (COSI) driver provisioning buckets and
+// credentials on the MinIO servers of Aliases and ClusterAliases.
+package cosi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	cosispec "sigs.k8s.io/container-object-storage-interface-spec"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// DefaultDriverName is the name BucketClasses and BucketAccessClasses reference the driver with
+const DefaultDriverName = "mc-controller.mxcd.de"
+
+// Parameters of BucketClasses
+const (
+	// ParameterClusterAlias selects the ClusterAlias buckets are created with
+	ParameterClusterAlias = "clusterAlias"
+	// ParameterAlias selects the Alias buckets are created with, together with ParameterAliasNamespace
+	ParameterAlias = "alias"
+	// ParameterAliasNamespace is the namespace of the Alias
+	ParameterAliasNamespace = "aliasNamespace"
+	// ParameterVersioning enables versioning on created buckets if "true"
+	ParameterVersioning = "versioning"
+)
+
+// ParameterAccess of BucketAccessClasses selects ReadOnly, ReadWrite (default) or Admin access
+const ParameterAccess = "access"
+
+// Keys of the S3 credentials returned to the provisioner sidecar
+const (
+	credentialsS3Key       = "s3"
+	accessKeyIDKey         = "accessKeyID"
+	accessSecretKeyKey     = "accessSecretKey"
+	credentialsEndpointKey = "endpoint"
+	credentialsRegionKey   = "region"
+)
+
+// Connection kinds encoded in bucket IDs
+const (
+	bucketIDClusterAlias = "clusteralias"
+	bucketIDAlias        = "alias"
+)
+
+// Driver implements the COSI identity and provisioner services. Buckets are created on the server of the
+// Alias or ClusterAlias selected by the BucketClass, and access is granted with a MinIO user and a policy
+// restricted to the bucket.
+type Driver struct {
+	cosispec.UnimplementedIdentityServer
+	cosispec.UnimplementedProvisionerServer
+
+	// Name is the driver name returned to the provisioner sidecar
+	Name string
+	// Client reads the Aliases, ClusterAliases and their secrets
+	Client client.Client
+}
+
+var _ cosispec.IdentityServer = &Driver{}
+var _ cosispec.ProvisionerServer = &Driver{}
+
+// DriverGetInfo returns the name of the driver
+func (d *Driver) DriverGetInfo(ctx context.Context, req *cosispec.DriverGetInfoRequest) (*cosispec.DriverGetInfoResponse, error) {
+	if d.Name == "" {
+		return nil, status.Error(codes.Unavailable, "driver name is not configured")
+	}
+	return &cosispec.DriverGetInfoResponse{Name: d.Name}, nil
+}
+
+// DriverCreateBucket creates a bucket tagged with the owner marker of the driver and the digest of the
+// parameters. Buckets the driver created with the same parameters are reported as created, so that the
+// call is idempotent, while other existing buckets are reported as already existing.
+func (d *Driver) DriverCreateBucket(ctx context.Context, req *cosispec.DriverCreateBucketRequest) (*cosispec.DriverCreateBucketResponse, error) {
+	logger := log.FromContext(ctx).WithValues("bucket", req.GetName())
+
+	conn, err := bucketClassConnection(req.GetParameters())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	versioning := false
+	if value, ok := req.GetParameters()[ParameterVersioning]; ok {
+		versioning, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s parameter: %v", ParameterVersioning, err)
+		}
+	}
+	bucketName := req.GetName()
+	if bucketName == "" {
+		return nil, status.Error(codes.InvalidArgument, "bucket name is required")
+	}
+
+	minioClient, err := d.newClient(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := minioClient.S3.BucketExists(ctx, bucketName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check bucket %s: %v", bucketName, err)
+	}
+	if exists {
+		existingTags, err := bucketTags(ctx, minioClient, bucketName)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get tags of bucket %s: %v", bucketName, err)
+		}
+		if existingTags[miniov1beta1.OwnerTag] != d.owner() {
+			return nil, status.Errorf(codes.AlreadyExists, "bucket %s exists and was not created by the driver", bucketName)
+		}
+		if existingTags[parametersTag] != parametersDigest(req.GetParameters()) {
+			return nil, status.Errorf(codes.AlreadyExists, "bucket %s was created with different parameters", bucketName)
+		}
+	} else {
+		ownerTags, err := d.ownerTags(req.GetParameters())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		logger.Info("Creating bucket")
+		err = minioClient.S3.MakeBucket(ctx, bucketName, minio.MakeBucketOptions{Region: minioClient.Region()})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create bucket %s: %v", bucketName, err)
+		}
+		if err := minioClient.S3.SetBucketTagging(ctx, bucketName, ownerTags); err != nil {
+			// An untagged bucket would be foreign to the retry, so it is removed again while still empty
+			if err := minioClient.S3.RemoveBucket(ctx, bucketName); err != nil {
+				logger.Error(err, "Failed to remove untagged bucket")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to tag bucket %s: %v", bucketName, err)
+		}
+	}
+	if versioning {
+		if err := minioClient.S3.EnableVersioning(ctx, bucketName); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to enable versioning of bucket %s: %v", bucketName, err)
+		}
+	}
+
+	return &cosispec.DriverCreateBucketResponse{
+		BucketId: bucketID(conn, bucketName),
+		BucketInfo: &cosispec.Protocol{
+			Type: &cosispec.Protocol_S3{S3: &cosispec.S3{
+				Region:           minioClient.Region(),
+				SignatureVersion: cosispec.S3SignatureVersion_S3V4,
+			}},
+		},
+	}, nil
+}
+
+// DriverDeleteBucket deletes a bucket. Buckets that no longer exist are reported as deleted, while buckets
+// that still hold objects or were not created by the driver are kept.
+func (d *Driver) DriverDeleteBucket(ctx context.Context, req *cosispec.DriverDeleteBucketRequest) (*cosispec.DriverDeleteBucketResponse, error) {
+	conn, bucketName, err := parseBucketID(req.GetBucketId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger := log.FromContext(ctx).WithValues("bucket", bucketName)
+
+	minioClient, err := d.newClient(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	existingTags, err := bucketTags(ctx, minioClient, bucketName)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchBucket" {
+		return nil, status.Errorf(codes.Internal, "failed to get tags of bucket %s: %v", bucketName, err)
+	}
+	if err == nil && existingTags[miniov1beta1.OwnerTag] != d.owner() {
+		return nil, status.Errorf(codes.FailedPrecondition, "bucket %s was not created by the driver", bucketName)
+	}
+
+	logger.Info("Deleting bucket")
+	if err := minioClient.S3.RemoveBucket(ctx, bucketName); err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "NoSuchBucket":
+		case "BucketNotEmpty":
+			return nil, status.Errorf(codes.FailedPrecondition, "bucket %s is not empty", bucketName)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to delete bucket %s: %v", bucketName, err)
+		}
+	}
+	return &cosispec.DriverDeleteBucketResponse{}, nil
+}
+
+// DriverGrantBucketAccess creates a MinIO user named after the account, together with a policy of the same
+// name granting access to the bucket. Both carry the owner marker of the driver, and users or policies
+// with that name created otherwise are reported as already existing. Granting access to an existing
+// account generates a new secret key.
+func (d *Driver) DriverGrantBucketAccess(ctx context.Context, req *cosispec.DriverGrantBucketAccessRequest) (*cosispec.DriverGrantBucketAccessResponse, error) {
+	conn, bucketName, err := parseBucketID(req.GetBucketId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetAuthenticationType() != cosispec.AuthenticationType_Key {
+		return nil, status.Errorf(codes.InvalidArgument, "authentication type %s is not supported, only Key is", req.GetAuthenticationType())
+	}
+	accountName := req.GetName()
+	if accountName == "" {
+		return nil, status.Error(codes.InvalidArgument, "account name is required")
+	}
+	access := miniov1beta1.BucketAccess(req.GetParameters()[ParameterAccess])
+	document, err := minioclient.BucketPolicy(bucketName, access)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	document, err = withPolicyOwner(document, d.owner())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	logger := log.FromContext(ctx).WithValues("bucket", bucketName, "account", accountName)
+
+	minioClient, err := d.newClient(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never hand out the credentials of users or widen the policies the driver did not create
+	owner, userExists, err := userOwner(ctx, minioClient, accountName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user %s: %v", accountName, err)
+	}
+	if userExists && owner != d.owner() {
+		return nil, status.Errorf(codes.AlreadyExists, "user %s exists and was not created by the driver", accountName)
+	}
+	owner, policyExists, err := policyOwner(ctx, minioClient, accountName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get policy %s: %v", accountName, err)
+	}
+	if policyExists && owner != d.owner() {
+		return nil, status.Errorf(codes.AlreadyExists, "policy %s exists and was not created by the driver", accountName)
+	}
+
+	secretKey, err := minioclient.GenerateSecretKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.Info("Granting bucket access", "access", access)
+	if err := minioClient.Admin.AddCannedPolicy(ctx, accountName, document); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add policy %s: %v", accountName, err)
+	}
+	if err := minioClient.Admin.AddUser(ctx, accountName, secretKey); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add user %s: %v", accountName, err)
+	}
+	if !userExists {
+		// MinIO users have no metadata, the owner is recorded as membership in the owner group
+		err := minioClient.Admin.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+			Group:   miniov1beta1.OwnerGroupPrefix + d.owner(),
+			Members: []string{accountName},
+		})
+		if err != nil {
+			// A user without owner would be foreign to the retry, so it is removed again
+			if err := minioClient.Admin.RemoveUser(ctx, accountName); err != nil {
+				logger.Error(err, "Failed to remove user without owner")
+			}
+			return nil, status.Errorf(codes.Internal, "failed to record the owner of user %s: %v", accountName, err)
+		}
+	}
+	if err := minioClient.Admin.SetPolicy(ctx, accountName, accountName, false); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to attach policy to user %s: %v", accountName, err)
+	}
+
+	endpoint, useSSL := minioClient.Endpoint()
+	scheme := "http"
+	if useSSL {
+		scheme = "https"
+	}
+	return &cosispec.DriverGrantBucketAccessResponse{
+		AccountId: accountName,
+		Credentials: map[string]*cosispec.CredentialDetails{
+			credentialsS3Key: {Secrets: map[string]string{
+				accessKeyIDKey:         accountName,
+				accessSecretKeyKey:     secretKey,
+				credentialsEndpointKey: scheme + "://" + endpoint,
+				credentialsRegionKey:   minioClient.Region(),
+			}},
+		},
+	}, nil
+}
+
+// DriverRevokeBucketAccess removes the user and policy of an account. Accounts that no longer exist are
+// reported as revoked, users and policies the driver did not create are kept.
+func (d *Driver) DriverRevokeBucketAccess(ctx context.Context, req *cosispec.DriverRevokeBucketAccessRequest) (*cosispec.DriverRevokeBucketAccessResponse, error) {
+	conn, bucketName, err := parseBucketID(req.GetBucketId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	accountName := req.GetAccountId()
+	if accountName == "" {
+		return nil, status.Error(codes.InvalidArgument, "account ID is required")
+	}
+	logger := log.FromContext(ctx).WithValues("bucket", bucketName, "account", accountName)
+
+	minioClient, err := d.newClient(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Revoking bucket access")
+	owner, exists, err := userOwner(ctx, minioClient, accountName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user %s: %v", accountName, err)
+	}
+	if exists && owner == d.owner() {
+		if err := minioClient.Admin.RemoveUser(ctx, accountName); err != nil && madmin.ToErrorResponse(err).Code != "XMinioAdminNoSuchUser" {
+			return nil, status.Errorf(codes.Internal, "failed to remove user %s: %v", accountName, err)
+		}
+	}
+	owner, exists, err = policyOwner(ctx, minioClient, accountName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get policy %s: %v", accountName, err)
+	}
+	if exists && owner == d.owner() {
+		if err := minioClient.Admin.RemoveCannedPolicy(ctx, accountName); err != nil && madmin.ToErrorResponse(err).Code != "XMinioAdminNoSuchPolicy" {
+			return nil, status.Errorf(codes.Internal, "failed to remove policy %s: %v", accountName, err)
+		}
+	}
+	return &cosispec.DriverRevokeBucketAccessResponse{}, nil
+}
+
+// newClient creates a MinIO client for a connection. BucketClasses are created by cluster administrators,
+// so aliases are used without reference grants like for cluster-scoped resources.
+func (d *Driver) newClient(ctx context.Context, conn miniov1beta1.MinIOConnection) (*minioclient.Client, error) {
+	minioClient, err := minioclient.NewClusterClient(ctx, d.Client, conn)
+	if err != nil {
+		var notPermitted *minioclient.ReferenceNotPermittedError
+		if errors.As(err, &notPermitted) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Errorf(codes.Unavailable, "failed to create MinIO client: %v", err)
+	}
+	return minioClient, nil
+}
+
+// bucketClassConnection returns the connection selected by the parameters of a BucketClass
+func bucketClassConnection(parameters map[string]string) (miniov1beta1.MinIOConnection, error) {
+	clusterAlias := parameters[ParameterClusterAlias]
+	alias := parameters[ParameterAlias]
+	aliasNamespace := parameters[ParameterAliasNamespace]
+
+	switch {
+	case clusterAlias != "" && alias != "":
+		return miniov1beta1.MinIOConnection{}, fmt.Errorf("only one of the %s and %s parameters may be set", ParameterClusterAlias, ParameterAlias)
+	case clusterAlias != "":
+		return miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: clusterAlias}}, nil
+	case alias != "" && aliasNamespace != "":
+		return miniov1beta1.MinIOConnection{AliasRef: &miniov1beta1.AliasReference{Name: alias, Namespace: &aliasNamespace}}, nil
+	case alias != "":
+		return miniov1beta1.MinIOConnection{}, fmt.Errorf("the %s parameter is required with the %s parameter", ParameterAliasNamespace, ParameterAlias)
+	default:
+		return miniov1beta1.MinIOConnection{}, fmt.Errorf("one of the %s and %s parameters must be set", ParameterClusterAlias, ParameterAlias)
+	}
+}
+
+// bucketID returns the ID of a bucket. Deletions and access grants only receive the bucket ID, so it
+// encodes the connection the bucket was created with.
+func bucketID(conn miniov1beta1.MinIOConnection, bucketName string) string {
+	if conn.ClusterAliasRef != nil {
+		return strings.Join([]string{bucketIDClusterAlias, conn.ClusterAliasRef.Name, bucketName}, "/")
+	}
+	return strings.Join([]string{bucketIDAlias, *conn.AliasRef.Namespace, conn.AliasRef.Name, bucketName}, "/")
+}
+
+// parseBucketID returns the connection and bucket name encoded in a bucket ID
+func parseBucketID(id string) (miniov1beta1.MinIOConnection, string, error) {
+	parts := strings.Split(id, "/")
+	switch {
+	case len(parts) == 3 && parts[0] == bucketIDClusterAlias && parts[1] != "" && parts[2] != "":
+		return miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: parts[1]}}, parts[2], nil
+	case len(parts) == 4 && parts[0] == bucketIDAlias && parts[1] != "" && parts[2] != "" && parts[3] != "":
+		namespace := parts[1]
+		return miniov1beta1.MinIOConnection{AliasRef: &miniov1beta1.AliasReference{Name: parts[2], Namespace: &namespace}}, parts[3], nil
+	default:
+		return miniov1beta1.MinIOConnection{}, "", fmt.Errorf("invalid bucket ID %q", id)
+	}
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosi
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	cosispec "sigs.k8s.io/container-object-storage-interface-spec"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+var _ = Describe("COSI Driver", func() {
+	var (
+		ctx         context.Context
+		minio       *fakeMinIO
+		identity    cosispec.IdentityClient
+		provisioner cosispec.ProvisionerClient
+	)
+
+	clusterAliasParameters := map[string]string{ParameterClusterAlias: "minio"}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		minio = newFakeMinIO()
+		server := httptest.NewServer(minio)
+		DeferCleanup(server.Close)
+
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+		region := "eu-central-1"
+		credentials := map[string][]byte{
+			miniov1beta1.DefaultAccessKeyIDKey:     []byte("admin"),
+			miniov1beta1.DefaultSecretAccessKeyKey: []byte("password"),
+		}
+		objects := []client.Object{
+			&miniov1beta1.ClusterAlias{
+				ObjectMeta: metav1.ObjectMeta{Name: "minio"},
+				Spec: miniov1beta1.ClusterAliasSpec{
+					URL:       server.URL,
+					Region:    &region,
+					SecretRef: miniov1beta1.ClusterSecretReference{Name: "minio-credentials"},
+				},
+				Status: miniov1beta1.AliasStatus{Ready: true},
+			},
+			&miniov1beta1.Alias{
+				ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "team-a"},
+				Spec: miniov1beta1.AliasSpec{
+					URL:       server.URL,
+					SecretRef: miniov1beta1.SecretReference{Name: "minio-credentials"},
+				},
+				Status: miniov1beta1.AliasStatus{Ready: true},
+			},
+			&miniov1beta1.Alias{
+				ObjectMeta: metav1.ObjectMeta{Name: "unhealthy", Namespace: "team-a"},
+				Spec: miniov1beta1.AliasSpec{
+					URL:       server.URL,
+					SecretRef: miniov1beta1.SecretReference{Name: "minio-credentials"},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "minio-credentials", Namespace: minioclient.OperatorNamespace},
+				Data:       credentials,
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "minio-credentials", Namespace: "team-a"},
+				Data:       credentials,
+			},
+		}
+		driver := &Driver{
+			Name:   DefaultDriverName,
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		}
+
+		// Serve the driver like for the provisioner sidecar
+		dir, err := os.MkdirTemp("", "cosi")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+		socket := filepath.Join(dir, "cosi.sock")
+
+		serveCtx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() {
+			done <- Serve(serveCtx, "unix://"+socket, driver)
+		}()
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(conn.Close)
+		identity = cosispec.NewIdentityClient(conn)
+		provisioner = cosispec.NewProvisionerClient(conn)
+	})
+
+	createBucket := func(name string, parameters map[string]string) string {
+		response, err := provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{Name: name, Parameters: parameters})
+		Expect(err).NotTo(HaveOccurred())
+		return response.BucketId
+	}
+
+	It("should return the driver name", func() {
+		response, err := identity.DriverGetInfo(ctx, &cosispec.DriverGetInfoRequest{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Name).To(Equal(DefaultDriverName))
+	})
+
+	Context("When creating buckets", func() {
+		It("should create a bucket on the server of the cluster alias", func() {
+			response, err := provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{
+				Name:       "bc-1",
+				Parameters: map[string]string{ParameterClusterAlias: "minio", ParameterVersioning: "true"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.BucketId).To(Equal("clusteralias/minio/bc-1"))
+			Expect(response.BucketInfo.GetS3().GetRegion()).To(Equal("eu-central-1"))
+			Expect(response.BucketInfo.GetS3().GetSignatureVersion()).To(Equal(cosispec.S3SignatureVersion_S3V4))
+			buckets, _, _ := minio.snapshot()
+			Expect(buckets).To(HaveKey("bc-1"))
+			Expect(buckets["bc-1"].versioning).To(BeTrue())
+			Expect(string(buckets["bc-1"].tags)).To(ContainSubstring("cosi.mc-controller.mxcd.de"))
+		})
+
+		It("should report existing buckets as created", func() {
+			Expect(createBucket("bc-1", clusterAliasParameters)).To(Equal("clusteralias/minio/bc-1"))
+			Expect(createBucket("bc-1", clusterAliasParameters)).To(Equal("clusteralias/minio/bc-1"))
+			buckets, _, _ := minio.snapshot()
+			Expect(buckets).To(HaveLen(1))
+		})
+
+		It("should refuse buckets the driver did not create", func() {
+			minio.create("bc-1")
+			_, err := provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{Name: "bc-1", Parameters: clusterAliasParameters})
+			Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+		})
+
+		It("should refuse buckets created with different parameters", func() {
+			createBucket("bc-1", clusterAliasParameters)
+			_, err := provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{
+				Name:       "bc-1",
+				Parameters: map[string]string{ParameterClusterAlias: "minio", ParameterVersioning: "true"},
+			})
+			Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+		})
+
+		It("should create a bucket with a namespaced alias", func() {
+			id := createBucket("bc-1", map[string]string{ParameterAlias: "minio", ParameterAliasNamespace: "team-a"})
+			Expect(id).To(Equal("alias/team-a/minio/bc-1"))
+			buckets, _, _ := minio.snapshot()
+			Expect(buckets).To(HaveKey("bc-1"))
+		})
+
+		It("should reject bucket classes without a connection", func() {
+			_, err := provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{Name: "bc-1"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{
+				Name:       "bc-1",
+				Parameters: map[string]string{ParameterAlias: "minio"},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+
+		It("should report aliases that are not ready as unavailable", func() {
+			_, err := provisioner.DriverCreateBucket(ctx, &cosispec.DriverCreateBucketRequest{
+				Name:       "bc-1",
+				Parameters: map[string]string{ParameterAlias: "unhealthy", ParameterAliasNamespace: "team-a"},
+			})
+			Expect(status.Code(err)).To(Equal(codes.Unavailable))
+		})
+	})
+
+	Context("When deleting buckets", func() {
+		It("should delete the bucket and report missing buckets as deleted", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+
+			_, err := provisioner.DriverDeleteBucket(ctx, &cosispec.DriverDeleteBucketRequest{BucketId: id})
+			Expect(err).NotTo(HaveOccurred())
+			buckets, _, _ := minio.snapshot()
+			Expect(buckets).To(BeEmpty())
+
+			_, err = provisioner.DriverDeleteBucket(ctx, &cosispec.DriverDeleteBucketRequest{BucketId: id})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should keep buckets holding objects", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+			minio.putObjects("bc-1", 1)
+
+			_, err := provisioner.DriverDeleteBucket(ctx, &cosispec.DriverDeleteBucketRequest{BucketId: id})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			buckets, _, _ := minio.snapshot()
+			Expect(buckets).To(HaveKey("bc-1"))
+		})
+
+		It("should keep buckets the driver did not create", func() {
+			minio.create("bc-1")
+			_, err := provisioner.DriverDeleteBucket(ctx, &cosispec.DriverDeleteBucketRequest{BucketId: "clusteralias/minio/bc-1"})
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			buckets, _, _ := minio.snapshot()
+			Expect(buckets).To(HaveKey("bc-1"))
+		})
+
+		It("should reject invalid bucket IDs", func() {
+			_, err := provisioner.DriverDeleteBucket(ctx, &cosispec.DriverDeleteBucketRequest{BucketId: "bc-1"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+	})
+
+	Context("When granting bucket access", func() {
+		It("should create a user with a policy restricted to the bucket", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+
+			response, err := provisioner.DriverGrantBucketAccess(ctx, &cosispec.DriverGrantBucketAccessRequest{
+				BucketId:           id,
+				Name:               "ba-1",
+				AuthenticationType: cosispec.AuthenticationType_Key,
+				Parameters:         map[string]string{ParameterAccess: string(miniov1beta1.BucketAccessReadOnly)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.AccountId).To(Equal("ba-1"))
+
+			secrets := response.Credentials["s3"].GetSecrets()
+			Expect(secrets).To(HaveKeyWithValue("accessKeyID", "ba-1"))
+			Expect(secrets["accessSecretKey"]).To(HaveLen(minioclient.SecretKeyLength))
+			Expect(secrets["endpoint"]).To(HavePrefix("http://127.0.0.1:"))
+			Expect(secrets).To(HaveKeyWithValue("region", "eu-central-1"))
+
+			_, users, policies := minio.snapshot()
+			Expect(users).To(HaveKeyWithValue("ba-1", "ba-1"))
+			Expect(policies["ba-1"]).To(ContainSubstring("arn:aws:s3:::bc-1"))
+			Expect(policies["ba-1"]).NotTo(ContainSubstring("s3:PutObject"))
+			Expect(policies["ba-1"]).To(ContainSubstring(miniov1beta1.OwnerPolicyIDPrefix + "cosi.mc-controller.mxcd.de"))
+		})
+
+		It("should grant access to an account again", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+			request := &cosispec.DriverGrantBucketAccessRequest{
+				BucketId:           id,
+				Name:               "ba-1",
+				AuthenticationType: cosispec.AuthenticationType_Key,
+			}
+			_, err := provisioner.DriverGrantBucketAccess(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = provisioner.DriverGrantBucketAccess(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should refuse users and policies the driver did not create", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+			minio.create("ba-1")
+
+			_, err := provisioner.DriverGrantBucketAccess(ctx, &cosispec.DriverGrantBucketAccessRequest{
+				BucketId:           id,
+				Name:               "ba-1",
+				AuthenticationType: cosispec.AuthenticationType_Key,
+			})
+			Expect(status.Code(err)).To(Equal(codes.AlreadyExists))
+			_, _, policies := minio.snapshot()
+			Expect(policies["ba-1"]).NotTo(ContainSubstring("arn:aws:s3:::bc-1"))
+		})
+
+		It("should reject IAM authentication", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+
+			_, err := provisioner.DriverGrantBucketAccess(ctx, &cosispec.DriverGrantBucketAccessRequest{
+				BucketId:           id,
+				Name:               "ba-1",
+				AuthenticationType: cosispec.AuthenticationType_IAM,
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			_, users, _ := minio.snapshot()
+			Expect(users).To(BeEmpty())
+		})
+
+		It("should reject unknown access levels", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+
+			_, err := provisioner.DriverGrantBucketAccess(ctx, &cosispec.DriverGrantBucketAccessRequest{
+				BucketId:           id,
+				Name:               "ba-1",
+				AuthenticationType: cosispec.AuthenticationType_Key,
+				Parameters:         map[string]string{ParameterAccess: "WriteOnly"},
+			})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+	})
+
+	Context("When revoking bucket access", func() {
+		It("should remove the user and policy and report missing accounts as revoked", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+			_, err := provisioner.DriverGrantBucketAccess(ctx, &cosispec.DriverGrantBucketAccessRequest{
+				BucketId:           id,
+				Name:               "ba-1",
+				AuthenticationType: cosispec.AuthenticationType_Key,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			request := &cosispec.DriverRevokeBucketAccessRequest{BucketId: id, AccountId: "ba-1"}
+			_, err = provisioner.DriverRevokeBucketAccess(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			_, users, policies := minio.snapshot()
+			Expect(users).To(BeEmpty())
+			Expect(policies).To(BeEmpty())
+
+			_, err = provisioner.DriverRevokeBucketAccess(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should keep users and policies the driver did not create", func() {
+			id := createBucket("bc-1", clusterAliasParameters)
+			minio.create("ba-1")
+
+			_, err := provisioner.DriverRevokeBucketAccess(ctx, &cosispec.DriverRevokeBucketAccessRequest{BucketId: id, AccountId: "ba-1"})
+			Expect(err).NotTo(HaveOccurred())
+			_, users, policies := minio.snapshot()
+			Expect(users).To(HaveKey("ba-1"))
+			Expect(policies).To(HaveKey("ba-1"))
+		})
+	})
+})
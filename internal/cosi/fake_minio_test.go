@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosi
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// fakeBucket is a bucket of the fake MinIO server
+type fakeBucket struct {
+	versioning bool
+	objects    int
+	tags       []byte
+}
+
+// fakeMinIO implements the S3 and admin API calls of the driver in memory
+type fakeMinIO struct {
+	mu       sync.Mutex
+	buckets  map[string]*fakeBucket
+	users    map[string]string
+	groups   map[string][]string
+	policies map[string]string
+}
+
+func newFakeMinIO() *fakeMinIO {
+	return &fakeMinIO{
+		buckets:  map[string]*fakeBucket{},
+		users:    map[string]string{},
+		groups:   map[string][]string{},
+		policies: map[string]string{},
+	}
+}
+
+// create adds a bucket, user and policy named name that were not created by the driver
+func (f *fakeMinIO) create(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.buckets[name] = &fakeBucket{}
+	f.users[name] = ""
+	f.policies[name] = `{"Version":"2012-10-17","Statement":[]}`
+}
+
+// snapshot returns copies of the buckets, users with their policies and policy documents
+func (f *fakeMinIO) snapshot() (map[string]fakeBucket, map[string]string, map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	buckets := map[string]fakeBucket{}
+	for name, bucket := range f.buckets {
+		buckets[name] = *bucket
+	}
+	return buckets, maps.Clone(f.users), maps.Clone(f.policies)
+}
+
+// putObjects sets the number of objects a bucket holds
+func (f *fakeMinIO) putObjects(bucket string, objects int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.buckets[bucket].objects = objects
+}
+
+func (f *fakeMinIO) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, "/minio/admin/v3/") {
+		f.serveAdmin(w, r, strings.TrimPrefix(r.URL.Path, "/minio/admin/v3/"))
+		return
+	}
+	f.serveS3(w, r, strings.Trim(r.URL.Path, "/"))
+}
+
+func (f *fakeMinIO) serveS3(w http.ResponseWriter, r *http.Request, name string) {
+	query := r.URL.Query()
+	bucket, exists := f.buckets[name]
+
+	switch {
+	case r.Method == http.MethodGet && query.Has("location"):
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, `<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`)
+	case r.Method == http.MethodHead && exists:
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodHead:
+		w.WriteHeader(http.StatusNotFound)
+	case query.Has("tagging") && !exists:
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", name)
+	case r.Method == http.MethodGet && query.Has("tagging") && bucket.tags == nil:
+		writeS3Error(w, http.StatusNotFound, "NoSuchTagSet", name)
+	case r.Method == http.MethodGet && query.Has("tagging"):
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write(bucket.tags)
+	case r.Method == http.MethodPut && query.Has("tagging"):
+		bucket.tags, _ = io.ReadAll(r.Body)
+	case r.Method == http.MethodPut && query.Has("versioning") && exists:
+		bucket.versioning = true
+	case r.Method == http.MethodPut && !exists:
+		f.buckets[name] = &fakeBucket{}
+	case r.Method == http.MethodPut:
+		writeS3Error(w, http.StatusConflict, "BucketAlreadyOwnedByYou", name)
+	case r.Method == http.MethodDelete && !exists:
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", name)
+	case r.Method == http.MethodDelete && bucket.objects > 0:
+		writeS3Error(w, http.StatusConflict, "BucketNotEmpty", name)
+	case r.Method == http.MethodDelete:
+		delete(f.buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", name)
+	}
+}
+
+func (f *fakeMinIO) serveAdmin(w http.ResponseWriter, r *http.Request, api string) {
+	query := r.URL.Query()
+
+	switch api {
+	case "add-user":
+		// The secret key is encrypted with the admin credentials and not needed by the tests
+		if _, ok := f.users[query.Get("accessKey")]; !ok {
+			f.users[query.Get("accessKey")] = ""
+		}
+	case "user-info":
+		if _, ok := f.users[query.Get("accessKey")]; !ok {
+			writeAdminError(w, "XMinioAdminNoSuchUser")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"memberOf": f.groups[query.Get("accessKey")]})
+	case "update-group-members":
+		var update struct {
+			Group   string   `json:"group"`
+			Members []string `json:"members"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		for _, member := range update.Members {
+			f.groups[member] = append(f.groups[member], update.Group)
+		}
+	case "remove-user":
+		if _, ok := f.users[query.Get("accessKey")]; !ok {
+			writeAdminError(w, "XMinioAdminNoSuchUser")
+			return
+		}
+		delete(f.users, query.Get("accessKey"))
+		delete(f.groups, query.Get("accessKey"))
+	case "info-canned-policy":
+		if _, ok := f.policies[query.Get("name")]; !ok {
+			writeAdminError(w, "XMinioAdminNoSuchPolicy")
+			return
+		}
+		_, _ = io.WriteString(w, f.policies[query.Get("name")])
+	case "add-canned-policy":
+		document, _ := io.ReadAll(r.Body)
+		f.policies[query.Get("name")] = string(document)
+	case "remove-canned-policy":
+		if _, ok := f.policies[query.Get("name")]; !ok {
+			writeAdminError(w, "XMinioAdminNoSuchPolicy")
+			return
+		}
+		delete(f.policies, query.Get("name"))
+	case "set-user-or-group-policy":
+		if _, ok := f.users[query.Get("userOrGroup")]; !ok {
+			writeAdminError(w, "XMinioAdminNoSuchUser")
+			return
+		}
+		f.users[query.Get("userOrGroup")] = query.Get("policyName")
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func writeS3Error(w http.ResponseWriter, statusCode int, code, bucket string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(statusCode)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName    xml.Name `xml:"Error"`
+		Code       string   `xml:"Code"`
+		Message    string   `xml:"Message"`
+		BucketName string   `xml:"BucketName"`
+	}{Code: code, Message: fmt.Sprintf("%s: %s", code, bucket), BucketName: bucket})
+}
+
+func writeAdminError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]string{"Code": code, "Message": code})
+}
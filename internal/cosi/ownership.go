@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// parametersTag is the bucket tag holding the digest of the BucketClass parameters a bucket was created with
+const parametersTag = "mc-controller.mxcd.de/cosi-parameters"
+
+// owner returns the owner marker stamped into the buckets, users and policies the driver creates. The
+// driver has no resource of its own, so the marker is derived from its name.
+func (d *Driver) owner() string {
+	return "cosi." + d.Name
+}
+
+// ownerTags returns the tags of a bucket created by the driver with parameters
+func (d *Driver) ownerTags(parameters map[string]string) (*tags.Tags, error) {
+	return tags.MapToBucketTags(map[string]string{
+		miniov1beta1.OwnerTag: d.owner(),
+		parametersTag:         parametersDigest(parameters),
+	})
+}
+
+// parametersDigest returns the SHA-256 digest of BucketClass parameters. Bucket tags cannot hold the
+// separators needed to store the parameters themselves.
+func parametersDigest(parameters map[string]string) string {
+	keys := make([]string, 0, len(parameters))
+	for key := range parameters {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		// Length prefixes keep the digests of different splits of the same bytes apart
+		fmt.Fprintf(hash, "%d:%s%d:%s", len(key), key, len(parameters[key]), parameters[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// bucketTags returns the tags of a bucket, which are empty if none are set
+func bucketTags(ctx context.Context, minioClient *minioclient.Client, bucketName string) (map[string]string, error) {
+	bucketTags, err := minioClient.S3.GetBucketTagging(ctx, bucketName)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchTagSet" {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	return bucketTags.ToMap(), nil
+}
+
+// userOwner returns the owner recorded in the owner group memberships of a user, and whether the user exists
+func userOwner(ctx context.Context, minioClient *minioclient.Client, username string) (string, bool, error) {
+	userInfo, err := minioClient.Admin.GetUserInfo(ctx, username)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchUser" {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	for _, group := range userInfo.MemberOf {
+		if owner, ok := strings.CutPrefix(group, miniov1beta1.OwnerGroupPrefix); ok {
+			return owner, true, nil
+		}
+	}
+	return "", true, nil
+}
+
+// policyOwner returns the owner recorded in the ID of a canned policy document, and whether the policy exists
+func policyOwner(ctx context.Context, minioClient *minioclient.Client, policyName string) (string, bool, error) {
+	document, err := minioClient.Admin.InfoCannedPolicy(ctx, policyName)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioAdminNoSuchPolicy" {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	var policy struct {
+		ID string `json:"ID"`
+	}
+	if err := json.Unmarshal(document, &policy); err != nil {
+		return "", true, nil
+	}
+	if owner, ok := strings.CutPrefix(policy.ID, miniov1beta1.OwnerPolicyIDPrefix); ok {
+		return owner, true, nil
+	}
+	return "", true, nil
+}
+
+// withPolicyOwner sets the ID of a canned policy document to the owner marker owner
+func withPolicyOwner(document []byte, owner string) ([]byte, error) {
+	var policy map[string]interface{}
+	if err := json.Unmarshal(document, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy document: %w", err)
+	}
+	policy["ID"] = miniov1beta1.OwnerPolicyIDPrefix + owner
+	return json.Marshal(policy)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+
+	"google.golang.org/grpc"
+	cosispec "sigs.k8s.io/container-object-storage-interface-spec"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultEndpoint is the socket the provisioner sidecar connects to
+const DefaultEndpoint = "unix:///var/lib/cosi/cosi.sock"
+
+// Serve serves the identity and provisioner services of driver on endpoint until ctx is done. Endpoints are
+// unix:// sockets, which are replaced if they exist, or tcp:// addresses.
+func Serve(ctx context.Context, endpoint string, driver *Driver) error {
+	listener, err := listen(endpoint)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	cosispec.RegisterIdentityServer(server, driver)
+	cosispec.RegisterProvisionerServer(server, driver)
+
+	go func() {
+		<-ctx.Done()
+		server.GracefulStop()
+	}()
+
+	log.FromContext(ctx).Info("Serving COSI driver", "name", driver.Name, "endpoint", endpoint)
+	if err := server.Serve(listener); err != nil {
+		return fmt.Errorf("failed to serve COSI driver: %w", err)
+	}
+	return nil
+}
+
+// listen opens the listener of an endpoint
+func listen(endpoint string) (net.Listener, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint %s: %w", endpoint, err)
+	}
+
+	var address string
+	switch u.Scheme {
+	case "unix":
+		address = u.Path
+		// A socket left behind by a previous driver would make listening fail
+		if err := os.Remove(address); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove socket %s: %w", address, err)
+		}
+	case "tcp":
+		address = u.Host
+	default:
+		return nil, fmt.Errorf("unsupported endpoint scheme %q, expected unix or tcp", u.Scheme)
+	}
+
+	listener, err := net.Listen(u.Scheme, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", endpoint, err)
+	}
+	return listener, nil
+}
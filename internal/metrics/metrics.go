@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics defines the Prometheus collectors exposed by mc-controller.
+// All collectors are registered with the controller-runtime metrics registry,
+// so they are served on the manager's metrics endpoint.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "mc_controller"
+
+	// OutcomeSuccess labels a MinIO API call that succeeded
+	OutcomeSuccess = "success"
+	// OutcomeError labels a MinIO API call that failed
+	OutcomeError = "error"
+)
+
+var (
+	// MinIORequestsTotal counts MinIO API calls by alias, operation and outcome
+	MinIORequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "minio",
+			Name:      "requests_total",
+			Help:      "Total number of MinIO API calls made by the controller.",
+		},
+		[]string{"alias", "operation", "outcome"},
+	)
+
+	// MinIORequestDuration observes the latency of MinIO API calls
+	MinIORequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "minio",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of MinIO API calls made by the controller.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"alias", "operation", "outcome"},
+	)
+
+	// AliasHealthy reports whether the last health check of an Alias succeeded
+	AliasHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "alias_healthy",
+			Help:      "Whether the last health check of the alias succeeded (1) or failed (0).",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// AliasInfo exposes the MinIO server version behind an Alias as a label
+	AliasInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "alias_info",
+			Help:      "Information about the MinIO server behind the alias. Always 1.",
+		},
+		[]string{"namespace", "name", "version"},
+	)
+
+	// DriftCorrectionsTotal counts corrections of out-of-band changes in MinIO
+	DriftCorrectionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "drift_corrections_total",
+			Help:      "Total number of times the controller corrected drift between a resource and MinIO.",
+		},
+		[]string{"kind"},
+	)
+
+	// DriftDetectedTotal counts detected differences between a resource and MinIO
+	DriftDetectedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "drift_detected_total",
+			Help:      "Total number of times the controller detected drift between a resource and MinIO.",
+		},
+		[]string{"kind"},
+	)
+
+	// BucketUsageBytes reports the size of a bucket as last measured by MinIO
+	BucketUsageBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "bucket_usage_bytes",
+			Help:      "Total size of the objects in the bucket, as reported by the MinIO data usage scanner.",
+		},
+		[]string{"namespace", "name", "bucket"},
+	)
+
+	// BucketObjects reports the number of objects in a bucket as last measured by MinIO
+	BucketObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "bucket_objects",
+			Help:      "Number of objects in the bucket, as reported by the MinIO data usage scanner.",
+		},
+		[]string{"namespace", "name", "bucket"},
+	)
+
+	// BucketQuotaBytes reports the hard quota configured on a bucket
+	BucketQuotaBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "bucket_quota_bytes",
+			Help:      "Hard quota configured on the bucket in MinIO. 0 means no quota.",
+		},
+		[]string{"namespace", "name", "bucket"},
+	)
+
+	// ReplicationPendingObjects reports the replication backlog of a BucketReplication in objects
+	ReplicationPendingObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "replication_pending_objects",
+			Help:      "Number of objects waiting for replication to the destination bucket.",
+		},
+		[]string{"namespace", "name", "bucket"},
+	)
+
+	// ReplicationPendingBytes reports the replication backlog of a BucketReplication in bytes
+	ReplicationPendingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "replication_pending_bytes",
+			Help:      "Size of the objects waiting for replication to the destination bucket.",
+		},
+		[]string{"namespace", "name", "bucket"},
+	)
+
+	// ReplicationFailedObjects reports the objects a BucketReplication failed to replicate
+	ReplicationFailedObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "replication_failed_objects",
+			Help:      "Number of objects that failed to replicate to the destination bucket.",
+		},
+		[]string{"namespace", "name", "bucket"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		MinIORequestsTotal,
+		MinIORequestDuration,
+		AliasHealthy,
+		AliasInfo,
+		DriftCorrectionsTotal,
+		DriftDetectedTotal,
+		BucketUsageBytes,
+		BucketObjects,
+		BucketQuotaBytes,
+		ReplicationPendingObjects,
+		ReplicationPendingBytes,
+		ReplicationFailedObjects,
+	)
+}
+
+// SetAliasHealth records the health and server version of an Alias
+func SetAliasHealth(namespace, name string, healthy bool, version string) {
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	AliasHealthy.WithLabelValues(namespace, name).Set(value)
+
+	if version != "" {
+		AliasInfo.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+		AliasInfo.WithLabelValues(namespace, name, version).Set(1)
+	}
+}
+
+// DeleteAlias removes all series of a deleted Alias
+func DeleteAlias(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	AliasHealthy.DeletePartialMatch(labels)
+	AliasInfo.DeletePartialMatch(labels)
+}
+
+// SetBucketUsage records the usage and quota of a bucket
+func SetBucketUsage(namespace, name, bucket string, sizeBytes, objects, quotaBytes uint64) {
+	BucketUsageBytes.WithLabelValues(namespace, name, bucket).Set(float64(sizeBytes))
+	BucketObjects.WithLabelValues(namespace, name, bucket).Set(float64(objects))
+	BucketQuotaBytes.WithLabelValues(namespace, name, bucket).Set(float64(quotaBytes))
+}
+
+// DeleteBucket removes all series of a deleted Bucket resource
+func DeleteBucket(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	BucketUsageBytes.DeletePartialMatch(labels)
+	BucketObjects.DeletePartialMatch(labels)
+	BucketQuotaBytes.DeletePartialMatch(labels)
+}
+
+// SetReplicationBacklog records the replication backlog of a BucketReplication
+func SetReplicationBacklog(namespace, name, bucket string, pendingObjects, pendingBytes, failedObjects uint64) {
+	ReplicationPendingObjects.WithLabelValues(namespace, name, bucket).Set(float64(pendingObjects))
+	ReplicationPendingBytes.WithLabelValues(namespace, name, bucket).Set(float64(pendingBytes))
+	ReplicationFailedObjects.WithLabelValues(namespace, name, bucket).Set(float64(failedObjects))
+}
+
+// DeleteReplication removes all series of a deleted BucketReplication resource
+func DeleteReplication(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	ReplicationPendingObjects.DeletePartialMatch(labels)
+	ReplicationPendingBytes.DeletePartialMatch(labels)
+	ReplicationFailedObjects.DeletePartialMatch(labels)
+}
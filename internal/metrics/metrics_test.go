@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Alias metrics", func() {
+	AfterEach(func() {
+		DeleteAlias("team-a", "minio")
+	})
+
+	It("should record the health and keep only the current version of an alias", func() {
+		SetAliasHealth("team-a", "minio", true, "RELEASE.2024-01-01")
+		Expect(testutil.ToFloat64(AliasHealthy.WithLabelValues("team-a", "minio"))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(AliasInfo.WithLabelValues("team-a", "minio", "RELEASE.2024-01-01"))).To(Equal(1.0))
+
+		SetAliasHealth("team-a", "minio", true, "RELEASE.2024-02-01")
+		Expect(AliasInfo.DeletePartialMatch(prometheus.Labels{"version": "RELEASE.2024-01-01"})).To(BeZero())
+		Expect(testutil.ToFloat64(AliasInfo.WithLabelValues("team-a", "minio", "RELEASE.2024-02-01"))).To(Equal(1.0))
+	})
+
+	It("should keep the version of an alias that fails its health check", func() {
+		SetAliasHealth("team-a", "minio", true, "RELEASE.2024-01-01")
+		SetAliasHealth("team-a", "minio", false, "")
+		Expect(testutil.ToFloat64(AliasHealthy.WithLabelValues("team-a", "minio"))).To(BeZero())
+		Expect(testutil.ToFloat64(AliasInfo.WithLabelValues("team-a", "minio", "RELEASE.2024-01-01"))).To(Equal(1.0))
+	})
+
+	It("should remove all series of a deleted alias", func() {
+		SetAliasHealth("team-a", "minio", true, "RELEASE.2024-01-01")
+		DeleteAlias("team-a", "minio")
+		Expect(testutil.CollectAndCount(AliasHealthy)).To(BeZero())
+		Expect(testutil.CollectAndCount(AliasInfo)).To(BeZero())
+	})
+})
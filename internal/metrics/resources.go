@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// collectTimeout bounds the time spent listing resources during a scrape
+const collectTimeout = 10 * time.Second
+
+// resourceKinds maps the kinds counted by the ResourceCollector to their list types
+var resourceKinds = []struct {
+	kind string
+	list client.ObjectList
+}{
+	{kind: "Alias", list: &miniov1beta1.AliasList{}},
+	{kind: "ClusterAlias", list: &miniov1beta1.ClusterAliasList{}},
+	{kind: "Endpoint", list: &miniov1alpha1.EndpointList{}},
+	{kind: "Bucket", list: &miniov1beta1.BucketList{}},
+	{kind: "BucketReplication", list: &miniov1beta1.BucketReplicationList{}},
+	{kind: "SiteReplication", list: &miniov1beta1.SiteReplicationList{}},
+	{kind: "Tier", list: &miniov1beta1.TierList{}},
+	{kind: "NotificationTarget", list: &miniov1beta1.NotificationTargetList{}},
+	{kind: "ServerConfig", list: &miniov1beta1.ServerConfigList{}},
+	{kind: "IdentityProvider", list: &miniov1beta1.IdentityProviderList{}},
+	{kind: "BucketClaim", list: &miniov1beta1.BucketClaimList{}},
+	{kind: "User", list: &miniov1beta1.UserList{}},
+	{kind: "Policy", list: &miniov1beta1.PolicyList{}},
+	{kind: "PolicyAttachment", list: &miniov1beta1.PolicyAttachmentList{}},
+	{kind: "LifecyclePolicy", list: &miniov1beta1.LifecyclePolicyList{}},
+}
+
+// countReady lists the resources of a list type and counts them by their status.ready field,
+// which all kinds report
+func countReady(ctx context.Context, reader client.Reader, listType client.ObjectList) (ready, notReady float64, err error) {
+	list := listType.DeepCopyObject().(client.ObjectList)
+	if err := reader.List(ctx, list); err != nil {
+		return 0, 0, err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, item := range items {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+		if err != nil {
+			return 0, 0, err
+		}
+		if isReady, _, _ := unstructured.NestedBool(content, "status", "ready"); isReady {
+			ready++
+		} else {
+			notReady++
+		}
+	}
+	return ready, notReady, nil
+}
+
+// ResourceCollector reports the number of Ready and NotReady resources per kind.
+// The counts are computed from the manager's cache on every scrape, so they never
+// go stale when resources are deleted.
+type ResourceCollector struct {
+	reader client.Reader
+	desc   *prometheus.Desc
+}
+
+// NewResourceCollector creates a collector that lists resources through reader
+func NewResourceCollector(reader client.Reader) *ResourceCollector {
+	return &ResourceCollector{
+		reader: reader,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "resources"),
+			"Number of managed resources by kind and readiness.",
+			[]string{"kind", "status"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *ResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implements prometheus.Collector
+func (c *ResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	for _, kind := range resourceKinds {
+		ready, notReady, err := countReady(ctx, c.reader, kind.list)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(c.desc, err)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, ready, kind.kind, "Ready")
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, notReady, kind.kind, "NotReady")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("ResourceCollector", func() {
+	It("should count the resources of every kind by readiness", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(miniov1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&miniov1beta1.Alias{
+				ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "default"},
+				Status:     miniov1beta1.AliasStatus{Ready: true},
+			},
+			&miniov1beta1.Alias{ObjectMeta: metav1.ObjectMeta{Name: "failing", Namespace: "default"}},
+			&miniov1beta1.ClusterAlias{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+				Status:     miniov1beta1.AliasStatus{Ready: true},
+			},
+			&miniov1beta1.Bucket{
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+				Status:     miniov1beta1.BucketStatus{Ready: true},
+			},
+		).Build()
+
+		ready, notReady, err := countReady(ctx, c, &miniov1beta1.AliasList{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect([]float64{ready, notReady}).To(Equal([]float64{1, 1}))
+
+		ready, notReady, err = countReady(ctx, c, &miniov1beta1.ClusterAliasList{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect([]float64{ready, notReady}).To(Equal([]float64{1, 0}))
+
+		ready, notReady, err = countReady(ctx, c, &miniov1beta1.UserList{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect([]float64{ready, notReady}).To(Equal([]float64{0, 0}))
+
+		// Every kind reports a Ready and a NotReady series
+		Expect(testutil.CollectAndCount(NewResourceCollector(c))).To(Equal(2 * len(resourceKinds)))
+	})
+})
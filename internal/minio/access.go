@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// secretKeyAlphabet are the characters of generated secret keys
+const secretKeyAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// SecretKeyLength is the length of generated secret keys
+const SecretKeyLength = 40
+
+// bucketPolicyStatement is a statement of a policy granting access to a bucket
+type bucketPolicyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource []string `json:"Resource"`
+}
+
+// bucketPolicyDocument is a policy granting access to a bucket
+type bucketPolicyDocument struct {
+	Version   string                  `json:"Version"`
+	Statement []bucketPolicyStatement `json:"Statement"`
+}
+
+// BucketPolicy returns the policy document granting access to a single bucket. ReadWrite is granted unless
+// another access is set.
+func BucketPolicy(bucketName string, access miniov1beta1.BucketAccess) ([]byte, error) {
+	bucketResource := "arn:aws:s3:::" + bucketName
+	objectResource := bucketResource + "/*"
+
+	var statements []bucketPolicyStatement
+	switch access {
+	case miniov1beta1.BucketAccessReadOnly:
+		statements = []bucketPolicyStatement{
+			{Effect: "Allow", Action: []string{"s3:GetBucketLocation", "s3:ListBucket"}, Resource: []string{bucketResource}},
+			{Effect: "Allow", Action: []string{"s3:GetObject"}, Resource: []string{objectResource}},
+		}
+	case miniov1beta1.BucketAccessAdmin:
+		statements = []bucketPolicyStatement{
+			{Effect: "Allow", Action: []string{"s3:*"}, Resource: []string{bucketResource, objectResource}},
+		}
+	case "", miniov1beta1.BucketAccessReadWrite:
+		statements = []bucketPolicyStatement{
+			{Effect: "Allow", Action: []string{"s3:GetBucketLocation", "s3:ListBucket", "s3:ListBucketMultipartUploads"}, Resource: []string{bucketResource}},
+			{Effect: "Allow", Action: []string{"s3:AbortMultipartUpload", "s3:DeleteObject", "s3:GetObject", "s3:ListMultipartUploadParts", "s3:PutObject"}, Resource: []string{objectResource}},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported bucket access %q", access)
+	}
+
+	document, err := json.Marshal(bucketPolicyDocument{Version: "2012-10-17", Statement: statements})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode policy: %w", err)
+	}
+	return document, nil
+}
+
+// GenerateSecretKey returns a random secret key
+func GenerateSecretKey() (string, error) {
+	key := make([]byte, SecretKeyLength)
+	for i := range key {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(secretKeyAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate secret key: %w", err)
+		}
+		key[i] = secretKeyAlphabet[n.Int64()]
+	}
+	return string(key), nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Bucket access", func() {
+	It("should only grant access to the given bucket", func() {
+		for _, access := range []miniov1beta1.BucketAccess{
+			miniov1beta1.BucketAccessReadOnly, miniov1beta1.BucketAccessReadWrite, miniov1beta1.BucketAccessAdmin,
+		} {
+			document, err := BucketPolicy("app-data", access)
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := bucketPolicyDocument{}
+			Expect(json.Unmarshal(document, &policy)).To(Succeed())
+			Expect(policy.Statement).NotTo(BeEmpty())
+			for _, statement := range policy.Statement {
+				for _, resource := range statement.Resource {
+					Expect(resource).To(Or(Equal("arn:aws:s3:::app-data"), Equal("arn:aws:s3:::app-data/*")))
+				}
+			}
+		}
+	})
+
+	It("should not grant writes to read-only access", func() {
+		document, err := BucketPolicy("app-data", miniov1beta1.BucketAccessReadOnly)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(document)).NotTo(ContainSubstring("s3:PutObject"))
+		Expect(string(document)).NotTo(ContainSubstring("s3:DeleteObject"))
+
+		_, err = BucketPolicy("app-data", "WriteOnly")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should generate distinct secret keys", func() {
+		first, err := GenerateSecretKey()
+		Expect(err).NotTo(HaveOccurred())
+		second, err := GenerateSecretKey()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(HaveLen(SecretKeyLength))
+		Expect(first).NotTo(Equal(second))
+	})
+})
@@ -26,13 +26,18 @@ import (
 
 	"github.com/minio/madmin-go/v3"
 	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 )
 
+// OperatorNamespace is the namespace the operator runs in. Secrets referenced by ClusterAliases are read from it.
+var OperatorNamespace = "mc-controller-system"
+
 // Client wraps MinIO client and admin client
 type Client struct {
 	S3     *minio.Client
@@ -42,6 +47,8 @@ type Client struct {
 
 // ClientConfig holds configuration for MinIO client
 type ClientConfig struct {
+	// Alias identifies the connection in metrics (namespace/name of the Alias, or the endpoint host)
+	Alias           string
 	Endpoint        string
 	AccessKeyID     string
 	SecretAccessKey string
@@ -49,25 +56,95 @@ type ClientConfig struct {
 	Insecure        bool
 	PathStyle       bool
 	Region          string
+	// CredentialSource selects how credentials are obtained, the static keys are used when empty
+	CredentialSource miniov1beta1.CredentialSource
+	// STSEndpoint is the URL temporary credentials are requested from
+	STSEndpoint string
+	// RoleARN is the role assumed for temporary credentials
+	RoleARN string
+	// TokenPath is the file holding the web identity token
+	TokenPath string
+	// DurationSeconds is the requested lifetime of temporary credentials, the STS default is used when zero
+	DurationSeconds int
 }
 
 // NewClient creates a new MinIO client from connection configuration
-func NewClient(ctx context.Context, k8sClient client.Client, conn miniov1alpha1.MinIOConnection, namespace string) (*Client, error) {
+func NewClient(ctx context.Context, k8sClient client.Client, conn miniov1beta1.MinIOConnection, namespace string) (*Client, error) {
 	config, err := buildClientConfig(ctx, k8sClient, conn, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build client config: %w", err)
 	}
 
+	return newClient(config)
+}
+
+// NewClusterClient creates a new MinIO client for a cluster-scoped resource. Cluster-scoped resources are
+// created by cluster administrators, so an Alias is used from its own namespace and a ClusterAlias regardless
+// of its namespace selector.
+func NewClusterClient(ctx context.Context, k8sClient client.Client, conn miniov1beta1.MinIOConnection) (*Client, error) {
+	var config *ClientConfig
+	var err error
+	switch {
+	case conn.AliasRef != nil && conn.AliasRef.Namespace != nil:
+		config, err = buildClientConfig(ctx, k8sClient, conn, *conn.AliasRef.Namespace)
+	case conn.ClusterAliasRef != nil:
+		config, err = buildClusterAliasClientConfig(ctx, k8sClient, conn.ClusterAliasRef.Name, "")
+	default:
+		err = fmt.Errorf("cluster-scoped resources must reference an Alias with namespace or a ClusterAlias")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client config: %w", err)
+	}
+
+	return newClient(config)
+}
+
+// NewAliasClient creates a new MinIO client for an Alias regardless of its readiness, to check its health
+func NewAliasClient(ctx context.Context, k8sClient client.Client, alias *miniov1beta1.Alias) (*Client, error) {
+	config, err := aliasClientConfig(ctx, k8sClient, alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client config: %w", err)
+	}
+
+	return newClient(config)
+}
+
+// NewClusterAliasClient creates a new MinIO client for a ClusterAlias regardless of its readiness, to check its health
+func NewClusterAliasClient(ctx context.Context, k8sClient client.Client, alias *miniov1beta1.ClusterAlias) (*Client, error) {
+	config, err := clusterAliasClientConfig(ctx, k8sClient, alias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client config: %w", err)
+	}
+
+	return newClient(config)
+}
+
+// NewClientForEndpoint creates a new MinIO client from a deprecated Endpoint reference
+func NewClientForEndpoint(ctx context.Context, k8sClient client.Client, ref miniov1alpha1.EndpointReference, namespace string) (*Client, error) {
+	config, err := buildEndpointClientConfig(ctx, k8sClient, ref, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client config: %w", err)
+	}
+
+	return newClient(config)
+}
+
+// newClient creates the S3 and admin clients for a client configuration
+func newClient(config *ClientConfig) (*Client, error) {
+	transport := newTransport(config)
+
+	// The S3 and admin clients share the credentials so that temporary credentials are refreshed for both
+	creds, err := newCredentials(config, transport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credentials: %w", err)
+	}
+
 	// Create S3 client
 	minioClient, err := minio.New(config.Endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, ""),
-		Secure: config.UseSSL,
-		Region: config.Region,
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: config.Insecure,
-			},
-		},
+		Creds:     creds,
+		Secure:    config.UseSSL,
+		Region:    config.Region,
+		Transport: transport,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create S3 client: %w", err)
@@ -79,19 +156,15 @@ func NewClient(ctx context.Context, k8sClient client.Client, conn miniov1alpha1.
 	}
 
 	// Create admin client
-	adminClient, err := madmin.New(config.Endpoint, config.AccessKeyID, config.SecretAccessKey, config.UseSSL)
+	adminClient, err := madmin.NewWithOptions(config.Endpoint, &madmin.Options{
+		Creds:     creds,
+		Secure:    config.UseSSL,
+		Transport: transport,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create admin client: %w", err)
 	}
 
-	if config.Insecure {
-		adminClient.SetCustomTransport(&http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		})
-	}
-
 	return &Client{
 		S3:     minioClient,
 		Admin:  adminClient,
@@ -99,6 +172,19 @@ func NewClient(ctx context.Context, k8sClient client.Client, conn miniov1alpha1.
 	}, nil
 }
 
+// newTransport creates the instrumented HTTP transport shared by the S3 and admin clients
+func newTransport(config *ClientConfig) http.RoundTripper {
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: config.Insecure,
+	}
+	return &instrumentedTransport{
+		alias:    config.Alias,
+		endpoint: config.Endpoint,
+		base:     base,
+	}
+}
+
 // parseEndpointURL parses a URL and returns the endpoint (host:port) and SSL setting
 func parseEndpointURL(rawURL string) (endpoint string, useSSL bool, err error) {
 	// Handle case where URL might already be just host:port
@@ -129,21 +215,26 @@ func parseEndpointURL(rawURL string) (endpoint string, useSSL bool, err error) {
 }
 
 // buildClientConfig builds client configuration from connection spec
-func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov1alpha1.MinIOConnection, defaultNamespace string) (*ClientConfig, error) {
+func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov1beta1.MinIOConnection, defaultNamespace string) (*ClientConfig, error) {
 	config := &ClientConfig{
 		UseSSL:    true, // Default to SSL
 		PathStyle: false,
 	}
 
-	// Get endpoint URL - prioritize AliasRef over EndpointRef
+	// Get endpoint URL - prioritize AliasRef over URL
 	if conn.AliasRef != nil {
-		alias := &miniov1alpha1.Alias{}
+		alias := &miniov1beta1.Alias{}
 		aliasNamespace := defaultNamespace
 		if conn.AliasRef.Namespace != nil {
 			aliasNamespace = *conn.AliasRef.Namespace
 		}
 
-		err := k8sClient.Get(ctx, client.ObjectKey{
+		err := checkReferenceGrant(ctx, k8sClient, defaultNamespace, miniov1beta1.AliasGrantKindAlias, aliasNamespace, conn.AliasRef.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		err = k8sClient.Get(ctx, client.ObjectKey{
 			Name:      conn.AliasRef.Name,
 			Namespace: aliasNamespace,
 		}, alias)
@@ -155,63 +246,18 @@ func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov
 			return nil, fmt.Errorf("alias %s/%s is not ready", aliasNamespace, conn.AliasRef.Name)
 		}
 
-		// Parse the URL to extract endpoint and SSL setting
-		endpoint, useSSL, err := parseEndpointURL(alias.Spec.URL)
+		aliasConfig, err := aliasClientConfig(ctx, k8sClient, alias)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse alias URL: %w", err)
+			return nil, err
 		}
-		config.Endpoint = endpoint
-		config.UseSSL = useSSL
+		config = aliasConfig
 
-		if alias.Spec.PathStyle {
-			config.PathStyle = true
-		}
-		if alias.Spec.Region != nil {
-			config.Region = *alias.Spec.Region
-		}
-
-		// Use TLS config from alias if specified
-		if alias.Spec.TLS != nil {
-			config.Insecure = alias.Spec.TLS.Insecure
-		}
-
-		// Get credentials from alias secret
-		secretNamespace := aliasNamespace
-		if alias.Spec.SecretRef.Namespace != nil {
-			secretNamespace = *alias.Spec.SecretRef.Namespace
-		}
-
-		secret := &corev1.Secret{}
-		err = k8sClient.Get(ctx, client.ObjectKey{
-			Name:      alias.Spec.SecretRef.Name,
-			Namespace: secretNamespace,
-		}, secret)
+	} else if conn.ClusterAliasRef != nil {
+		clusterConfig, err := buildClusterAliasClientConfig(ctx, k8sClient, conn.ClusterAliasRef.Name, defaultNamespace)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get alias secret %s/%s: %w", secretNamespace, alias.Spec.SecretRef.Name, err)
-		}
-
-		// Get access key ID
-		accessKeyIDKey := alias.Spec.SecretRef.AccessKeyIDKey
-		if accessKeyIDKey == "" {
-			accessKeyIDKey = "accessKeyID"
+			return nil, err
 		}
-		accessKeyIDBytes, ok := secret.Data[accessKeyIDKey]
-		if !ok {
-			return nil, fmt.Errorf("access key ID not found in alias secret %s/%s with key %s", secretNamespace, alias.Spec.SecretRef.Name, accessKeyIDKey)
-		}
-		config.AccessKeyID = string(accessKeyIDBytes)
-
-		// Get secret access key
-		secretAccessKeyKey := alias.Spec.SecretRef.SecretAccessKeyKey
-		if secretAccessKeyKey == "" {
-			secretAccessKeyKey = "secretAccessKey"
-		}
-		secretAccessKeyBytes, ok := secret.Data[secretAccessKeyKey]
-		if !ok {
-			return nil, fmt.Errorf("secret access key not found in alias secret %s/%s with key %s", secretNamespace, alias.Spec.SecretRef.Name, secretAccessKeyKey)
-		}
-		config.SecretAccessKey = string(secretAccessKeyBytes)
-
+		config = clusterConfig
 	} else if conn.URL != nil {
 		// Parse the URL to extract endpoint and SSL setting
 		endpoint, useSSL, err := parseEndpointURL(*conn.URL)
@@ -220,84 +266,9 @@ func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov
 		}
 		config.Endpoint = endpoint
 		config.UseSSL = useSSL
-	} else if conn.EndpointRef != nil {
-		endpoint := &miniov1alpha1.Endpoint{}
-		endpointNamespace := defaultNamespace
-		if conn.EndpointRef.Namespace != nil {
-			endpointNamespace = *conn.EndpointRef.Namespace
-		}
-
-		err := k8sClient.Get(ctx, client.ObjectKey{
-			Name:      conn.EndpointRef.Name,
-			Namespace: endpointNamespace,
-		}, endpoint)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get endpoint %s/%s: %w", endpointNamespace, conn.EndpointRef.Name, err)
-		}
-
-		if !endpoint.Status.Ready {
-			return nil, fmt.Errorf("endpoint %s/%s is not ready", endpointNamespace, conn.EndpointRef.Name)
-		}
-
-		// Parse the URL to extract endpoint and SSL setting
-		endpointHost, useSSL, err := parseEndpointURL(endpoint.Spec.URL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse endpoint URL: %w", err)
-		}
-		config.Endpoint = endpointHost
-		config.UseSSL = useSSL
-
-		if endpoint.Spec.PathStyle {
-			config.PathStyle = true
-		}
-		if endpoint.Spec.Region != nil {
-			config.Region = *endpoint.Spec.Region
-		}
-
-		// Use TLS config from endpoint if specified
-		if endpoint.Spec.TLS != nil {
-			config.Insecure = endpoint.Spec.TLS.Insecure
-		}
-
-		// Get credentials from endpoint secret
-		secretNamespace := endpointNamespace
-		if endpoint.Spec.SecretRef.Namespace != nil {
-			secretNamespace = *endpoint.Spec.SecretRef.Namespace
-		}
-
-		secret := &corev1.Secret{}
-		err = k8sClient.Get(ctx, client.ObjectKey{
-			Name:      endpoint.Spec.SecretRef.Name,
-			Namespace: secretNamespace,
-		}, secret)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get endpoint secret %s/%s: %w", secretNamespace, endpoint.Spec.SecretRef.Name, err)
-		}
-
-		// Get access key ID
-		accessKeyIDKey := endpoint.Spec.SecretRef.AccessKeyIDKey
-		if accessKeyIDKey == "" {
-			accessKeyIDKey = "accessKeyID"
-		}
-		accessKeyIDBytes, ok := secret.Data[accessKeyIDKey]
-		if !ok {
-			return nil, fmt.Errorf("access key ID not found in endpoint secret %s/%s with key %s", secretNamespace, endpoint.Spec.SecretRef.Name, accessKeyIDKey)
-		}
-		config.AccessKeyID = string(accessKeyIDBytes)
-
-		// Get secret access key
-		secretAccessKeyKey := endpoint.Spec.SecretRef.SecretAccessKeyKey
-		if secretAccessKeyKey == "" {
-			secretAccessKeyKey = "secretAccessKey"
-		}
-		secretAccessKeyBytes, ok := secret.Data[secretAccessKeyKey]
-		if !ok {
-			return nil, fmt.Errorf("secret access key not found in endpoint secret %s/%s with key %s", secretNamespace, endpoint.Spec.SecretRef.Name, secretAccessKeyKey)
-		}
-		config.SecretAccessKey = string(secretAccessKeyBytes)
-
+		config.Alias = endpoint
 	} else {
-		return nil, fmt.Errorf("either AliasRef, URL, or EndpointRef must be specified")
+		return nil, fmt.Errorf("one of AliasRef, ClusterAliasRef or URL must be specified")
 	}
 
 	// Handle credentials when using URL directly (not with AliasRef which handles its own credentials)
@@ -308,6 +279,10 @@ func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov
 			secretNamespace = *conn.SecretRef.Namespace
 		}
 
+		if err := CheckSecretReference(ctx, k8sClient, defaultNamespace, secretNamespace, conn.SecretRef.Name); err != nil {
+			return nil, err
+		}
+
 		secret := &corev1.Secret{}
 		err := k8sClient.Get(ctx, client.ObjectKey{
 			Name:      conn.SecretRef.Name,
@@ -320,7 +295,7 @@ func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov
 		// Get access key ID
 		accessKeyIDKey := conn.SecretRef.AccessKeyIDKey
 		if accessKeyIDKey == "" {
-			accessKeyIDKey = "accessKeyID"
+			accessKeyIDKey = miniov1beta1.DefaultAccessKeyIDKey
 		}
 		accessKeyIDBytes, ok := secret.Data[accessKeyIDKey]
 		if !ok {
@@ -331,7 +306,7 @@ func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov
 		// Get secret access key
 		secretAccessKeyKey := conn.SecretRef.SecretAccessKeyKey
 		if secretAccessKeyKey == "" {
-			secretAccessKeyKey = "secretAccessKey"
+			secretAccessKeyKey = miniov1beta1.DefaultSecretAccessKeyKey
 		}
 		secretAccessKeyBytes, ok := secret.Data[secretAccessKeyKey]
 		if !ok {
@@ -348,6 +323,299 @@ func buildClientConfig(ctx context.Context, k8sClient client.Client, conn miniov
 	return config, nil
 }
 
+// aliasClientConfig builds client configuration from the spec of an Alias
+func aliasClientConfig(ctx context.Context, k8sClient client.Client, alias *miniov1beta1.Alias) (*ClientConfig, error) {
+	config := &ClientConfig{
+		UseSSL:    true, // Default to SSL
+		PathStyle: false,
+	}
+
+	// Parse the URL to extract endpoint and SSL setting
+	endpoint, useSSL, err := parseEndpointURL(alias.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse alias URL: %w", err)
+	}
+	config.Endpoint = endpoint
+	config.UseSSL = useSSL
+	config.Alias = alias.Namespace + "/" + alias.Name
+
+	if alias.Spec.PathStyle {
+		config.PathStyle = true
+	}
+	if alias.Spec.Region != nil {
+		config.Region = *alias.Spec.Region
+	}
+
+	// Use TLS config from alias if specified
+	if alias.Spec.TLS != nil {
+		config.Insecure = alias.Spec.TLS.Insecure
+	}
+
+	if err := CheckNamespacedCredentials(alias.Spec.Credentials, alias.Spec.URL); err != nil {
+		return nil, err
+	}
+	applyAliasCredentials(config, alias.Spec.Credentials, alias.Spec.URL)
+	if !usesSecretKeys(config.CredentialSource) {
+		return config, nil
+	}
+
+	// Get credentials from alias secret
+	secretNamespace := alias.Namespace
+	if alias.Spec.SecretRef.Namespace != nil {
+		secretNamespace = *alias.Spec.SecretRef.Namespace
+	}
+
+	if err := CheckSecretReference(ctx, k8sClient, alias.Namespace, secretNamespace, alias.Spec.SecretRef.Name); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err = k8sClient.Get(ctx, client.ObjectKey{
+		Name:      alias.Spec.SecretRef.Name,
+		Namespace: secretNamespace,
+	}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alias secret %s/%s: %w", secretNamespace, alias.Spec.SecretRef.Name, err)
+	}
+
+	// Get access key ID
+	accessKeyIDKey := alias.Spec.SecretRef.AccessKeyIDKey
+	if accessKeyIDKey == "" {
+		accessKeyIDKey = miniov1beta1.DefaultAccessKeyIDKey
+	}
+	accessKeyIDBytes, ok := secret.Data[accessKeyIDKey]
+	if !ok {
+		return nil, fmt.Errorf("access key ID not found in alias secret %s/%s with key %s", secretNamespace, alias.Spec.SecretRef.Name, accessKeyIDKey)
+	}
+	config.AccessKeyID = string(accessKeyIDBytes)
+
+	// Get secret access key
+	secretAccessKeyKey := alias.Spec.SecretRef.SecretAccessKeyKey
+	if secretAccessKeyKey == "" {
+		secretAccessKeyKey = miniov1beta1.DefaultSecretAccessKeyKey
+	}
+	secretAccessKeyBytes, ok := secret.Data[secretAccessKeyKey]
+	if !ok {
+		return nil, fmt.Errorf("secret access key not found in alias secret %s/%s with key %s", secretNamespace, alias.Spec.SecretRef.Name, secretAccessKeyKey)
+	}
+	config.SecretAccessKey = string(secretAccessKeyBytes)
+
+	return config, nil
+}
+
+// buildClusterAliasClientConfig builds client configuration from a ClusterAlias used by a resource in namespace.
+// The namespace selector is not consulted for cluster-scoped resources, which pass an empty namespace.
+func buildClusterAliasClientConfig(ctx context.Context, k8sClient client.Client, name, namespace string) (*ClientConfig, error) {
+	alias := &miniov1beta1.ClusterAlias{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: name}, alias); err != nil {
+		return nil, fmt.Errorf("failed to get cluster alias %s: %w", name, err)
+	}
+
+	allowed := true
+	if namespace != "" {
+		var err error
+		allowed, err = NamespaceAllowed(ctx, k8sClient, alias, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !allowed {
+		return nil, &ReferenceNotPermittedError{
+			message: fmt.Sprintf("namespace %s is not allowed to use cluster alias %s", namespace, name),
+		}
+	}
+
+	if !alias.Status.Ready {
+		return nil, fmt.Errorf("cluster alias %s is not ready", name)
+	}
+
+	return clusterAliasClientConfig(ctx, k8sClient, alias)
+}
+
+// clusterAliasClientConfig builds client configuration from the spec of a ClusterAlias
+func clusterAliasClientConfig(ctx context.Context, k8sClient client.Client, alias *miniov1beta1.ClusterAlias) (*ClientConfig, error) {
+	config := &ClientConfig{
+		UseSSL:    true, // Default to SSL
+		PathStyle: false,
+	}
+	name := alias.Name
+
+	// Parse the URL to extract endpoint and SSL setting
+	endpoint, useSSL, err := parseEndpointURL(alias.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cluster alias URL: %w", err)
+	}
+	config.Endpoint = endpoint
+	config.UseSSL = useSSL
+	config.Alias = name
+
+	if alias.Spec.PathStyle {
+		config.PathStyle = true
+	}
+	if alias.Spec.Region != nil {
+		config.Region = *alias.Spec.Region
+	}
+
+	// Use TLS config from alias if specified
+	if alias.Spec.TLS != nil {
+		config.Insecure = alias.Spec.TLS.Insecure
+	}
+
+	applyAliasCredentials(config, alias.Spec.Credentials, alias.Spec.URL)
+	if !usesSecretKeys(config.CredentialSource) {
+		return config, nil
+	}
+
+	// Get credentials from the alias secret in the operator namespace
+	secret := &corev1.Secret{}
+	err = k8sClient.Get(ctx, client.ObjectKey{
+		Name:      alias.Spec.SecretRef.Name,
+		Namespace: OperatorNamespace,
+	}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster alias secret %s/%s: %w", OperatorNamespace, alias.Spec.SecretRef.Name, err)
+	}
+
+	// Get access key ID
+	accessKeyIDKey := alias.Spec.SecretRef.AccessKeyIDKey
+	if accessKeyIDKey == "" {
+		accessKeyIDKey = miniov1beta1.DefaultAccessKeyIDKey
+	}
+	accessKeyIDBytes, ok := secret.Data[accessKeyIDKey]
+	if !ok {
+		return nil, fmt.Errorf("access key ID not found in cluster alias secret %s/%s with key %s", OperatorNamespace, alias.Spec.SecretRef.Name, accessKeyIDKey)
+	}
+	config.AccessKeyID = string(accessKeyIDBytes)
+
+	// Get secret access key
+	secretAccessKeyKey := alias.Spec.SecretRef.SecretAccessKeyKey
+	if secretAccessKeyKey == "" {
+		secretAccessKeyKey = miniov1beta1.DefaultSecretAccessKeyKey
+	}
+	secretAccessKeyBytes, ok := secret.Data[secretAccessKeyKey]
+	if !ok {
+		return nil, fmt.Errorf("secret access key not found in cluster alias secret %s/%s with key %s", OperatorNamespace, alias.Spec.SecretRef.Name, secretAccessKeyKey)
+	}
+	config.SecretAccessKey = string(secretAccessKeyBytes)
+
+	return config, nil
+}
+
+// NamespaceAllowed reports whether resources in namespace may use a ClusterAlias according to its namespace selector.
+// A ClusterAlias without a selector is not shared with any namespace.
+func NamespaceAllowed(ctx context.Context, k8sClient client.Reader, alias *miniov1beta1.ClusterAlias, namespace string) (bool, error) {
+	if alias.Spec.NamespaceSelector == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(alias.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespace selector of cluster alias %s: %w", alias.Name, err)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return false, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// buildEndpointClientConfig builds client configuration from a deprecated Endpoint resource
+func buildEndpointClientConfig(ctx context.Context, k8sClient client.Client, ref miniov1alpha1.EndpointReference, defaultNamespace string) (*ClientConfig, error) {
+	config := &ClientConfig{
+		UseSSL:    true, // Default to SSL
+		PathStyle: false,
+	}
+
+	endpoint := &miniov1alpha1.Endpoint{}
+	endpointNamespace := defaultNamespace
+	if ref.Namespace != nil {
+		endpointNamespace = *ref.Namespace
+	}
+
+	err := checkReferenceGrant(ctx, k8sClient, defaultNamespace, miniov1beta1.AliasGrantKindEndpoint, endpointNamespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = k8sClient.Get(ctx, client.ObjectKey{
+		Name:      ref.Name,
+		Namespace: endpointNamespace,
+	}, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint %s/%s: %w", endpointNamespace, ref.Name, err)
+	}
+
+	if !endpoint.Status.Ready {
+		return nil, fmt.Errorf("endpoint %s/%s is not ready", endpointNamespace, ref.Name)
+	}
+
+	// Parse the URL to extract endpoint and SSL setting
+	endpointHost, useSSL, err := parseEndpointURL(endpoint.Spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint URL: %w", err)
+	}
+	config.Endpoint = endpointHost
+	config.UseSSL = useSSL
+	config.Alias = endpointNamespace + "/" + ref.Name
+
+	if endpoint.Spec.PathStyle {
+		config.PathStyle = true
+	}
+	if endpoint.Spec.Region != nil {
+		config.Region = *endpoint.Spec.Region
+	}
+
+	// Use TLS config from endpoint if specified
+	if endpoint.Spec.TLS != nil {
+		config.Insecure = endpoint.Spec.TLS.Insecure
+	}
+
+	// Get credentials from endpoint secret
+	secretNamespace := endpointNamespace
+	if endpoint.Spec.SecretRef.Namespace != nil {
+		secretNamespace = *endpoint.Spec.SecretRef.Namespace
+	}
+
+	if err := CheckSecretReference(ctx, k8sClient, endpointNamespace, secretNamespace, endpoint.Spec.SecretRef.Name); err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{}
+	err = k8sClient.Get(ctx, client.ObjectKey{
+		Name:      endpoint.Spec.SecretRef.Name,
+		Namespace: secretNamespace,
+	}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint secret %s/%s: %w", secretNamespace, endpoint.Spec.SecretRef.Name, err)
+	}
+
+	// Get access key ID
+	accessKeyIDKey := endpoint.Spec.SecretRef.AccessKeyIDKey
+	if accessKeyIDKey == "" {
+		accessKeyIDKey = miniov1alpha1.DefaultAccessKeyIDKey
+	}
+	accessKeyIDBytes, ok := secret.Data[accessKeyIDKey]
+	if !ok {
+		return nil, fmt.Errorf("access key ID not found in endpoint secret %s/%s with key %s", secretNamespace, endpoint.Spec.SecretRef.Name, accessKeyIDKey)
+	}
+	config.AccessKeyID = string(accessKeyIDBytes)
+
+	// Get secret access key
+	secretAccessKeyKey := endpoint.Spec.SecretRef.SecretAccessKeyKey
+	if secretAccessKeyKey == "" {
+		secretAccessKeyKey = miniov1alpha1.DefaultSecretAccessKeyKey
+	}
+	secretAccessKeyBytes, ok := secret.Data[secretAccessKeyKey]
+	if !ok {
+		return nil, fmt.Errorf("secret access key not found in endpoint secret %s/%s with key %s", secretNamespace, endpoint.Spec.SecretRef.Name, secretAccessKeyKey)
+	}
+	config.SecretAccessKey = string(secretAccessKeyBytes)
+
+	return config, nil
+}
+
 // HealthCheck performs a health check on the MinIO server
 func (c *Client) HealthCheck(ctx context.Context) error {
 	// Try to list buckets as a simple health check
@@ -359,3 +627,25 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 func (c *Client) GetServerInfo(ctx context.Context) (madmin.InfoMessage, error) {
 	return c.Admin.ServerInfo(ctx)
 }
+
+// Endpoint returns the host and port of the MinIO server and whether it is reached over TLS
+func (c *Client) Endpoint() (string, bool) {
+	return c.config.Endpoint, c.config.UseSSL
+}
+
+// Region returns the region of the MinIO server, us-east-1 if none is configured
+func (c *Client) Region() string {
+	if c.config.Region == "" {
+		return DefaultRegion
+	}
+	return c.config.Region
+}
+
+// Credentials returns the access key and secret key the client authenticates with. Temporary credentials are
+// refused since the servers they would be handed to keep using them after they expire.
+func (c *Client) Credentials() (string, string, error) {
+	if c.config.temporaryCredentials() {
+		return "", "", fmt.Errorf("alias %s uses %s credentials, static keys are required", c.config.Alias, c.config.CredentialSource)
+	}
+	return c.config.AccessKeyID, c.config.SecretAccessKey, nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// AllowNamespacedAmbientCredentials lets namespaced Aliases use WebIdentity and AWS credentials. Both authenticate
+// with the identity of the operator pod, so by default only ClusterAliases, which are created by administrators,
+// may use them.
+var AllowNamespacedAmbientCredentials = false
+
+// AllowedSTSHosts are the hosts namespaced Aliases may send the web identity token to besides the host of their URL
+var AllowedSTSHosts []string
+
+// CredentialsNotPermittedError reports credential settings an Alias is not permitted to use
+type CredentialsNotPermittedError struct {
+	message string
+}
+
+func (e *CredentialsNotPermittedError) Error() string {
+	return e.message
+}
+
+// CheckNamespacedCredentials rejects credential settings that would let a namespaced Alias authenticate with the
+// identity of the operator pod or send its token to a server the administrator did not allow
+func CheckNamespacedCredentials(creds *miniov1beta1.AliasCredentials, aliasURL string) error {
+	if creds == nil {
+		return nil
+	}
+	switch creds.Source {
+	case miniov1beta1.CredentialSourceWebIdentity, miniov1beta1.CredentialSourceAWS:
+		if !AllowNamespacedAmbientCredentials {
+			return &CredentialsNotPermittedError{message: fmt.Sprintf("%s credentials are only permitted for ClusterAliases", creds.Source)}
+		}
+	}
+	if creds.TokenPath != "" && creds.TokenPath != miniov1beta1.DefaultWebIdentityTokenPath {
+		return &CredentialsNotPermittedError{message: "a custom tokenPath is only permitted for ClusterAliases"}
+	}
+	if creds.Source == miniov1beta1.CredentialSourceWebIdentity && creds.STSEndpoint != nil && !STSHostAllowed(*creds.STSEndpoint, aliasURL) {
+		return &CredentialsNotPermittedError{message: fmt.Sprintf("stsEndpoint %s is neither the alias host nor an allowed STS host", *creds.STSEndpoint)}
+	}
+	return nil
+}
+
+// STSHostAllowed reports whether stsEndpoint is on the host of the alias URL or on one of the AllowedSTSHosts
+func STSHostAllowed(stsEndpoint, aliasURL string) bool {
+	stsHost := endpointHostname(stsEndpoint)
+	if stsHost == "" {
+		return false
+	}
+	return stsHost == endpointHostname(aliasURL) || slices.ContainsFunc(AllowedSTSHosts, func(host string) bool {
+		return strings.EqualFold(host, stsHost)
+	})
+}
+
+// endpointHostname returns the lower-cased hostname of a URL, which may omit its scheme
+func endpointHostname(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// credentialsCache holds the temporary credentials of each alias. Clients are created for every reconcile, sharing
+// the credentials lets them be refreshed when they expire instead of being requested anew every time.
+var credentialsCache sync.Map
+
+// cachedCredentials are temporary credentials together with the fingerprint of the configuration they were created for
+type cachedCredentials struct {
+	fingerprint string
+	creds       *credentials.Credentials
+}
+
+// temporaryCredentials reports whether the configuration obtains short-lived credentials from an STS service
+// or the AWS credential chain
+func (config *ClientConfig) temporaryCredentials() bool {
+	return config.CredentialSource != "" && config.CredentialSource != miniov1beta1.CredentialSourceSecret
+}
+
+// usesSecretKeys reports whether a credential source authenticates with the keys of the secret reference
+func usesSecretKeys(source miniov1beta1.CredentialSource) bool {
+	return source == "" || source == miniov1beta1.CredentialSourceSecret || source == miniov1beta1.CredentialSourceAssumeRole
+}
+
+// applyAliasCredentials copies the credential settings of an alias into a client configuration
+func applyAliasCredentials(config *ClientConfig, creds *miniov1beta1.AliasCredentials, aliasURL string) {
+	config.CredentialSource = miniov1beta1.CredentialSourceSecret
+	config.STSEndpoint = aliasURL
+	config.TokenPath = miniov1beta1.DefaultWebIdentityTokenPath
+	if creds != nil {
+		if creds.Source != "" {
+			config.CredentialSource = creds.Source
+		}
+		if creds.TokenPath != "" {
+			config.TokenPath = creds.TokenPath
+		}
+		if creds.DurationSeconds != nil {
+			config.DurationSeconds = int(*creds.DurationSeconds)
+		}
+		if creds.STSEndpoint != nil {
+			config.STSEndpoint = *creds.STSEndpoint
+		}
+		config.RoleARN = creds.RoleARN
+	}
+
+	// The STS client requires a URL, plain host:port endpoints default to SSL like the S3 client
+	if !strings.Contains(config.STSEndpoint, "://") {
+		config.STSEndpoint = "https://" + config.STSEndpoint
+	}
+}
+
+// newCredentials returns the credentials for a client configuration. Temporary credentials are shared between the
+// clients of an alias and refreshed transparently before they expire.
+func newCredentials(config *ClientConfig, transport http.RoundTripper) (*credentials.Credentials, error) {
+	if !config.temporaryCredentials() {
+		return credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, ""), nil
+	}
+
+	fingerprint := credentialsFingerprint(config)
+	if cached, ok := credentialsCache.Load(config.Alias); ok && cached.(cachedCredentials).fingerprint == fingerprint {
+		return cached.(cachedCredentials).creds, nil
+	}
+
+	stsClient := &http.Client{Transport: transport}
+	var creds *credentials.Credentials
+	switch config.CredentialSource {
+	case miniov1beta1.CredentialSourceWebIdentity:
+		tokenPath, duration := config.TokenPath, config.DurationSeconds
+		var err error
+		creds, err = credentials.NewSTSWebIdentity(config.STSEndpoint, func() (*credentials.WebIdentityToken, error) {
+			token, err := os.ReadFile(tokenPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read web identity token: %w", err)
+			}
+			return &credentials.WebIdentityToken{Token: strings.TrimSpace(string(token)), Expiry: duration}, nil
+		}, func(identity *credentials.STSWebIdentity) {
+			identity.Client = stsClient
+			identity.RoleARN = config.RoleARN
+		})
+		if err != nil {
+			return nil, err
+		}
+	case miniov1beta1.CredentialSourceAssumeRole:
+		if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+			return nil, fmt.Errorf("access key and secret key are required to assume a role")
+		}
+		creds = credentials.New(&credentials.STSAssumeRole{
+			Client:      stsClient,
+			STSEndpoint: config.STSEndpoint,
+			Options: credentials.STSAssumeRoleOptions{
+				AccessKey:       config.AccessKeyID,
+				SecretKey:       config.SecretAccessKey,
+				Location:        config.Region,
+				DurationSeconds: config.DurationSeconds,
+				RoleARN:         config.RoleARN,
+			},
+		})
+	case miniov1beta1.CredentialSourceAWS:
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{},
+		})
+	default:
+		return nil, fmt.Errorf("unsupported credential source %q", config.CredentialSource)
+	}
+
+	credentialsCache.Store(config.Alias, cachedCredentials{fingerprint: fingerprint, creds: creds})
+	return creds, nil
+}
+
+// credentialsFingerprint identifies the settings temporary credentials are obtained with, so that cached
+// credentials are replaced when the alias or its secret changes
+func credentialsFingerprint(config *ClientConfig) string {
+	hash := sha256.New()
+	for _, value := range []string{
+		string(config.CredentialSource), config.Endpoint, config.STSEndpoint, config.RoleARN, config.TokenPath,
+		config.Region, fmt.Sprint(config.DurationSeconds, config.Insecure), config.AccessKeyID, config.SecretAccessKey,
+	} {
+		hash.Write([]byte(value))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Alias credentials", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	newFakeClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	}
+
+	newAlias := func(creds *miniov1beta1.AliasCredentials) *miniov1beta1.Alias {
+		return &miniov1beta1.Alias{
+			ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "team-a"},
+			Spec: miniov1beta1.AliasSpec{
+				URL:         "https://minio.example.com",
+				SecretRef:   miniov1beta1.SecretReference{Name: "minio-credentials"},
+				Credentials: creds,
+			},
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "minio-credentials", Namespace: "team-a"},
+		Data: map[string][]byte{
+			miniov1beta1.DefaultAccessKeyIDKey:     []byte("access"),
+			miniov1beta1.DefaultSecretAccessKeyKey: []byte("secret"),
+		},
+	}
+
+	Context("When building the client configuration of an alias", func() {
+		It("should read static keys from the secret by default", func() {
+			config, err := aliasClientConfig(ctx, newFakeClient(secret.DeepCopy()), newAlias(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.CredentialSource).To(Equal(miniov1beta1.CredentialSourceSecret))
+			Expect(config.AccessKeyID).To(Equal("access"))
+			Expect(config.temporaryCredentials()).To(BeFalse())
+		})
+
+		It("should not require a secret for web identity credentials", func() {
+			AllowNamespacedAmbientCredentials = true
+			DeferCleanup(func() { AllowNamespacedAmbientCredentials = false })
+			alias := newAlias(&miniov1beta1.AliasCredentials{Source: miniov1beta1.CredentialSourceWebIdentity})
+			alias.Spec.SecretRef = miniov1beta1.SecretReference{}
+
+			config, err := aliasClientConfig(ctx, newFakeClient(), alias)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.STSEndpoint).To(Equal("https://minio.example.com"))
+			Expect(config.TokenPath).To(Equal(miniov1beta1.DefaultWebIdentityTokenPath))
+			Expect(config.AccessKeyID).To(BeEmpty())
+		})
+
+		It("should read the keys to assume a role with from the secret", func() {
+			duration := int32(3600)
+			stsEndpoint := "https://sts.example.com"
+			alias := newAlias(&miniov1beta1.AliasCredentials{
+				Source:          miniov1beta1.CredentialSourceAssumeRole,
+				DurationSeconds: &duration,
+				STSEndpoint:     &stsEndpoint,
+			})
+
+			config, err := aliasClientConfig(ctx, newFakeClient(secret.DeepCopy()), alias)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.SecretAccessKey).To(Equal("secret"))
+			Expect(config.DurationSeconds).To(Equal(3600))
+			Expect(config.STSEndpoint).To(Equal("https://sts.example.com"))
+
+			_, err = aliasClientConfig(ctx, newFakeClient(), alias)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When checking the credentials of namespaced aliases", func() {
+		AfterEach(func() {
+			AllowNamespacedAmbientCredentials = false
+			AllowedSTSHosts = nil
+		})
+
+		It("should only permit the identity of the operator pod for cluster aliases", func() {
+			for _, source := range []miniov1beta1.CredentialSource{miniov1beta1.CredentialSourceWebIdentity, miniov1beta1.CredentialSourceAWS} {
+				alias := newAlias(&miniov1beta1.AliasCredentials{Source: source})
+				_, err := aliasClientConfig(ctx, newFakeClient(secret.DeepCopy()), alias)
+				var notPermitted *CredentialsNotPermittedError
+				Expect(errors.As(err, &notPermitted)).To(BeTrue())
+			}
+
+			AllowNamespacedAmbientCredentials = true
+			Expect(CheckNamespacedCredentials(&miniov1beta1.AliasCredentials{Source: miniov1beta1.CredentialSourceAWS}, "https://minio.example.com")).To(Succeed())
+		})
+
+		It("should reject a custom token path", func() {
+			AllowNamespacedAmbientCredentials = true
+			Expect(CheckNamespacedCredentials(&miniov1beta1.AliasCredentials{
+				Source:    miniov1beta1.CredentialSourceWebIdentity,
+				TokenPath: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+			}, "https://minio.example.com")).NotTo(Succeed())
+		})
+
+		It("should only send the token to the alias host or allowed STS hosts", func() {
+			AllowNamespacedAmbientCredentials = true
+			creds := func(stsEndpoint string) *miniov1beta1.AliasCredentials {
+				return &miniov1beta1.AliasCredentials{Source: miniov1beta1.CredentialSourceWebIdentity, STSEndpoint: &stsEndpoint}
+			}
+
+			Expect(CheckNamespacedCredentials(creds("https://minio.example.com:9000"), "minio.example.com")).To(Succeed())
+			Expect(CheckNamespacedCredentials(creds("https://attacker.example.com"), "https://minio.example.com")).NotTo(Succeed())
+
+			AllowedSTSHosts = []string{"sts.example.com"}
+			Expect(CheckNamespacedCredentials(creds("https://STS.example.com"), "https://minio.example.com")).To(Succeed())
+		})
+
+		It("should let the keys of the alias assume a role on other STS hosts", func() {
+			stsEndpoint := "https://sts.example.com"
+			Expect(CheckNamespacedCredentials(&miniov1beta1.AliasCredentials{
+				Source:      miniov1beta1.CredentialSourceAssumeRole,
+				STSEndpoint: &stsEndpoint,
+			}, "https://minio.example.com")).To(Succeed())
+		})
+	})
+
+	Context("When creating clients with temporary credentials", func() {
+		var (
+			server    *httptest.Server
+			requests  int
+			lastToken string
+			tokenPath string
+		)
+
+		BeforeEach(func() {
+			requests = 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.ParseForm()).To(Succeed())
+				requests++
+				lastToken = r.PostForm.Get("WebIdentityToken")
+				fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+<AssumeRoleWithWebIdentityResult><Credentials>
+<AccessKeyId>temporary-%d</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>session</SessionToken>
+<Expiration>%s</Expiration>
+</Credentials></AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`, requests, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+			}))
+			DeferCleanup(server.Close)
+
+			tokenPath = filepath.Join(GinkgoT().TempDir(), "token")
+			Expect(os.WriteFile(tokenPath, []byte("first-token\n"), 0o600)).To(Succeed())
+		})
+
+		newConfig := func() *ClientConfig {
+			return &ClientConfig{
+				Alias:            "team-a/web-identity",
+				Endpoint:         "minio.example.com",
+				UseSSL:           true,
+				CredentialSource: miniov1beta1.CredentialSourceWebIdentity,
+				STSEndpoint:      server.URL,
+				TokenPath:        tokenPath,
+			}
+		}
+
+		It("should share and refresh the credentials of an alias", func() {
+			first, err := newClient(newConfig())
+			Expect(err).NotTo(HaveOccurred())
+			second, err := newClient(newConfig())
+			Expect(err).NotTo(HaveOccurred())
+
+			creds, err := newCredentials(newConfig(), http.DefaultTransport)
+			Expect(err).NotTo(HaveOccurred())
+			value, err := creds.GetWithContext(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value.AccessKeyID).To(Equal("temporary-1"))
+			Expect(value.SessionToken).To(Equal("session"))
+			Expect(lastToken).To(Equal("first-token"))
+
+			// Clients of the alias use the cached credentials instead of requesting new ones
+			firstValue, err := first.S3.GetCreds()
+			Expect(err).NotTo(HaveOccurred())
+			secondValue, err := second.S3.GetCreds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(firstValue).To(Equal(secondValue))
+			_, err = creds.GetWithContext(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal(1))
+
+			// Expired credentials are requested again with the rotated token
+			Expect(os.WriteFile(tokenPath, []byte("second-token"), 0o600)).To(Succeed())
+			creds.Expire()
+			value, err = creds.GetWithContext(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value.AccessKeyID).To(Equal("temporary-2"))
+			Expect(lastToken).To(Equal("second-token"))
+		})
+
+		It("should replace cached credentials when the alias changes", func() {
+			creds, err := newCredentials(newConfig(), http.DefaultTransport)
+			Expect(err).NotTo(HaveOccurred())
+
+			config := newConfig()
+			config.RoleARN = "arn:minio:iam:::role/operator"
+			changed, err := newCredentials(config, http.DefaultTransport)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).NotTo(BeIdenticalTo(creds))
+		})
+
+		It("should refuse to hand out temporary credentials", func() {
+			minioClient, err := newClient(newConfig())
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = minioClient.Credentials()
+			Expect(err).To(HaveOccurred())
+
+			minioClient, err = newClient(&ClientConfig{Alias: "static", Endpoint: "minio.example.com", AccessKeyID: "access", SecretAccessKey: "secret"})
+			Expect(err).NotTo(HaveOccurred())
+			accessKey, secretKey, err := minioClient.Credentials()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(accessKey).To(Equal("access"))
+			Expect(secretKey).To(Equal("secret"))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// DefaultRegion is the region MinIO reports when none is configured
+const DefaultRegion = "us-east-1"
+
+// ConnectionEndpoint describes how workloads reach the MinIO server of a connection, without its credentials
+type ConnectionEndpoint struct {
+	// URL is the URL of the MinIO server including its scheme
+	URL string
+	// Region is the region of the MinIO server
+	Region string
+	// CABundle is the PEM encoded CA bundle the server certificate is validated with
+	CABundle []byte
+}
+
+// ResolveEndpoint resolves the endpoint of a connection used by a resource in namespace. Reference grants and
+// namespace selectors are checked like for clients, but the credentials of the connection are not read.
+func ResolveEndpoint(ctx context.Context, k8sClient client.Reader, conn miniov1beta1.MinIOConnection, namespace string) (*ConnectionEndpoint, error) {
+	var rawURL string
+	var region *string
+	var tlsConfig *miniov1beta1.TLSConfig
+	switch {
+	case conn.AliasRef != nil:
+		aliasNamespace := namespace
+		if conn.AliasRef.Namespace != nil {
+			aliasNamespace = *conn.AliasRef.Namespace
+		}
+		if err := checkReferenceGrant(ctx, k8sClient, namespace, miniov1beta1.AliasGrantKindAlias, aliasNamespace, conn.AliasRef.Name); err != nil {
+			return nil, err
+		}
+
+		alias := &miniov1beta1.Alias{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: conn.AliasRef.Name, Namespace: aliasNamespace}, alias); err != nil {
+			return nil, fmt.Errorf("failed to get alias %s/%s: %w", aliasNamespace, conn.AliasRef.Name, err)
+		}
+		rawURL, region, tlsConfig = alias.Spec.URL, alias.Spec.Region, alias.Spec.TLS
+	case conn.ClusterAliasRef != nil:
+		alias := &miniov1beta1.ClusterAlias{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Name: conn.ClusterAliasRef.Name}, alias); err != nil {
+			return nil, fmt.Errorf("failed to get cluster alias %s: %w", conn.ClusterAliasRef.Name, err)
+		}
+		allowed, err := NamespaceAllowed(ctx, k8sClient, alias, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, &ReferenceNotPermittedError{
+				message: fmt.Sprintf("namespace %s is not allowed to use cluster alias %s", namespace, alias.Name),
+			}
+		}
+		rawURL, region, tlsConfig = alias.Spec.URL, alias.Spec.Region, alias.Spec.TLS
+	case conn.URL != nil:
+		rawURL, tlsConfig = *conn.URL, conn.TLS
+	default:
+		return nil, fmt.Errorf("one of AliasRef, ClusterAliasRef or URL must be specified")
+	}
+
+	endpoint := &ConnectionEndpoint{URL: rawURL, Region: DefaultRegion}
+	// Plain host:port URLs default to SSL like the S3 client
+	if !strings.Contains(endpoint.URL, "://") {
+		endpoint.URL = "https://" + endpoint.URL
+	}
+	if region != nil && *region != "" {
+		endpoint.Region = *region
+	}
+	if tlsConfig != nil {
+		endpoint.CABundle = tlsConfig.CABundle
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// ReferenceNotPermittedError is returned when a resource references an object it is not allowed to use
+type ReferenceNotPermittedError struct {
+	message string
+}
+
+// Error implements error
+func (e *ReferenceNotPermittedError) Error() string {
+	return e.message
+}
+
+// checkReferenceGrant returns a ReferenceNotPermittedError unless a resource in fromNamespace may reference
+// the named object of kind in toNamespace. References within a namespace are always permitted, references
+// to other namespaces require an AliasGrant in the target namespace.
+func checkReferenceGrant(ctx context.Context, k8sClient client.Reader, fromNamespace, kind, toNamespace, name string) error {
+	if fromNamespace == toNamespace {
+		return nil
+	}
+
+	grants := &miniov1beta1.AliasGrantList{}
+	if err := k8sClient.List(ctx, grants, client.InNamespace(toNamespace)); err != nil {
+		return fmt.Errorf("failed to list alias grants in namespace %s: %w", toNamespace, err)
+	}
+	for i := range grants.Items {
+		if grants.Items[i].Permits(fromNamespace, kind, name) {
+			return nil
+		}
+	}
+
+	return &ReferenceNotPermittedError{
+		message: fmt.Sprintf("reference from namespace %s to %s %s/%s is not permitted by any AliasGrant in namespace %s",
+			fromNamespace, kind, toNamespace, name, toNamespace),
+	}
+}
+
+// CheckSecretReference returns a ReferenceNotPermittedError unless a resource in fromNamespace may read the named secret
+func CheckSecretReference(ctx context.Context, k8sClient client.Client, fromNamespace, secretNamespace, name string) error {
+	return checkReferenceGrant(ctx, k8sClient, fromNamespace, miniov1beta1.AliasGrantKindSecret, secretNamespace, name)
+}
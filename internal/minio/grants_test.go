@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Reference grants", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	newFakeClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	}
+
+	grant := func(from string, to ...miniov1beta1.AliasGrantTo) *miniov1beta1.AliasGrant {
+		return &miniov1beta1.AliasGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "platform"},
+			Spec: miniov1beta1.AliasGrantSpec{
+				From: []miniov1beta1.AliasGrantFrom{{Namespace: from}},
+				To:   to,
+			},
+		}
+	}
+
+	expectNotPermitted := func(err error) {
+		var notPermitted *ReferenceNotPermittedError
+		Expect(errors.As(err, &notPermitted)).To(BeTrue(), "expected a ReferenceNotPermittedError, got %v", err)
+	}
+
+	It("should permit references within a namespace", func() {
+		Expect(checkReferenceGrant(ctx, newFakeClient(), "team-a", miniov1beta1.AliasGrantKindAlias, "team-a", "minio")).To(Succeed())
+	})
+
+	It("should reject cross-namespace references without a grant", func() {
+		err := checkReferenceGrant(ctx, newFakeClient(), "team-a", miniov1beta1.AliasGrantKindAlias, "platform", "minio")
+		expectNotPermitted(err)
+	})
+
+	It("should permit cross-namespace references with a grant for the kind", func() {
+		c := newFakeClient(grant("team-a", miniov1beta1.AliasGrantTo{Kind: miniov1beta1.AliasGrantKindAlias}))
+		Expect(checkReferenceGrant(ctx, c, "team-a", miniov1beta1.AliasGrantKindAlias, "platform", "minio")).To(Succeed())
+	})
+
+	It("should reject references from namespaces not listed in the grant", func() {
+		c := newFakeClient(grant("team-a", miniov1beta1.AliasGrantTo{Kind: miniov1beta1.AliasGrantKindAlias}))
+		expectNotPermitted(checkReferenceGrant(ctx, c, "team-b", miniov1beta1.AliasGrantKindAlias, "platform", "minio"))
+	})
+
+	It("should only permit the named resource of a grant", func() {
+		name := "minio"
+		c := newFakeClient(grant("team-a", miniov1beta1.AliasGrantTo{Kind: miniov1beta1.AliasGrantKindSecret, Name: &name}))
+		Expect(CheckSecretReference(ctx, c, "team-a", "platform", "minio")).To(Succeed())
+		expectNotPermitted(CheckSecretReference(ctx, c, "team-a", "platform", "minio-admin"))
+		expectNotPermitted(checkReferenceGrant(ctx, c, "team-a", miniov1beta1.AliasGrantKindAlias, "platform", "minio"))
+	})
+
+	It("should reject a connection to an alias in another namespace without a grant", func() {
+		aliasNamespace := "platform"
+		conn := miniov1beta1.MinIOConnection{
+			AliasRef: &miniov1beta1.AliasReference{Name: "minio", Namespace: &aliasNamespace},
+		}
+		_, err := buildClientConfig(ctx, newFakeClient(), conn, "team-a")
+		expectNotPermitted(err)
+	})
+
+	It("should reject namespaces not matching the selector of a cluster alias", func() {
+		alias := &miniov1beta1.ClusterAlias{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+			Spec: miniov1beta1.ClusterAliasSpec{
+				URL:       "https://minio.example.com",
+				SecretRef: miniov1beta1.ClusterSecretReference{Name: "minio-credentials"},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"minio-access": "true"},
+				},
+			},
+		}
+		allowed := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"minio-access": "true"}}}
+		denied := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}
+		c := newFakeClient(alias, allowed, denied)
+
+		ok, err := NamespaceAllowed(ctx, c, alias, "team-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		conn := miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared"}}
+		_, err = buildClientConfig(ctx, c, conn, "team-b")
+		expectNotPermitted(err)
+	})
+
+	It("should only share a cluster alias with the namespaces it selects", func() {
+		alias := &miniov1beta1.ClusterAlias{ObjectMeta: metav1.ObjectMeta{Name: "shared"}}
+		c := newFakeClient(alias, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}})
+
+		ok, err := NamespaceAllowed(ctx, c, alias, "team-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+
+		alias.Spec.NamespaceSelector = &metav1.LabelSelector{}
+		ok, err = NamespaceAllowed(ctx, c, alias, "team-a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/mxcd/mc-controller/internal/metrics"
+)
+
+// adminAPIPrefix is the path prefix of all MinIO admin API calls
+const adminAPIPrefix = "/minio/admin/"
+
+// s3Subresources are the query parameters that select a bucket or object subresource
+var s3Subresources = []string{
+	"acl", "cors", "delete", "encryption", "lifecycle", "location", "notification",
+	"object-lock", "policy", "replication", "retention", "legal-hold", "tagging",
+	"uploads", "versioning", "versions",
+}
+
+// instrumentedTransport records metrics for every request sent to MinIO
+type instrumentedTransport struct {
+	alias string
+	// endpoint is the host and port of the MinIO server, requests to its subdomains address a bucket
+	endpoint string
+	base     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	operation := operationName(req, t.endpoint)
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	outcome := metrics.OutcomeSuccess
+	if err != nil || resp.StatusCode >= http.StatusBadRequest {
+		outcome = metrics.OutcomeError
+	}
+	metrics.MinIORequestsTotal.WithLabelValues(t.alias, operation, outcome).Inc()
+	metrics.MinIORequestDuration.WithLabelValues(t.alias, operation, outcome).Observe(time.Since(start).Seconds())
+
+	return resp, err
+}
+
+// operationName derives a low-cardinality operation name from a MinIO request sent to endpoint.
+// Admin calls are named after their API path (e.g. "admin:add-user"), S3 calls
+// after the method, the addressed level and the subresource (e.g. "PutBucketTagging").
+func operationName(req *http.Request, endpoint string) string {
+	urlPath := req.URL.Path
+	if strings.HasPrefix(urlPath, adminAPIPrefix) {
+		return "admin:" + path.Base(urlPath)
+	}
+
+	query := req.URL.Query()
+	subresource := ""
+	for _, key := range s3Subresources {
+		if _, ok := query[key]; ok {
+			subresource = key
+			break
+		}
+	}
+
+	bucket, object, _ := strings.Cut(strings.Trim(urlPath, "/"), "/")
+	// Virtual-host-style requests name the bucket in the host, their path is the object key
+	if virtualHostBucket(req.URL, endpoint) {
+		bucket, object = req.URL.Hostname(), strings.Trim(urlPath, "/")
+	}
+	switch {
+	case bucket == "":
+		if req.Method == http.MethodGet {
+			return "ListBuckets"
+		}
+		return methodVerb(req.Method) + "Service"
+	case object == "":
+		if subresource == "" && req.Method == http.MethodGet {
+			return "ListObjects"
+		}
+		if subresource == "delete" && req.Method == http.MethodPost {
+			return "DeleteObjects"
+		}
+		return methodVerb(req.Method) + "Bucket" + subresourceName(subresource)
+	default:
+		return methodVerb(req.Method) + "Object" + subresourceName(subresource)
+	}
+}
+
+// virtualHostBucket reports whether a request addresses a bucket in a subdomain of the endpoint
+func virtualHostBucket(requestURL *url.URL, endpoint string) bool {
+	host := (&url.URL{Host: endpoint}).Hostname()
+	return host != "" && strings.HasSuffix(strings.ToLower(requestURL.Hostname()), "."+strings.ToLower(host))
+}
+
+// methodVerb converts an HTTP method to the verb used in operation names
+func methodVerb(method string) string {
+	if method == "" {
+		return "Get"
+	}
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
+
+// subresourceName converts a subresource query parameter to CamelCase
+func subresourceName(subresource string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(subresource, "-") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
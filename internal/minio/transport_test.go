@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Operation names", func() {
+	DescribeTable("should name requests after the addressed resource",
+		func(method, target, operation string) {
+			req := httptest.NewRequest(method, target, nil)
+			Expect(operationName(req, "minio.example.com:9000")).To(Equal(operation))
+		},
+		Entry("admin call", http.MethodPut, "http://minio.example.com:9000/minio/admin/v3/add-user?accessKey=a", "admin:add-user"),
+		Entry("list buckets", http.MethodGet, "http://minio.example.com:9000/", "ListBuckets"),
+		Entry("create bucket", http.MethodPut, "http://minio.example.com:9000/data", "PutBucket"),
+		Entry("list objects", http.MethodGet, "http://minio.example.com:9000/data/?prefix=logs", "ListObjects"),
+		Entry("bucket subresource", http.MethodPut, "http://minio.example.com:9000/data?object-lock=", "PutBucketObjectLock"),
+		Entry("delete objects", http.MethodPost, "http://minio.example.com:9000/data?delete=", "DeleteObjects"),
+		Entry("object", http.MethodGet, "http://minio.example.com:9000/data/logs/app.log", "GetObject"),
+		Entry("object subresource", http.MethodPut, "http://minio.example.com:9000/data/app.log?tagging=", "PutObjectTagging"),
+		Entry("virtual-host bucket", http.MethodPut, "http://data.minio.example.com:9000/?tagging=", "PutBucketTagging"),
+		Entry("virtual-host list objects", http.MethodGet, "http://data.minio.example.com:9000/?prefix=logs", "ListObjects"),
+		Entry("virtual-host object", http.MethodGet, "http://data.minio.example.com:9000/app.log", "GetObject"),
+		Entry("virtual-host nested object", http.MethodHead, "http://data.minio.example.com:9000/logs/app.log", "HeadObject"),
+	)
+})
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+// dataUsageTTL is how long the data usage of an alias is reused. MinIO only updates it once per scanner cycle,
+// so requesting it for every bucket reconcile adds load on the server without returning fresher numbers.
+const dataUsageTTL = time.Minute
+
+// dataUsageCache holds the last data usage reported through each alias
+var dataUsageCache sync.Map
+
+// cachedDataUsage is the data usage of an alias together with the time it was requested
+type cachedDataUsage struct {
+	usage     madmin.DataUsageInfo
+	fetchedAt time.Time
+}
+
+// DataUsage returns the data usage of the MinIO server. The usage is shared by the clients of an alias and
+// requested again once it is older than dataUsageTTL.
+func (c *Client) DataUsage(ctx context.Context) (madmin.DataUsageInfo, error) {
+	if cached, ok := dataUsageCache.Load(c.config.Alias); ok && time.Since(cached.(cachedDataUsage).fetchedAt) < dataUsageTTL {
+		return cached.(cachedDataUsage).usage, nil
+	}
+
+	usage, err := c.Admin.DataUsageInfo(ctx)
+	if err != nil {
+		return madmin.DataUsageInfo{}, err
+	}
+	dataUsageCache.Store(c.config.Alias, cachedDataUsage{usage: usage, fetchedAt: time.Now()})
+	return usage, nil
+}
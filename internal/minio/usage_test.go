@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package minio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Data usage", func() {
+	It("should share the data usage between the clients of an alias", func() {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(HaveSuffix("/datausageinfo"))
+			requests++
+			_, _ = w.Write([]byte(`{"bucketsUsageInfo":{"data":{"size":2048,"objectsCount":3}}}`))
+		}))
+		DeferCleanup(server.Close)
+		DeferCleanup(dataUsageCache.Clear)
+
+		newConfig := func(alias string) *ClientConfig {
+			return &ClientConfig{
+				Alias:           alias,
+				Endpoint:        strings.TrimPrefix(server.URL, "http://"),
+				AccessKeyID:     "access",
+				SecretAccessKey: "secret",
+			}
+		}
+
+		ctx := context.Background()
+		for range 2 {
+			minioClient, err := newClient(newConfig("team-a/minio"))
+			Expect(err).NotTo(HaveOccurred())
+			usage, err := minioClient.DataUsage(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage.BucketsUsage).To(HaveKey("data"))
+			Expect(usage.BucketsUsage["data"].Size).To(Equal(uint64(2048)))
+		}
+		Expect(requests).To(Equal(1))
+
+		// Other aliases request their own usage
+		minioClient, err := newClient(newConfig("team-b/minio"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = minioClient.DataUsage(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests).To(Equal(2))
+	})
+})
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// builtinPolicies are the canned policies every MinIO server ships with. They grant access to all
+// buckets or to administration, so tenants may neither attach nor overwrite them.
+var builtinPolicies = []string{"consoleAdmin", "diagnostics", "readonly", "readwrite", "writeonly"}
+
+// bucketARNPrefix is the prefix of the resource ARNs of buckets and objects
+const bucketARNPrefix = "arn:aws:s3:::"
+
+// stringList is a policy element that holds either a single string or a list of strings
+type stringList []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*l = stringList{value}
+		return nil
+	}
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+// policyStatement holds the elements of a policy statement that decide what it grants
+type policyStatement struct {
+	Effect      string     `json:"Effect"`
+	Action      stringList `json:"Action"`
+	NotAction   stringList `json:"NotAction"`
+	Resource    stringList `json:"Resource"`
+	NotResource stringList `json:"NotResource"`
+}
+
+// checkBuiltinPolicy rejects the built-in canned policies
+func checkBuiltinPolicy(policy, policyName string) error {
+	if slices.Contains(builtinPolicies, policyName) {
+		return violation(policy, "built-in policy %s may not be used", policyName)
+	}
+	return nil
+}
+
+// checkPolicyDocument checks that a policy document only allows S3 actions on buckets starting with the
+// bucket prefix. Statements denying access are not restricted.
+func checkPolicyDocument(policy, prefix string, document []byte) error {
+	var parsed struct {
+		Statement []policyStatement `json:"Statement"`
+	}
+	if err := json.Unmarshal(document, &parsed); err != nil {
+		return violation(policy, "policy document cannot be checked: %v", err)
+	}
+
+	for _, statement := range parsed.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		if len(statement.NotAction) > 0 || len(statement.NotResource) > 0 {
+			return violation(policy, "statements allowing access may not use NotAction or NotResource")
+		}
+		for _, action := range statement.Action {
+			if !strings.HasPrefix(action, "s3:") {
+				return violation(policy, "action %q is not allowed, only S3 actions are", action)
+			}
+		}
+		if len(statement.Resource) == 0 {
+			return violation(policy, "statements allowing access must name their resources")
+		}
+		for _, resource := range statement.Resource {
+			if !bucketResourceAllowed(resource, prefix) {
+				return violation(policy, "resource %q must be a bucket starting with %q", resource, prefix)
+			}
+		}
+	}
+	return nil
+}
+
+// bucketResourceAllowed reports whether the resource ARN only matches buckets starting with prefix.
+// Wildcards and policy variables may match any bucket, so the prefix must precede them.
+func bucketResourceAllowed(resource, prefix string) bool {
+	bucket, ok := strings.CutPrefix(resource, bucketARNPrefix)
+	if !ok {
+		return false
+	}
+	bucket, _, _ = strings.Cut(bucket, "/")
+	if i := strings.IndexAny(bucket, "*?$"); i >= 0 {
+		bucket = bucket[:i]
+	}
+	return bucket != "" && strings.HasPrefix(bucket, prefix)
+}
+
+// checkOwnedPolicy checks that a Policy in the namespace of obj manages the canned policy policyName on the
+// MinIO server of conn, and that no Policy of another namespace claimed the canned policy before it
+func checkOwnedPolicy(ctx context.Context, c client.Reader, policy, policyName string, obj client.Object, conn miniov1beta1.MinIOConnection) error {
+	list := &miniov1beta1.PolicyList{}
+	if err := c.List(ctx, list, client.MatchingFields{PolicyNameField: policyName}); err != nil {
+		return fmt.Errorf("failed to list policies: %w", err)
+	}
+
+	target := connectionTarget(ctx, c, obj.GetNamespace(), obj.GetAnnotations(), conn)
+	var owner *miniov1beta1.Policy
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Namespace != obj.GetNamespace() || item.DeletionTimestamp != nil {
+			continue
+		}
+		if connectionTarget(ctx, c, item.Namespace, item.Annotations, item.Spec.Connection) == target {
+			owner = item
+			break
+		}
+	}
+	if owner == nil {
+		return violation(policy, "policy %s is not managed by a Policy in namespace %s", policyName, obj.GetNamespace())
+	}
+
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Namespace == owner.Namespace || other.DeletionTimestamp != nil {
+			continue
+		}
+		if createdBefore(other, owner) && connectionTarget(ctx, c, other.Namespace, other.Annotations, other.Spec.Connection) == target {
+			return violation(policy, "policy %s is managed by Policy %s/%s", policyName, other.Namespace, other.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,436 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tenant enforces the TenantPolicies of a namespace on the MinIO resources created in it
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// Reasons of tenant errors, used as condition reasons by the controllers
+const (
+	// ReasonPolicyViolation is used when a resource violates a TenantPolicy
+	ReasonPolicyViolation = "TenantPolicyViolation"
+	// ReasonBucketConflict is used when another Bucket already claims the same bucket
+	ReasonBucketConflict = "BucketConflict"
+)
+
+// Fields indexed by SetupIndexes, so that claims of the same MinIO resource are found without listing
+// all resources of the cluster
+const (
+	// BucketNameField indexes Buckets by spec.bucketName
+	BucketNameField = "spec.bucketName"
+	// PolicyNameField indexes Policies by spec.policyName
+	PolicyNameField = "spec.policyName"
+)
+
+// IndexBucketName returns the BucketNameField of a Bucket
+func IndexBucketName(obj client.Object) []string {
+	return []string{obj.(*miniov1beta1.Bucket).Spec.BucketName}
+}
+
+// IndexPolicyName returns the PolicyNameField of a Policy
+func IndexPolicyName(obj client.Object) []string {
+	return []string{obj.(*miniov1beta1.Policy).Spec.PolicyName}
+}
+
+// SetupIndexes registers the field indexes the checks list resources by
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &miniov1beta1.Bucket{}, BucketNameField, IndexBucketName); err != nil {
+		return fmt.Errorf("failed to index buckets by name: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &miniov1beta1.Policy{}, PolicyNameField, IndexPolicyName); err != nil {
+		return fmt.Errorf("failed to index policies by name: %w", err)
+	}
+	return nil
+}
+
+// Error is returned when a resource is not allowed by the tenant policies of its namespace
+type Error struct {
+	// Reason is the reason of the error
+	Reason string
+	// Message describes the error
+	Message string
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// violation returns an Error for a violation of the named TenantPolicy
+func violation(policy, format string, args ...interface{}) *Error {
+	return &Error{
+		Reason:  ReasonPolicyViolation,
+		Message: fmt.Sprintf("tenant policy %s: %s", policy, fmt.Sprintf(format, args...)),
+	}
+}
+
+// Check returns an Error if obj violates a TenantPolicy of its namespace or claims a bucket
+// that is already claimed by another Bucket
+func Check(ctx context.Context, c client.Reader, obj client.Object) error {
+	policies, err := PoliciesFor(ctx, c, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	for i := range policies {
+		if err := checkPolicy(ctx, c, &policies[i], obj); err != nil {
+			return err
+		}
+	}
+
+	if bucket, ok := obj.(*miniov1beta1.Bucket); ok {
+		return CheckBucketClaim(ctx, c, bucket)
+	}
+	return nil
+}
+
+// PoliciesFor returns the TenantPolicies that apply to namespace
+func PoliciesFor(ctx context.Context, c client.Reader, namespace string) ([]miniov1beta1.TenantPolicy, error) {
+	list := &miniov1beta1.TenantPolicyList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list tenant policies: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+
+	var policies []miniov1beta1.TenantPolicy
+	for _, policy := range list.Items {
+		if policy.Spec.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespace selector of tenant policy %s: %w", policy.Name, err)
+			}
+			if !selector.Matches(labels.Set(ns.Labels)) {
+				continue
+			}
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// checkPolicy checks obj against a single TenantPolicy
+func checkPolicy(ctx context.Context, c client.Reader, policy *miniov1beta1.TenantPolicy, obj client.Object) error {
+	switch o := obj.(type) {
+	case *miniov1beta1.Bucket:
+		if err := checkConnection(policy, o.Namespace, o.Annotations, o.Spec.Connection); err != nil {
+			return err
+		}
+		if prefix := expandPrefix(policy.Spec.BucketPrefix, o.Namespace); !strings.HasPrefix(o.Spec.BucketName, prefix) {
+			return violation(policy.Name, "bucket name %q must start with %q", o.Spec.BucketName, prefix)
+		}
+		return checkCount(ctx, c, policy.Name, policy.Spec.MaxBuckets, o, &miniov1beta1.BucketList{})
+	case *miniov1beta1.User:
+		if err := checkConnection(policy, o.Namespace, o.Annotations, o.Spec.Connection); err != nil {
+			return err
+		}
+		prefix := expandPrefix(policy.Spec.UsernamePrefix, o.Namespace)
+		if !strings.HasPrefix(o.Spec.Username, prefix) {
+			return violation(policy.Name, "username %q must start with %q", o.Spec.Username, prefix)
+		}
+		for _, group := range o.Spec.Groups {
+			if !strings.HasPrefix(group, prefix) {
+				return violation(policy.Name, "group %q must start with %q", group, prefix)
+			}
+		}
+		for _, policyName := range o.Spec.Policies {
+			if err := checkAttachedPolicy(ctx, c, policy.Name, policyName, o, o.Spec.Connection); err != nil {
+				return err
+			}
+		}
+		return checkCount(ctx, c, policy.Name, policy.Spec.MaxUsers, o, &miniov1beta1.UserList{})
+	case *miniov1beta1.Policy:
+		if err := checkConnection(policy, o.Namespace, o.Annotations, o.Spec.Connection); err != nil {
+			return err
+		}
+		if err := checkBuiltinPolicy(policy.Name, o.Spec.PolicyName); err != nil {
+			return err
+		}
+		if prefix := expandPrefix(policy.Spec.BucketPrefix, o.Namespace); prefix != "" {
+			if err := checkPolicyDocument(policy.Name, prefix, o.Spec.Policy); err != nil {
+				return err
+			}
+		}
+		return checkCount(ctx, c, policy.Name, policy.Spec.MaxPolicies, o, &miniov1beta1.PolicyList{})
+	case *miniov1beta1.PolicyAttachment:
+		if err := checkConnection(policy, o.Namespace, o.Annotations, o.Spec.Connection); err != nil {
+			return err
+		}
+		if err := checkAttachmentTarget(policy, o); err != nil {
+			return err
+		}
+		return checkAttachedPolicy(ctx, c, policy.Name, o.Spec.PolicyName, o, o.Spec.Connection)
+	case *miniov1beta1.LifecyclePolicy:
+		return checkConnection(policy, o.Namespace, o.Annotations, o.Spec.Connection)
+	case *miniov1beta1.BucketReplication:
+		return checkConnection(policy, o.Namespace, o.Annotations, o.Spec.Destination.Connection)
+	case *miniov1beta1.Tier:
+		return checkServerConnection(policy, "tiers", o.Namespace, o.Annotations, o.Spec.Connection)
+	case *miniov1beta1.NotificationTarget:
+		return checkServerConnection(policy, "notification targets", o.Namespace, o.Annotations, o.Spec.Connection)
+	case *miniov1beta1.ServerConfig:
+		return checkServerConnection(policy, "server configurations", o.Namespace, o.Annotations, o.Spec.Connection)
+	case *miniov1beta1.IdentityProvider:
+		return checkServerConnection(policy, "identity providers", o.Namespace, o.Annotations, o.Spec.Connection)
+	case *miniov1beta1.BucketClaim:
+		// The prefixes and quotas are enforced on the Bucket and User the claim creates
+		return checkConnection(policy, o.Namespace, o.Annotations, o.Spec.Connection)
+	}
+	return nil
+}
+
+// checkAttachmentTarget checks that a PolicyAttachment targets a user, group or OpenID claim value named
+// with the username prefix. LDAP principals are not named by tenants and cannot be targeted.
+func checkAttachmentTarget(policy *miniov1beta1.TenantPolicy, attachment *miniov1beta1.PolicyAttachment) error {
+	target := attachment.Spec.Target
+	if target.LDAPUser != nil || target.LDAPGroup != nil {
+		return violation(policy.Name, "policies may not be attached to LDAP users or groups")
+	}
+	prefix := expandPrefix(policy.Spec.UsernamePrefix, attachment.Namespace)
+	switch {
+	case target.User != nil && !strings.HasPrefix(*target.User, prefix):
+		return violation(policy.Name, "user %q must start with %q", *target.User, prefix)
+	case target.Group != nil && !strings.HasPrefix(*target.Group, prefix):
+		return violation(policy.Name, "group %q must start with %q", *target.Group, prefix)
+	case target.OpenIDClaim != nil:
+		// The policy is published under the claim value, which defaults to the policy name
+		claimValue := target.OpenIDClaim.ClaimValue
+		if claimValue == "" {
+			claimValue = attachment.Spec.PolicyName
+		}
+		if !strings.HasPrefix(claimValue, prefix) {
+			return violation(policy.Name, "OpenID claim value %q must start with %q", claimValue, prefix)
+		}
+	}
+	return nil
+}
+
+// checkAttachedPolicy checks that a policy attached by obj is owned by its namespace. Removing an
+// attachment grants nothing, so the policies of deleted resources are not checked.
+func checkAttachedPolicy(ctx context.Context, c client.Reader, policy, policyName string, obj client.Object, conn miniov1beta1.MinIOConnection) error {
+	if err := checkBuiltinPolicy(policy, policyName); err != nil {
+		return err
+	}
+	if obj.GetDeletionTimestamp() != nil {
+		return nil
+	}
+	return checkOwnedPolicy(ctx, c, policy, policyName, obj, conn)
+}
+
+// expandPrefix replaces the namespace placeholder in prefix
+func expandPrefix(prefix, namespace string) string {
+	return strings.ReplaceAll(prefix, miniov1beta1.TenantNamespacePlaceholder, namespace)
+}
+
+// checkConnection checks that a connection uses one of the aliases allowed by policy
+func checkConnection(policy *miniov1beta1.TenantPolicy, namespace string, annotations map[string]string, conn miniov1beta1.MinIOConnection) error {
+	if len(policy.Spec.AllowedAliases) == 0 {
+		return nil
+	}
+
+	for _, allowed := range policy.Spec.AllowedAliases {
+		switch allowed.Kind {
+		case "ClusterAlias":
+			if conn.ClusterAliasRef != nil && conn.ClusterAliasRef.Name == allowed.Name {
+				return nil
+			}
+		default:
+			if conn.AliasRef == nil || conn.AliasRef.Name != allowed.Name {
+				continue
+			}
+			if namespaceOrDefault(conn.AliasRef.Namespace, namespace) == namespaceOrDefault(allowed.Namespace, namespace) {
+				return nil
+			}
+		}
+	}
+
+	switch {
+	case conn.AliasRef != nil:
+		return violation(policy.Name, "alias %s/%s is not allowed", namespaceOrDefault(conn.AliasRef.Namespace, namespace), conn.AliasRef.Name)
+	case conn.ClusterAliasRef != nil:
+		return violation(policy.Name, "cluster alias %s is not allowed", conn.ClusterAliasRef.Name)
+	default:
+		return violation(policy.Name, "connections must use one of the allowed aliases")
+	}
+}
+
+// checkServerConnection checks the connection of a kind that changes the whole MinIO server. Cluster
+// aliases are shared with other tenants, so tenants may only change servers of their own aliases.
+func checkServerConnection(policy *miniov1beta1.TenantPolicy, kind, namespace string, annotations map[string]string, conn miniov1beta1.MinIOConnection) error {
+	if conn.ClusterAliasRef != nil {
+		return violation(policy.Name, "%s change the whole server and may not use cluster alias %s", kind, conn.ClusterAliasRef.Name)
+	}
+	return checkConnection(policy, namespace, annotations, conn)
+}
+
+// checkCount checks that obj is within the first max resources of its kind in its namespace. Resources
+// are ordered by creation, so that existing resources are not affected by resources created later.
+func checkCount(ctx context.Context, c client.Reader, policy string, max *int32, obj client.Object, list client.ObjectList) error {
+	if max == nil {
+		return nil
+	}
+
+	if err := c.List(ctx, list, client.InNamespace(obj.GetNamespace())); err != nil {
+		return fmt.Errorf("failed to list resources in namespace %s: %w", obj.GetNamespace(), err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract list items: %w", err)
+	}
+
+	older := 0
+	for _, i := range items {
+		item, ok := i.(client.Object)
+		if !ok {
+			continue
+		}
+		if item.GetName() == obj.GetName() || item.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if createdBefore(item, obj) {
+			older++
+		}
+	}
+	if older >= int(*max) {
+		return violation(policy, "namespace %s may not contain more than %d resources of this kind", obj.GetNamespace(), *max)
+	}
+	return nil
+}
+
+// CheckBucketClaim returns an Error with the ReasonBucketConflict reason if another Bucket created
+// before bucket manages a bucket with the same name on the same MinIO server
+func CheckBucketClaim(ctx context.Context, c client.Reader, bucket *miniov1beta1.Bucket) error {
+	list := &miniov1beta1.BucketList{}
+	if err := c.List(ctx, list, client.MatchingFields{BucketNameField: bucket.Spec.BucketName}); err != nil {
+		return fmt.Errorf("failed to list buckets: %w", err)
+	}
+
+	var target string
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.DeletionTimestamp != nil {
+			continue
+		}
+		if other.Namespace == bucket.Namespace && other.Name == bucket.Name {
+			continue
+		}
+		if !createdBefore(other, bucket) {
+			continue
+		}
+
+		if target == "" {
+			target = connectionTarget(ctx, c, bucket.Namespace, bucket.Annotations, bucket.Spec.Connection)
+		}
+		if connectionTarget(ctx, c, other.Namespace, other.Annotations, other.Spec.Connection) == target {
+			return &Error{
+				Reason:  ReasonBucketConflict,
+				Message: fmt.Sprintf("bucket %s is already claimed by Bucket %s/%s", bucket.Spec.BucketName, other.Namespace, other.Name),
+			}
+		}
+	}
+	return nil
+}
+
+// connectionTarget identifies the MinIO server of a connection by the host of its URL. The reference
+// itself is used if the URL cannot be resolved.
+func connectionTarget(ctx context.Context, c client.Reader, namespace string, annotations map[string]string, conn miniov1beta1.MinIOConnection) string {
+	var rawURL, ref string
+	switch {
+	case conn.AliasRef != nil:
+		key := client.ObjectKey{Name: conn.AliasRef.Name, Namespace: namespaceOrDefault(conn.AliasRef.Namespace, namespace)}
+		ref = "Alias/" + key.String()
+		alias := &miniov1beta1.Alias{}
+		if err := c.Get(ctx, key, alias); err == nil {
+			rawURL = alias.Spec.URL
+		}
+	case conn.ClusterAliasRef != nil:
+		ref = "ClusterAlias/" + conn.ClusterAliasRef.Name
+		alias := &miniov1beta1.ClusterAlias{}
+		if err := c.Get(ctx, client.ObjectKey{Name: conn.ClusterAliasRef.Name}, alias); err == nil {
+			rawURL = alias.Spec.URL
+		}
+	case conn.URL != nil:
+		rawURL = *conn.URL
+	default:
+		value, ok := annotations[miniov1beta1.LegacyEndpointRefAnnotation]
+		if !ok {
+			return ""
+		}
+		endpointRef := miniov1alpha1.ParseEndpointReference(value)
+		key := client.ObjectKey{Name: endpointRef.Name, Namespace: namespaceOrDefault(endpointRef.Namespace, namespace)}
+		ref = "Endpoint/" + key.String()
+		endpoint := &miniov1alpha1.Endpoint{}
+		if err := c.Get(ctx, key, endpoint); err == nil {
+			rawURL = endpoint.Spec.URL
+		}
+	}
+
+	if rawURL == "" {
+		return ref
+	}
+	if !strings.Contains(rawURL, "://") {
+		return strings.ToLower(rawURL)
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	return strings.ToLower(parsed.Host)
+}
+
+// createdBefore reports whether a was created before b. Objects that have not been created yet,
+// like the object of a create request, are ordered last.
+func createdBefore(a, b client.Object) bool {
+	aTime, bTime := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	switch {
+	case aTime.IsZero():
+		return false
+	case bTime.IsZero():
+		return true
+	case !aTime.Equal(&bTime):
+		return aTime.Before(&bTime)
+	}
+	return a.GetNamespace()+"/"+a.GetName() < b.GetNamespace()+"/"+b.GetName()
+}
+
+// namespaceOrDefault returns namespace if it is set, and defaultNamespace otherwise
+func namespaceOrDefault(namespace *string, defaultNamespace string) string {
+	if namespace != nil {
+		return *namespace
+	}
+	return defaultNamespace
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Tenant policies", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		policy *miniov1beta1.TenantPolicy
+		ns     *corev1.Namespace
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(miniov1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+		maxBuckets := int32(1)
+		policy = &miniov1beta1.TenantPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "teams"},
+			Spec: miniov1beta1.TenantPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "true"}},
+				BucketPrefix:      "{namespace}-",
+				UsernamePrefix:    "{namespace}-",
+				AllowedAliases:    []miniov1beta1.TenantAliasReference{{Kind: "ClusterAlias", Name: "shared"}},
+				MaxBuckets:        &maxBuckets,
+			},
+		}
+		ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"tenant": "true"}}}
+	})
+
+	newFakeClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithIndex(&miniov1beta1.Bucket{}, BucketNameField, IndexBucketName).
+			WithIndex(&miniov1beta1.Policy{}, PolicyNameField, IndexPolicyName).
+			Build()
+	}
+
+	newBucket := func(namespace, name, bucketName string, created time.Time) *miniov1beta1.Bucket {
+		return &miniov1beta1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(created)},
+			Spec: miniov1beta1.BucketSpec{
+				Connection: miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared"}},
+				BucketName: bucketName,
+			},
+		}
+	}
+
+	expectReason := func(err error, reason string) {
+		var tenantErr *Error
+		Expect(errors.As(err, &tenantErr)).To(BeTrue(), "expected a tenant error, got %v", err)
+		Expect(tenantErr.Reason).To(Equal(reason))
+	}
+
+	It("should admit a bucket that follows the policy", func() {
+		bucket := newBucket("team-a", "data", "team-a-data", time.Time{})
+		Expect(Check(ctx, newFakeClient(policy, ns), bucket)).To(Succeed())
+	})
+
+	It("should not apply policies to namespaces outside of the selector", func() {
+		other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}}
+		bucket := newBucket("team-b", "data", "data", time.Time{})
+		Expect(Check(ctx, newFakeClient(policy, other), bucket)).To(Succeed())
+	})
+
+	It("should reject a bucket name without the prefix", func() {
+		bucket := newBucket("team-a", "data", "team-b-data", time.Time{})
+		expectReason(Check(ctx, newFakeClient(policy, ns), bucket), ReasonPolicyViolation)
+	})
+
+	It("should reject a username without the prefix", func() {
+		user := &miniov1beta1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+			Spec: miniov1beta1.UserSpec{
+				Connection: miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared"}},
+				Username:   "admin",
+			},
+		}
+		expectReason(Check(ctx, newFakeClient(policy, ns), user), ReasonPolicyViolation)
+	})
+
+	It("should reject connections to aliases that are not allowed", func() {
+		bucket := newBucket("team-a", "data", "team-a-data", time.Time{})
+		bucket.Spec.Connection = miniov1beta1.MinIOConnection{AliasRef: &miniov1beta1.AliasReference{Name: "private"}}
+		expectReason(Check(ctx, newFakeClient(policy, ns), bucket), ReasonPolicyViolation)
+	})
+
+	It("should reject server-wide resources connecting through cluster aliases", func() {
+		shared := miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared"}}
+		objectMeta := metav1.ObjectMeta{Name: "server", Namespace: "team-a"}
+		for _, obj := range []client.Object{
+			&miniov1beta1.Tier{ObjectMeta: objectMeta, Spec: miniov1beta1.TierSpec{Connection: shared}},
+			&miniov1beta1.NotificationTarget{ObjectMeta: objectMeta, Spec: miniov1beta1.NotificationTargetSpec{Connection: shared}},
+			&miniov1beta1.ServerConfig{ObjectMeta: objectMeta, Spec: miniov1beta1.ServerConfigSpec{Connection: shared}},
+			&miniov1beta1.IdentityProvider{ObjectMeta: objectMeta, Spec: miniov1beta1.IdentityProviderSpec{Connection: shared}},
+		} {
+			expectReason(Check(ctx, newFakeClient(policy, ns), obj), ReasonPolicyViolation)
+		}
+
+		policy.Spec.AllowedAliases = append(policy.Spec.AllowedAliases, miniov1beta1.TenantAliasReference{Name: "own"})
+		own := &miniov1beta1.ServerConfig{ObjectMeta: objectMeta, Spec: miniov1beta1.ServerConfigSpec{
+			Connection: miniov1beta1.MinIOConnection{AliasRef: &miniov1beta1.AliasReference{Name: "own"}},
+		}}
+		Expect(Check(ctx, newFakeClient(policy, ns), own)).To(Succeed())
+	})
+
+	It("should reject buckets beyond the maximum count but keep existing ones", func() {
+		now := time.Now()
+		existing := newBucket("team-a", "first", "team-a-first", now.Add(-time.Hour))
+		later := newBucket("team-a", "second", "team-a-second", now)
+		c := newFakeClient(policy, ns, existing, later)
+
+		Expect(Check(ctx, c, existing)).To(Succeed())
+		expectReason(Check(ctx, c, later), ReasonPolicyViolation)
+		expectReason(Check(ctx, c, newBucket("team-a", "third", "team-a-third", time.Time{})), ReasonPolicyViolation)
+	})
+
+	It("should detect two buckets claiming the same bucket on the same server", func() {
+		url := "https://minio.example.com"
+		alias := &miniov1beta1.ClusterAlias{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+			Spec:       miniov1beta1.ClusterAliasSpec{URL: url},
+		}
+		now := time.Now()
+		first := newBucket("team-a", "data", "shared-data", now.Add(-time.Hour))
+		second := newBucket("team-b", "data", "shared-data", now)
+		second.Spec.Connection = miniov1beta1.MinIOConnection{URL: &url}
+		c := newFakeClient(alias, first, second)
+
+		Expect(CheckBucketClaim(ctx, c, first)).To(Succeed())
+		expectReason(CheckBucketClaim(ctx, c, second), ReasonBucketConflict)
+	})
+
+	It("should allow buckets with the same name on different servers", func() {
+		otherURL := "https://other.example.com"
+		now := time.Now()
+		first := newBucket("team-a", "data", "shared-data", now.Add(-time.Hour))
+		second := newBucket("team-b", "data", "shared-data", now)
+		second.Spec.Connection = miniov1beta1.MinIOConnection{URL: &otherURL}
+		c := newFakeClient(first, second)
+
+		Expect(CheckBucketClaim(ctx, c, second)).To(Succeed())
+	})
+	Context("When checking policies and attachments", func() {
+		shared := miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared"}}
+
+		newPolicy := func(namespace, policyName, document string, created time.Time) *miniov1beta1.Policy {
+			return &miniov1beta1.Policy{
+				ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: namespace, CreationTimestamp: metav1.NewTime(created)},
+				Spec: miniov1beta1.PolicySpec{
+					Connection: shared,
+					PolicyName: policyName,
+					Policy:     []byte(document),
+				},
+			}
+		}
+		newAttachment := func(policyName string, target miniov1beta1.PolicyAttachmentTarget) *miniov1beta1.PolicyAttachment {
+			return &miniov1beta1.PolicyAttachment{
+				ObjectMeta: metav1.ObjectMeta{Name: "attach", Namespace: "team-a"},
+				Spec: miniov1beta1.PolicyAttachmentSpec{
+					Connection: shared,
+					PolicyName: policyName,
+					Target:     target,
+				},
+			}
+		}
+		ptr := func(value string) *string { return &value }
+
+		It("should admit policies on buckets with the prefix", func() {
+			readers := newPolicy("team-a", "team-a-readers", `{"Version":"2012-10-17","Statement":[
+				{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::team-a-data/*","arn:aws:s3:::team-a-logs*"]},
+				{"Effect":"Deny","Action":"admin:*","Resource":"arn:aws:s3:::*"}]}`, time.Time{})
+			Expect(Check(ctx, newFakeClient(policy, ns), readers)).To(Succeed())
+		})
+
+		DescribeTable("should reject policies granting more than the tenant's buckets",
+			func(statement string) {
+				readers := newPolicy("team-a", "team-a-readers", `{"Version":"2012-10-17","Statement":[`+statement+`]}`, time.Time{})
+				expectReason(Check(ctx, newFakeClient(policy, ns), readers), ReasonPolicyViolation)
+			},
+			Entry("all buckets", `{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::*"}`),
+			Entry("a wildcard before the prefix", `{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::team-*"}`),
+			Entry("a policy variable", `{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::${aws:username}/*"}`),
+			Entry("another tenant's bucket", `{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::team-b-data"}`),
+			Entry("admin actions", `{"Effect":"Allow","Action":"admin:*","Resource":"arn:aws:s3:::team-a-data"}`),
+			Entry("NotResource", `{"Effect":"Allow","Action":"s3:*","NotResource":"arn:aws:s3:::team-b-data"}`),
+			Entry("no resources", `{"Effect":"Allow","Action":"s3:*"}`),
+		)
+
+		It("should reject overwriting built-in policies", func() {
+			readwrite := newPolicy("team-a", "readwrite", `{"Version":"2012-10-17","Statement":[]}`, time.Time{})
+			expectReason(Check(ctx, newFakeClient(policy, ns), readwrite), ReasonPolicyViolation)
+		})
+
+		It("should admit attaching an owned policy to a user with the prefix", func() {
+			owned := newPolicy("team-a", "team-a-readers", `{"Version":"2012-10-17","Statement":[]}`, time.Now())
+			attachment := newAttachment("team-a-readers", miniov1beta1.PolicyAttachmentTarget{User: ptr("team-a-app")})
+			Expect(Check(ctx, newFakeClient(policy, ns, owned), attachment)).To(Succeed())
+		})
+
+		It("should admit OpenID claim values with the prefix", func() {
+			owned := newPolicy("team-a", "team-a-readers", `{"Version":"2012-10-17","Statement":[]}`, time.Now())
+			c := newFakeClient(policy, ns, owned)
+			keycloak := miniov1beta1.IdentityProviderReference{Name: "keycloak"}
+
+			attachment := newAttachment("team-a-readers", miniov1beta1.PolicyAttachmentTarget{
+				OpenIDClaim: &miniov1beta1.OpenIDClaimTarget{IdentityProviderRef: keycloak},
+			})
+			Expect(Check(ctx, c, attachment)).To(Succeed())
+
+			attachment.Spec.Target.OpenIDClaim.ClaimValue = "team-a-developers"
+			Expect(Check(ctx, c, attachment)).To(Succeed())
+		})
+
+		It("should reject attaching built-in policies", func() {
+			attachment := newAttachment("consoleAdmin", miniov1beta1.PolicyAttachmentTarget{User: ptr("team-a-app")})
+			expectReason(Check(ctx, newFakeClient(policy, ns), attachment), ReasonPolicyViolation)
+
+			user := &miniov1beta1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+				Spec:       miniov1beta1.UserSpec{Connection: shared, Username: "team-a-app", Policies: []string{"readwrite"}},
+			}
+			expectReason(Check(ctx, newFakeClient(policy, ns), user), ReasonPolicyViolation)
+		})
+
+		It("should reject attaching policies of other namespaces", func() {
+			now := time.Now()
+			foreign := newPolicy("team-b", "team-b-readers", `{"Version":"2012-10-17","Statement":[]}`, now)
+			attachment := newAttachment("team-b-readers", miniov1beta1.PolicyAttachmentTarget{User: ptr("team-a-app")})
+			expectReason(Check(ctx, newFakeClient(policy, ns, foreign), attachment), ReasonPolicyViolation)
+
+			// A Policy naming a canned policy another namespace claimed first does not own it
+			claimed := newPolicy("team-a", "team-b-readers", `{"Version":"2012-10-17","Statement":[]}`, now.Add(time.Hour))
+			expectReason(Check(ctx, newFakeClient(policy, ns, foreign, claimed), attachment), ReasonPolicyViolation)
+		})
+
+		It("should reject targets that are not owned by the tenant", func() {
+			owned := newPolicy("team-a", "team-a-readers", `{"Version":"2012-10-17","Statement":[]}`, time.Now())
+			c := newFakeClient(policy, ns, owned)
+			for _, target := range []miniov1beta1.PolicyAttachmentTarget{
+				{User: ptr("admin")},
+				{Group: ptr("admins")},
+				{LDAPUser: ptr("cn=admin,dc=example,dc=com")},
+				{OpenIDClaim: &miniov1beta1.OpenIDClaimTarget{
+					IdentityProviderRef: miniov1beta1.IdentityProviderReference{Name: "keycloak"},
+					ClaimValue:          "consoleAdmin",
+				}},
+			} {
+				expectReason(Check(ctx, c, newAttachment("team-a-readers", target)), ReasonPolicyViolation)
+			}
+
+			user := &miniov1beta1.User{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+				Spec:       miniov1beta1.UserSpec{Connection: shared, Username: "team-a-app", Groups: []string{"admins"}},
+			}
+			expectReason(Check(ctx, c, user), ReasonPolicyViolation)
+		})
+	})
+})
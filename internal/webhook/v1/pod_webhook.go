@@ -0,0 +1,201 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1 contains the admission webhooks for core Kubernetes resources
+package v1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+const (
+	// caBundleVolume is the name of the downward API volume holding the injected CA bundle
+	caBundleVolume = "mc-controller-ca-bundle"
+	// caBundleMountPath is the directory the CA bundle is mounted into
+	caBundleMountPath = "/var/run/secrets/mc-controller/ca"
+	// caBundleFile is the file name of the CA bundle in its volume
+	caBundleFile = "ca.crt"
+)
+
+// SetupPodWebhookWithManager registers the webhook injecting MinIO connections into pods in the manager
+func SetupPodWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&corev1.Pod{}).
+		WithDefaulter(&PodCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// Pods are intercepted cluster-wide, so failures to call the webhook must not block workloads. Pods
+// referencing a bucket that cannot be resolved are still rejected. The marker cannot express selectors:
+// config/webhook/pod_webhook_patch.yaml and the chart only send pods labeled with InjectLabel outside
+// of the controller's namespace to the webhook.
+//+kubebuilder:webhook:path=/mutate--v1-pod,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mpod-v1.kb.io,admissionReviewVersions=v1
+
+// PodCustomDefaulter injects the connection of a Bucket and the keys of a credentials secret into annotated pods
+type PodCustomDefaulter struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomDefaulter = &PodCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *PodCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return fmt.Errorf("expected a Pod object but got %T", obj)
+	}
+
+	// Pods have to opt in, even if the webhook configuration was installed without the object selector
+	if pod.Labels[miniov1beta1.InjectLabel] != "true" {
+		return nil
+	}
+	bucketName := pod.Annotations[miniov1beta1.InjectBucketAnnotation]
+	secretName := pod.Annotations[miniov1beta1.InjectCredentialsAnnotation]
+	if bucketName == "" && secretName == "" {
+		return nil
+	}
+
+	// Pods created by controllers have no namespace set yet
+	namespace := pod.Namespace
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Namespace != "" {
+		namespace = req.Namespace
+	}
+
+	var env []corev1.EnvVar
+	var caBundle bool
+	if bucketName != "" {
+		bucket := &miniov1beta1.Bucket{}
+		if err := d.Client.Get(ctx, client.ObjectKey{Name: bucketName, Namespace: namespace}, bucket); err != nil {
+			return fmt.Errorf("failed to get bucket %s/%s: %w", namespace, bucketName, err)
+		}
+		endpoint, err := minioclient.ResolveEndpoint(ctx, d.Client, bucket.Spec.Connection, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to resolve the connection of bucket %s/%s: %w", namespace, bucketName, err)
+		}
+
+		env = append(env,
+			corev1.EnvVar{Name: "AWS_ENDPOINT_URL", Value: endpoint.URL},
+			corev1.EnvVar{Name: "AWS_REGION", Value: endpoint.Region},
+			corev1.EnvVar{Name: "BUCKET_NAME", Value: bucket.Spec.BucketName},
+		)
+		if len(endpoint.CABundle) > 0 {
+			// The CA bundle is passed through an annotation of the pod itself, so no secret or config map
+			// has to exist in the pod's namespace
+			pod.Annotations[miniov1beta1.InjectedCABundleAnnotation] = string(endpoint.CABundle)
+			env = append(env, corev1.EnvVar{Name: "AWS_CA_BUNDLE", Value: caBundleMountPath + "/" + caBundleFile})
+			caBundle = true
+		}
+	}
+	if secretName != "" {
+		env = append(env,
+			secretEnvVar("AWS_ACCESS_KEY_ID", secretName, annotationOrDefault(pod.Annotations,
+				miniov1beta1.InjectAccessKeyIDKeyAnnotation, miniov1beta1.DefaultAccessKeyIDKey)),
+			secretEnvVar("AWS_SECRET_ACCESS_KEY", secretName, annotationOrDefault(pod.Annotations,
+				miniov1beta1.InjectSecretAccessKeyKeyAnnotation, miniov1beta1.DefaultSecretAccessKeyKey)),
+		)
+	}
+
+	if caBundle && !slices.ContainsFunc(pod.Spec.Volumes, func(volume corev1.Volume) bool { return volume.Name == caBundleVolume }) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: caBundleVolume,
+			VolumeSource: corev1.VolumeSource{
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{{
+						Path: caBundleFile,
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: fmt.Sprintf("metadata.annotations['%s']", miniov1beta1.InjectedCABundleAnnotation),
+						},
+					}},
+				},
+			},
+		})
+	}
+
+	selected := injectedContainers(pod.Annotations[miniov1beta1.InjectContainersAnnotation])
+	for i := range pod.Spec.InitContainers {
+		if selected != nil && selected[pod.Spec.InitContainers[i].Name] {
+			injectContainer(&pod.Spec.InitContainers[i], env, caBundle)
+		}
+	}
+	for i := range pod.Spec.Containers {
+		if selected == nil || selected[pod.Spec.Containers[i].Name] {
+			injectContainer(&pod.Spec.Containers[i], env, caBundle)
+		}
+	}
+	return nil
+}
+
+// secretEnvVar returns an environment variable referencing a key of a secret
+func secretEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// annotationOrDefault returns the value of an annotation, or defaultValue if it is not set
+func annotationOrDefault(annotations map[string]string, name, defaultValue string) string {
+	if value := strings.TrimSpace(annotations[name]); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// injectedContainers parses the containers to inject, nil selects all containers but no init containers
+func injectedContainers(annotation string) map[string]bool {
+	if strings.TrimSpace(annotation) == "" {
+		return nil
+	}
+	selected := map[string]bool{}
+	for _, name := range strings.Split(annotation, ",") {
+		selected[strings.TrimSpace(name)] = true
+	}
+	return selected
+}
+
+// injectContainer adds the environment variables and the CA bundle mount to a container. Variables the
+// container already defines are kept.
+func injectContainer(container *corev1.Container, env []corev1.EnvVar, caBundle bool) {
+	for _, envVar := range env {
+		if !slices.ContainsFunc(container.Env, func(existing corev1.EnvVar) bool { return existing.Name == envVar.Name }) {
+			container.Env = append(container.Env, envVar)
+		}
+	}
+	if caBundle && !slices.ContainsFunc(container.VolumeMounts, func(mount corev1.VolumeMount) bool { return mount.Name == caBundleVolume }) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      caBundleVolume,
+			MountPath: caBundleMountPath,
+			ReadOnly:  true,
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Pod Webhook", func() {
+	var (
+		ctx       context.Context
+		scheme    *runtime.Scheme
+		alias     *miniov1beta1.Alias
+		bucket    *miniov1beta1.Bucket
+		pod       *corev1.Pod
+		defaulter PodCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		// Pods created by controllers carry their namespace only in the admission request
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{Namespace: "team-a"},
+		})
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+
+		region := "eu-central-1"
+		alias = &miniov1beta1.Alias{
+			ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "team-a"},
+			Spec: miniov1beta1.AliasSpec{
+				URL:       "https://minio.example.com",
+				SecretRef: miniov1beta1.SecretReference{Name: "minio-admin"},
+				Region:    &region,
+				TLS:       &miniov1beta1.TLSConfig{CABundle: []byte("-----BEGIN CERTIFICATE-----")},
+			},
+		}
+		bucket = &miniov1beta1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "uploads", Namespace: "team-a"},
+			Spec: miniov1beta1.BucketSpec{
+				Connection: miniov1beta1.MinIOConnection{AliasRef: &miniov1beta1.AliasReference{Name: "minio"}},
+				BucketName: "team-a-uploads",
+			},
+		}
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "app-",
+				Labels:       map[string]string{miniov1beta1.InjectLabel: "true"},
+				Annotations: map[string]string{
+					miniov1beta1.InjectBucketAnnotation:      "uploads",
+					miniov1beta1.InjectCredentialsAnnotation: "uploads-credentials",
+				},
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "migrate"}},
+				Containers: []corev1.Container{
+					{Name: "app", Env: []corev1.EnvVar{{Name: "AWS_REGION", Value: "us-west-2"}}},
+					{Name: "sidecar"},
+				},
+			},
+		}
+	})
+
+	newDefaulter := func(objs ...client.Object) PodCustomDefaulter {
+		return PodCustomDefaulter{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()}
+	}
+
+	envValue := func(container corev1.Container, name string) *corev1.EnvVar {
+		for i := range container.Env {
+			if container.Env[i].Name == name {
+				return &container.Env[i]
+			}
+		}
+		return nil
+	}
+
+	Context("When creating an annotated pod", func() {
+		BeforeEach(func() {
+			defaulter = newDefaulter(alias, bucket)
+		})
+
+		It("should inject the connection of the bucket", func() {
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+
+			sidecar := pod.Spec.Containers[1]
+			Expect(envValue(sidecar, "AWS_ENDPOINT_URL").Value).To(Equal("https://minio.example.com"))
+			Expect(envValue(sidecar, "AWS_REGION").Value).To(Equal("eu-central-1"))
+			Expect(envValue(sidecar, "BUCKET_NAME").Value).To(Equal("team-a-uploads"))
+			Expect(envValue(sidecar, "AWS_CA_BUNDLE").Value).To(Equal("/var/run/secrets/mc-controller/ca/ca.crt"))
+			Expect(sidecar.VolumeMounts).To(ConsistOf(HaveField("Name", caBundleVolume)))
+			Expect(pod.Annotations).To(HaveKeyWithValue(miniov1beta1.InjectedCABundleAnnotation, "-----BEGIN CERTIFICATE-----"))
+			Expect(pod.Spec.Volumes).To(ConsistOf(HaveField("VolumeSource.DownwardAPI.Items", ConsistOf(
+				HaveField("FieldRef.FieldPath", "metadata.annotations['mc-controller.mxcd.de/ca-bundle']")))))
+		})
+
+		It("should reference the keys of the credentials secret instead of inlining them", func() {
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+
+			for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"} {
+				envVar := envValue(pod.Spec.Containers[0], name)
+				Expect(envVar).NotTo(BeNil())
+				Expect(envVar.Value).To(BeEmpty())
+				Expect(envVar.ValueFrom.SecretKeyRef.Name).To(Equal("uploads-credentials"))
+			}
+			Expect(envValue(pod.Spec.Containers[0], "AWS_SECRET_ACCESS_KEY").ValueFrom.SecretKeyRef.Key).To(Equal(miniov1beta1.DefaultSecretAccessKeyKey))
+		})
+
+		It("should reference the keys named by the annotations", func() {
+			pod.Annotations[miniov1beta1.InjectAccessKeyIDKeyAnnotation] = "AWS_ACCESS_KEY_ID"
+			pod.Annotations[miniov1beta1.InjectSecretAccessKeyKeyAnnotation] = "AWS_SECRET_ACCESS_KEY"
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+
+			Expect(envValue(pod.Spec.Containers[0], "AWS_ACCESS_KEY_ID").ValueFrom.SecretKeyRef.Key).To(Equal("AWS_ACCESS_KEY_ID"))
+			Expect(envValue(pod.Spec.Containers[0], "AWS_SECRET_ACCESS_KEY").ValueFrom.SecretKeyRef.Key).To(Equal("AWS_SECRET_ACCESS_KEY"))
+		})
+
+		It("should keep variables defined by the container", func() {
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+			Expect(envValue(pod.Spec.Containers[0], "AWS_REGION").Value).To(Equal("us-west-2"))
+		})
+
+		It("should only inject the selected containers", func() {
+			pod.Annotations[miniov1beta1.InjectContainersAnnotation] = "migrate, app"
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+
+			Expect(envValue(pod.Spec.InitContainers[0], "BUCKET_NAME")).NotTo(BeNil())
+			Expect(envValue(pod.Spec.Containers[0], "BUCKET_NAME")).NotTo(BeNil())
+			Expect(pod.Spec.Containers[1].Env).To(BeEmpty())
+		})
+
+		It("should not inject init containers by default", func() {
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+			Expect(pod.Spec.InitContainers[0].Env).To(BeEmpty())
+		})
+
+		It("should only inject credentials without a bucket", func() {
+			delete(pod.Annotations, miniov1beta1.InjectBucketAnnotation)
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+
+			Expect(envValue(pod.Spec.Containers[1], "AWS_ACCESS_KEY_ID")).NotTo(BeNil())
+			Expect(envValue(pod.Spec.Containers[1], "AWS_ENDPOINT_URL")).To(BeNil())
+			Expect(pod.Spec.Volumes).To(BeEmpty())
+		})
+
+		It("should reject a pod referencing a missing bucket", func() {
+			pod.Annotations[miniov1beta1.InjectBucketAnnotation] = "missing"
+			Expect(defaulter.Default(ctx, pod)).NotTo(Succeed())
+		})
+
+		It("should reject a bucket using an alias of another namespace without a grant", func() {
+			otherNamespace := "minio-system"
+			alias.Namespace = otherNamespace
+			bucket.Spec.Connection.AliasRef.Namespace = &otherNamespace
+			defaulter = newDefaulter(alias, bucket)
+			Expect(defaulter.Default(ctx, pod)).NotTo(Succeed())
+		})
+	})
+
+	Context("When creating a pod without annotations", func() {
+		It("should leave the pod unchanged", func() {
+			defaulter = newDefaulter()
+			pod.Annotations = nil
+			original := pod.DeepCopy()
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+			Expect(pod).To(Equal(original))
+		})
+	})
+
+	Context("When creating an annotated pod without the opt-in label", func() {
+		It("should leave the pod unchanged", func() {
+			defaulter = newDefaulter(alias, bucket)
+			pod.Labels = nil
+			original := pod.DeepCopy()
+			Expect(defaulter.Default(ctx, pod)).To(Succeed())
+			Expect(pod).To(Equal(original))
+		})
+	})
+})
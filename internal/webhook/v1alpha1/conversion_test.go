@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Conversion", func() {
+	It("should keep a deprecated endpointRef across a round trip", func() {
+		namespace := "minio-system"
+		bucket := &miniov1alpha1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default"},
+			Spec: miniov1alpha1.BucketSpec{
+				Connection: miniov1alpha1.MinIOConnection{
+					EndpointRef: &miniov1alpha1.EndpointReference{Name: "minio", Namespace: &namespace},
+				},
+				BucketName: "test-bucket",
+			},
+		}
+
+		hub := &miniov1beta1.Bucket{}
+		Expect(bucket.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Annotations).To(HaveKeyWithValue(miniov1beta1.LegacyEndpointRefAnnotation, "minio-system/minio"))
+		Expect(bucket.Annotations).To(BeEmpty())
+
+		restored := &miniov1alpha1.Bucket{}
+		Expect(restored.ConvertFrom(hub)).To(Succeed())
+		Expect(restored.Spec).To(Equal(bucket.Spec))
+		Expect(restored.Annotations).To(BeEmpty())
+	})
+
+	It("should keep the drift of a bucket across a round trip", func() {
+		bucket := &miniov1alpha1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default"},
+			Spec: miniov1alpha1.BucketSpec{
+				BucketName:       "test-bucket",
+				ManagementPolicy: miniov1alpha1.ManagementPolicyFullNoDelete,
+				DriftPolicy:      miniov1alpha1.DriftPolicyReport,
+			},
+			Status: miniov1alpha1.BucketStatus{
+				Drift: []miniov1alpha1.DriftEntry{{Field: "versioning", Desired: "Enabled", Actual: "Suspended"}},
+			},
+		}
+
+		hub := &miniov1beta1.Bucket{}
+		Expect(bucket.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.Drift).To(Equal([]miniov1beta1.DriftEntry{{Field: "versioning", Desired: "Enabled", Actual: "Suspended"}}))
+
+		restored := &miniov1alpha1.Bucket{}
+		Expect(restored.ConvertFrom(hub)).To(Succeed())
+		Expect(restored.Spec).To(Equal(bucket.Spec))
+		Expect(restored.Status).To(Equal(bucket.Status))
+	})
+
+	It("should keep the notification target of a bucket across a round trip", func() {
+		bucket := &miniov1alpha1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default"},
+			Spec: miniov1alpha1.BucketSpec{
+				BucketName: "test-bucket",
+				Notification: &miniov1alpha1.BucketNotification{
+					Events:    []string{"s3:ObjectCreated:*"},
+					TargetRef: &miniov1alpha1.NotificationTargetReference{Name: "webhook"},
+				},
+			},
+		}
+
+		hub := &miniov1beta1.Bucket{}
+		Expect(bucket.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.Notification.TargetRef.Name).To(Equal("webhook"))
+
+		restored := &miniov1alpha1.Bucket{}
+		Expect(restored.ConvertFrom(hub)).To(Succeed())
+		Expect(restored.Spec).To(Equal(bucket.Spec))
+	})
+
+	It("should keep the OpenID claim of a policy attachment across a round trip", func() {
+		attachment := &miniov1alpha1.PolicyAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "developers", Namespace: "default"},
+			Spec: miniov1alpha1.PolicyAttachmentSpec{
+				PolicyName: "readwrite",
+				Target: miniov1alpha1.PolicyAttachmentTarget{
+					OpenIDClaim: &miniov1alpha1.OpenIDClaimTarget{
+						IdentityProviderRef: miniov1alpha1.IdentityProviderReference{Name: "keycloak"},
+						ClaimValue:          "developers",
+					},
+				},
+			},
+		}
+
+		hub := &miniov1beta1.PolicyAttachment{}
+		Expect(attachment.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.Target.OpenIDClaim.IdentityProviderRef.Name).To(Equal("keycloak"))
+
+		restored := &miniov1alpha1.PolicyAttachment{}
+		Expect(restored.ConvertFrom(hub)).To(Succeed())
+		Expect(restored.Spec).To(Equal(attachment.Spec))
+	})
+
+	It("should keep the credential source of an alias across a round trip", func() {
+		duration := int32(3600)
+		alias := &miniov1alpha1.Alias{
+			ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "default"},
+			Spec: miniov1alpha1.AliasSpec{
+				URL: "https://minio.example.com",
+				Credentials: &miniov1alpha1.AliasCredentials{
+					Source:          miniov1alpha1.CredentialSourceWebIdentity,
+					RoleARN:         "arn:minio:iam:::role/operator",
+					DurationSeconds: &duration,
+				},
+			},
+		}
+
+		hub := &miniov1beta1.Alias{}
+		Expect(alias.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.Credentials.Source).To(Equal(miniov1beta1.CredentialSourceWebIdentity))
+
+		restored := &miniov1alpha1.Alias{}
+		Expect(restored.ConvertFrom(hub)).To(Succeed())
+		Expect(restored.Spec).To(Equal(alias.Spec))
+	})
+
+	It("should keep a plaintext password across a round trip", func() {
+		password := "secret"
+		user := &miniov1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-user", Namespace: "default"},
+			Spec: miniov1alpha1.UserSpec{
+				Connection: miniov1alpha1.MinIOConnection{
+					AliasRef: &miniov1alpha1.AliasReference{Name: "minio"},
+				},
+				Username: "test-user",
+				Password: &password,
+			},
+		}
+
+		hub := &miniov1beta1.User{}
+		Expect(user.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.SecretRef).To(BeNil())
+		Expect(hub.Annotations).To(HaveKeyWithValue(miniov1beta1.LegacyPasswordAnnotation, "secret"))
+
+		restored := &miniov1alpha1.User{}
+		Expect(restored.ConvertFrom(hub)).To(Succeed())
+		Expect(restored.Spec).To(Equal(user.Spec))
+	})
+
+	It("should map the password secret key to passwordKey", func() {
+		user := &miniov1alpha1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-user", Namespace: "default"},
+			Spec: miniov1alpha1.UserSpec{
+				Username: "test-user",
+				SecretRef: &miniov1alpha1.SecretReference{
+					Name:               "test-user-password",
+					SecretAccessKeyKey: "pw",
+				},
+			},
+		}
+
+		hub := &miniov1beta1.User{}
+		Expect(user.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.SecretRef).To(Equal(&miniov1beta1.UserSecretReference{Name: "test-user-password", PasswordKey: "pw"}))
+	})
+
+	It("should convert nested lifecycle rules", func() {
+		prefix := "logs/"
+		days := 30
+		lifecyclePolicy := &miniov1alpha1.LifecyclePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-lifecycle", Namespace: "default"},
+			Spec: miniov1alpha1.LifecyclePolicySpec{
+				BucketName: "test-bucket",
+				Rules: []miniov1alpha1.LifecycleRule{{
+					ID:     "expire-logs",
+					Status: miniov1alpha1.LifecycleRuleStatusEnabled,
+					Filter: &miniov1alpha1.LifecycleFilter{
+						And: &miniov1alpha1.LifecycleFilterAnd{Prefix: &prefix, Tags: map[string]string{"type": "log"}},
+					},
+					Expiration:  &miniov1alpha1.LifecycleExpiration{Days: &days},
+					Transitions: []miniov1alpha1.LifecycleTransition{{Days: &days, StorageClass: "GLACIER"}},
+				}},
+			},
+		}
+
+		hub := &miniov1beta1.LifecyclePolicy{}
+		Expect(lifecyclePolicy.ConvertTo(hub)).To(Succeed())
+
+		restored := &miniov1alpha1.LifecyclePolicy{}
+		Expect(restored.ConvertFrom(hub)).To(Succeed())
+		Expect(restored.Spec).To(Equal(lifecyclePolicy.Spec))
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+)
+
+// SetupEndpointWebhookWithManager registers the webhooks for Endpoint in the manager
+func SetupEndpointWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1alpha1.Endpoint{}).
+		WithValidator(&EndpointCustomValidator{}).
+		WithDefaulter(&EndpointCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1alpha1-endpoint,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=endpoints,verbs=create;update,versions=v1alpha1,name=mendpoint-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// EndpointCustomDefaulter sets default values on Endpoint resources
+type EndpointCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &EndpointCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *EndpointCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	endpoint, ok := obj.(*miniov1alpha1.Endpoint)
+	if !ok {
+		return fmt.Errorf("expected an Endpoint object but got %T", obj)
+	}
+
+	defaultSecretReference(&endpoint.Spec.SecretRef)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1alpha1-endpoint,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=endpoints,verbs=create;update,versions=v1alpha1,name=vendpoint-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// EndpointCustomValidator validates Endpoint resources
+type EndpointCustomValidator struct{}
+
+var _ webhook.CustomValidator = &EndpointCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *EndpointCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	endpoint, ok := obj.(*miniov1alpha1.Endpoint)
+	if !ok {
+		return nil, fmt.Errorf("expected an Endpoint object but got %T", obj)
+	}
+
+	return admission.Warnings{"Endpoint is deprecated, use Alias instead or set spec.migration.enabled to migrate"}, invalid("Endpoint", endpoint.Name, validateEndpoint(endpoint))
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *EndpointCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	endpoint, ok := newObj.(*miniov1alpha1.Endpoint)
+	if !ok {
+		return nil, fmt.Errorf("expected an Endpoint object but got %T", newObj)
+	}
+
+	return admission.Warnings{"Endpoint is deprecated, use Alias instead or set spec.migration.enabled to migrate"}, invalid("Endpoint", endpoint.Name, validateEndpoint(endpoint))
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *EndpointCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateEndpoint validates the spec of an Endpoint
+func validateEndpoint(endpoint *miniov1alpha1.Endpoint) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateURL(endpoint.Spec.URL, specPath.Child("url"))
+	allErrs = append(allErrs, validateSecretReference(endpoint.Spec.SecretRef, specPath.Child("secretRef"))...)
+	if migration := endpoint.Spec.Migration; migration != nil && migration.AliasName != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*migration.AliasName) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("migration", "aliasName"), *migration.AliasName, msg))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+)
+
+var _ = Describe("Endpoint Webhook", func() {
+	var (
+		ctx       context.Context
+		endpoint  *miniov1alpha1.Endpoint
+		validator EndpointCustomValidator
+		defaulter EndpointCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		endpoint = &miniov1alpha1.Endpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "default"},
+			Spec: miniov1alpha1.EndpointSpec{
+				URL:       "https://minio.example.com",
+				SecretRef: miniov1alpha1.SecretReference{Name: "minio-credentials"},
+			},
+		}
+	})
+
+	It("should admit a valid endpoint with a deprecation warning", func() {
+		warnings, err := validator.ValidateCreate(ctx, endpoint)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+	})
+
+	It("should reject an endpoint without a secret name", func() {
+		endpoint.Spec.SecretRef.Name = ""
+		_, err := validator.ValidateCreate(ctx, endpoint)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an endpoint with an unsupported URL scheme", func() {
+		endpoint.Spec.URL = "ftp://minio.example.com"
+		_, err := validator.ValidateCreate(ctx, endpoint)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an invalid migration alias name", func() {
+		aliasName := "Invalid_Name"
+		endpoint.Spec.Migration = &miniov1alpha1.EndpointMigration{Enabled: true, AliasName: &aliasName}
+		_, err := validator.ValidateCreate(ctx, endpoint)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should default the secret keys", func() {
+		Expect(defaulter.Default(ctx, endpoint)).To(Succeed())
+		Expect(endpoint.Spec.SecretRef.AccessKeyIDKey).To(Equal(miniov1alpha1.DefaultAccessKeyIDKey))
+		Expect(endpoint.Spec.SecretRef.SecretAccessKeyKey).To(Equal(miniov1alpha1.DefaultSecretAccessKeyKey))
+	})
+})
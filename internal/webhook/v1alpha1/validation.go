@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the admission webhooks for the v1alpha1 API
+package v1alpha1
+
+import (
+	"net/url"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+)
+
+// invalid converts a list of field errors to an API error for the given kind
+func invalid(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: miniov1alpha1.GroupVersion.Group, Kind: kind}, name, allErrs)
+}
+
+// validateSecretReference checks that a secret reference names a secret
+func validateSecretReference(ref miniov1alpha1.SecretReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "secret name must be set"))
+	}
+	return allErrs
+}
+
+// validateURL checks that rawURL is an absolute http or https URL
+func validateURL(rawURL string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, rawURL, err.Error()))
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		allErrs = append(allErrs, field.Invalid(fldPath, rawURL, "url must use the http or https scheme"))
+	}
+	if parsed.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, rawURL, "url must contain a host"))
+	}
+
+	return allErrs
+}
+
+// defaultSecretReference fills in the default keys of a credentials secret reference
+func defaultSecretReference(ref *miniov1alpha1.SecretReference) {
+	if ref.AccessKeyIDKey == "" {
+		ref.AccessKeyIDKey = miniov1alpha1.DefaultAccessKeyIDKey
+	}
+	if ref.SecretAccessKeyKey == "" {
+		ref.SecretAccessKeyKey = miniov1alpha1.DefaultSecretAccessKeyKey
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+// SetupAliasWebhookWithManager registers the webhooks for Alias in the manager
+func SetupAliasWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.Alias{}).
+		WithValidator(&AliasCustomValidator{}).
+		WithDefaulter(&AliasCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-alias,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=aliases,verbs=create;update,versions=v1beta1,name=malias-v1beta1.kb.io,admissionReviewVersions=v1
+
+// AliasCustomDefaulter sets default values on Alias resources
+type AliasCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &AliasCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *AliasCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	alias, ok := obj.(*miniov1beta1.Alias)
+	if !ok {
+		return fmt.Errorf("expected an Alias object but got %T", obj)
+	}
+
+	if usesSecretKeys(alias.Spec.Credentials) {
+		defaultSecretReference(&alias.Spec.SecretRef)
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-alias,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=aliases,verbs=create;update,versions=v1beta1,name=valias-v1beta1.kb.io,admissionReviewVersions=v1
+
+// AliasCustomValidator validates Alias resources
+type AliasCustomValidator struct{}
+
+var _ webhook.CustomValidator = &AliasCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *AliasCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	alias, ok := obj.(*miniov1beta1.Alias)
+	if !ok {
+		return nil, fmt.Errorf("expected an Alias object but got %T", obj)
+	}
+
+	return nil, invalid("Alias", alias.Name, validateAlias(alias))
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *AliasCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	alias, ok := newObj.(*miniov1beta1.Alias)
+	if !ok {
+		return nil, fmt.Errorf("expected an Alias object but got %T", newObj)
+	}
+
+	return nil, invalid("Alias", alias.Name, validateAlias(alias))
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *AliasCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateAlias validates the spec of an Alias
+func validateAlias(alias *miniov1beta1.Alias) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateURL(alias.Spec.URL, specPath.Child("url"))
+	allErrs = append(allErrs, validateAliasCredentials(alias.Spec.Credentials, alias.Spec.SecretRef.Name, specPath)...)
+	// Namespaced aliases must not use the identity of the operator pod, the controller refuses them as well
+	if err := minioclient.CheckNamespacedCredentials(alias.Spec.Credentials, alias.Spec.URL); err != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("credentials"), err.Error()))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	minioclient "github.com/mxcd/mc-controller/internal/minio"
+)
+
+var _ = Describe("Alias Webhook", func() {
+	var (
+		ctx       context.Context
+		alias     *miniov1beta1.Alias
+		validator AliasCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		alias = &miniov1beta1.Alias{
+			ObjectMeta: metav1.ObjectMeta{Name: "minio", Namespace: "team-a"},
+			Spec: miniov1beta1.AliasSpec{
+				URL:       "https://minio.example.com",
+				SecretRef: miniov1beta1.SecretReference{Name: "minio-credentials"},
+			},
+		}
+	})
+
+	It("should admit an alias with a secret", func() {
+		_, err := validator.ValidateCreate(ctx, alias)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject the identity of the operator pod", func() {
+		alias.Spec.SecretRef = miniov1beta1.SecretReference{}
+		alias.Spec.Credentials = &miniov1beta1.AliasCredentials{Source: miniov1beta1.CredentialSourceAWS}
+		_, err := validator.ValidateCreate(ctx, alias)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject web identity tokens sent to other hosts", func() {
+		minioclient.AllowNamespacedAmbientCredentials = true
+		DeferCleanup(func() { minioclient.AllowNamespacedAmbientCredentials = false })
+
+		stsEndpoint := "https://sts.attacker.example"
+		alias.Spec.SecretRef = miniov1beta1.SecretReference{}
+		alias.Spec.Credentials = &miniov1beta1.AliasCredentials{
+			Source:      miniov1beta1.CredentialSourceWebIdentity,
+			STSEndpoint: &stsEndpoint,
+		}
+		_, err := validator.ValidateCreate(ctx, alias)
+		Expect(err).To(HaveOccurred())
+
+		alias.Spec.Credentials.STSEndpoint = nil
+		_, err = validator.ValidateCreate(ctx, alias)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupBucketWebhookWithManager registers the webhooks for Bucket in the manager
+func SetupBucketWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.Bucket{}).
+		WithValidator(&BucketCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&BucketCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-bucket,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=buckets,verbs=create;update,versions=v1beta1,name=mbucket-v1beta1.kb.io,admissionReviewVersions=v1
+
+// BucketCustomDefaulter sets default values on Bucket resources
+type BucketCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &BucketCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *BucketCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	bucket, ok := obj.(*miniov1beta1.Bucket)
+	if !ok {
+		return fmt.Errorf("expected a Bucket object but got %T", obj)
+	}
+
+	defaultConnection(&bucket.Spec.Connection)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-bucket,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=buckets,verbs=create;update,versions=v1beta1,name=vbucket-v1beta1.kb.io,admissionReviewVersions=v1
+
+// BucketCustomValidator validates Bucket resources
+type BucketCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &BucketCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *BucketCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bucket, ok := obj.(*miniov1beta1.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bucket object but got %T", obj)
+	}
+
+	if err := invalid("Bucket", bucket.Name, validateBucket(bucket)); err != nil {
+		return connectionWarnings(bucket.Annotations), err
+	}
+	return connectionWarnings(bucket.Annotations), validateTenantPolicies(ctx, v.Client, "buckets", bucket)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *BucketCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldBucket, ok := oldObj.(*miniov1beta1.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bucket object but got %T", oldObj)
+	}
+	bucket, ok := newObj.(*miniov1beta1.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("expected a Bucket object but got %T", newObj)
+	}
+
+	specPath := field.NewPath("spec")
+	allErrs := validateBucket(bucket)
+	allErrs = append(allErrs, validateImmutable(bucket.Spec.BucketName, oldBucket.Spec.BucketName, specPath.Child("bucketName"))...)
+	allErrs = append(allErrs, validateImmutable(bucket.Spec.ObjectLocking, oldBucket.Spec.ObjectLocking, specPath.Child("objectLocking"))...)
+
+	if err := invalid("Bucket", bucket.Name, allErrs); err != nil {
+		return connectionWarnings(bucket.Annotations), err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldBucket.Spec, bucket.Spec) {
+		return connectionWarnings(bucket.Annotations), nil
+	}
+	return connectionWarnings(bucket.Annotations), validateTenantPolicies(ctx, v.Client, "buckets", bucket)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *BucketCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateBucket validates the spec of a Bucket
+func validateBucket(bucket *miniov1beta1.Bucket) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateConnection(bucket.Spec.Connection, bucket.Annotations, specPath.Child("connection"))
+	allErrs = append(allErrs, validateBucketName(bucket.Spec.BucketName, specPath.Child("bucketName"))...)
+	if bucket.Spec.BucketPolicy != nil {
+		allErrs = append(allErrs, validateBucketPolicy(bucket.Spec.BucketName, bucket.Spec.BucketPolicy, specPath.Child("bucketPolicy"))...)
+	}
+	if encryption := bucket.Spec.Encryption; encryption != nil && encryption.KMSKeyID != "" && encryption.Type != miniov1beta1.BucketEncryptionSSEKMS {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("encryption", "kmsKeyID"), encryption.KMSKeyID, "kmsKeyID can only be used with SSE-KMS"))
+	}
+	if notification := bucket.Spec.Notification; notification != nil {
+		notificationPath := specPath.Child("notification")
+		if len(notification.Events) == 0 {
+			allErrs = append(allErrs, field.Required(notificationPath.Child("events"), "at least one event must be set"))
+		}
+		if notification.Topic == nil && notification.Queue == nil && notification.LambdaFunction == nil && notification.TargetRef == nil {
+			allErrs = append(allErrs, field.Required(notificationPath, "one of topic, queue, lambdaFunction or targetRef must be set"))
+		}
+		arns := []struct {
+			name string
+			arn  *string
+		}{{"topic", notification.Topic}, {"queue", notification.Queue}, {"lambdaFunction", notification.LambdaFunction}}
+		for _, destination := range arns {
+			if destination.arn != nil && (!strings.HasPrefix(*destination.arn, "arn:") || strings.Count(*destination.arn, ":") != 5) {
+				allErrs = append(allErrs, field.Invalid(notificationPath.Child(destination.name), *destination.arn,
+					"must be an ARN of the form arn:partition:service:region:account:resource"))
+			}
+		}
+		if notification.TargetRef != nil && notification.TargetRef.Name == "" {
+			allErrs = append(allErrs, field.Required(notificationPath.Child("targetRef", "name"), "notification target name must be set"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateBucketPolicy checks that a bucket policy is either a preset or a valid policy document
+// granting access to the bucket only
+func validateBucketPolicy(bucketName string, bucketPolicy *miniov1beta1.BucketPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch {
+	case bucketPolicy.Preset == "" && bucketPolicy.Policy == "":
+		return append(allErrs, field.Required(fldPath, "either preset or policy must be set"))
+	case bucketPolicy.Preset != "" && bucketPolicy.Policy != "":
+		return append(allErrs, field.Invalid(fldPath, "", "preset and policy are mutually exclusive"))
+	}
+
+	if bucketPolicy.Prefix != "" {
+		prefixPath := fldPath.Child("prefix")
+		switch {
+		case bucketPolicy.Preset == "":
+			allErrs = append(allErrs, field.Invalid(prefixPath, bucketPolicy.Prefix, "prefix can only be used with a preset"))
+		case strings.HasPrefix(bucketPolicy.Prefix, "/"):
+			allErrs = append(allErrs, field.Invalid(prefixPath, bucketPolicy.Prefix, "prefix must not start with /"))
+		case strings.ContainsAny(bucketPolicy.Prefix, "*?"):
+			allErrs = append(allErrs, field.Invalid(prefixPath, bucketPolicy.Prefix, "prefix must not contain wildcards"))
+		}
+	}
+
+	if bucketPolicy.Policy == "" {
+		return allErrs
+	}
+	policyPath := fldPath.Child("policy")
+	if errs := validatePolicyDocument([]byte(bucketPolicy.Policy), policyPath); len(errs) > 0 {
+		return append(allErrs, errs...)
+	}
+
+	// Bucket policies apply to principals and must not grant access to other buckets
+	var policy policyDocument
+	if err := json.Unmarshal([]byte(bucketPolicy.Policy), &policy); err != nil {
+		return append(allErrs, field.Invalid(policyPath, bucketPolicy.Policy, err.Error()))
+	}
+	bucketResource := "arn:aws:s3:::" + bucketName
+	for i, statement := range policy.Statement {
+		statementPath := policyPath.Child("Statement").Index(i)
+		if len(statement.Principal) == 0 && len(statement.NotPrincipal) == 0 {
+			allErrs = append(allErrs, field.Required(statementPath.Child("principal"), "bucket policy statement must contain Principal or NotPrincipal"))
+		}
+		if len(statement.Resource) == 0 && len(statement.NotResource) == 0 {
+			allErrs = append(allErrs, field.Required(statementPath.Child("resource"), "bucket policy statement must contain Resource or NotResource"))
+		}
+		allErrs = append(allErrs, validateBucketResources(statement.Resource, bucketResource, statementPath.Child("resource"))...)
+		allErrs = append(allErrs, validateBucketResources(statement.NotResource, bucketResource, statementPath.Child("notResource"))...)
+	}
+
+	return allErrs
+}
+
+// validateBucketResources checks that the resources of a bucket policy statement name the bucket or objects in it
+func validateBucketResources(resources []string, bucketResource string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for j, resource := range resources {
+		if resource != bucketResource && !strings.HasPrefix(resource, bucketResource+"/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(j), resource,
+				fmt.Sprintf("resource must be the bucket %s or objects in it", bucketResource)))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/tenant"
+)
+
+var _ = Describe("Bucket Webhook", func() {
+	var (
+		ctx       context.Context
+		bucket    *miniov1beta1.Bucket
+		validator BucketCustomValidator
+		defaulter BucketCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		bucket = &miniov1beta1.Bucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default"},
+			Spec: miniov1beta1.BucketSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				BucketName: "test-bucket",
+			},
+		}
+	})
+
+	Context("When creating a Bucket", func() {
+		It("should admit a valid bucket", func() {
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject bucket names violating the S3 naming rules",
+			func(name string) {
+				bucket.Spec.BucketName = name
+				_, err := validator.ValidateCreate(ctx, bucket)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("too short", "ab"),
+			Entry("uppercase letters", "Test-Bucket"),
+			Entry("underscore", "test_bucket"),
+			Entry("trailing hyphen", "test-bucket-"),
+			Entry("adjacent periods", "test..bucket"),
+			Entry("IP address", "192.168.1.1"),
+			Entry("reserved prefix", "xn--bucket"),
+			Entry("reserved suffix", "bucket-s3alias"),
+		)
+
+		It("should reject a connection without any connection method", func() {
+			bucket.Spec.Connection = miniov1beta1.MinIOConnection{}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a connection with more than one connection method", func() {
+			url := "https://minio.example.com"
+			bucket.Spec.Connection.URL = &url
+			bucket.Spec.Connection.SecretRef = &miniov1beta1.SecretReference{Name: "minio-credentials"}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should admit a connection referencing a cluster alias", func() {
+			bucket.Spec.Connection = miniov1beta1.MinIOConnection{
+				ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared-minio"},
+			}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a connection referencing both an alias and a cluster alias", func() {
+			bucket.Spec.Connection.ClusterAliasRef = &miniov1beta1.ClusterAliasReference{Name: "shared-minio"}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should admit and warn about an endpointRef carried over from v1alpha1", func() {
+			bucket.Spec.Connection = miniov1beta1.MinIOConnection{}
+			bucket.Annotations = map[string]string{miniov1beta1.LegacyEndpointRefAnnotation: "minio"}
+			warnings, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+		})
+	})
+
+	Context("When setting a bucket policy", func() {
+		It("should admit a preset with a prefix", func() {
+			bucket.Spec.BucketPolicy = &miniov1beta1.BucketPolicy{Preset: miniov1beta1.BucketPolicyPresetDownload, Prefix: "public/"}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should admit a policy document for the bucket", func() {
+			bucket.Spec.BucketPolicy = &miniov1beta1.BucketPolicy{Policy: `{
+				"Version": "2012-10-17",
+				"Statement": [{
+					"Effect": "Allow",
+					"Principal": {"AWS": ["*"]},
+					"Action": ["s3:GetObject"],
+					"Resource": ["arn:aws:s3:::test-bucket/*"]
+				}]
+			}`}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("should reject invalid bucket policies",
+			func(bucketPolicy miniov1beta1.BucketPolicy) {
+				bucket.Spec.BucketPolicy = &bucketPolicy
+				_, err := validator.ValidateCreate(ctx, bucket)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("neither preset nor policy", miniov1beta1.BucketPolicy{}),
+			Entry("both preset and policy", miniov1beta1.BucketPolicy{Preset: "public", Policy: `{"Version": "2012-10-17"}`}),
+			Entry("prefix without preset", miniov1beta1.BucketPolicy{Prefix: "public/", Policy: `{"Version": "2012-10-17"}`}),
+			Entry("prefix with leading slash", miniov1beta1.BucketPolicy{Preset: "download", Prefix: "/public"}),
+			Entry("prefix with wildcard", miniov1beta1.BucketPolicy{Preset: "download", Prefix: "public/*"}),
+			Entry("malformed JSON", miniov1beta1.BucketPolicy{Policy: `{"Version": `}),
+			Entry("statement without principal", miniov1beta1.BucketPolicy{Policy: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::test-bucket/*"}]}`}),
+			Entry("resource of another bucket", miniov1beta1.BucketPolicy{Policy: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::other-bucket/*"}]}`}),
+		)
+
+		It("should report resources of other buckets at their own index", func() {
+			bucket.Spec.BucketPolicy = &miniov1beta1.BucketPolicy{Policy: `{"Version": "2012-10-17", "Statement": [
+				{"Effect": "Deny", "Principal": "*", "Action": "s3:GetObject",
+				 "NotResource": ["arn:aws:s3:::test-bucket/public/*", "arn:aws:s3:::other-bucket/*"]}]}`}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			var statusErr *apierrors.StatusError
+			Expect(errors.As(err, &statusErr)).To(BeTrue())
+			Expect(statusErr.ErrStatus.Details.Causes).To(ContainElement(
+				HaveField("Field", "spec.bucketPolicy.policy.Statement[0].notResource[1]")))
+		})
+	})
+
+	Context("When setting the bucket encryption", func() {
+		It("should admit SSE-KMS with a key", func() {
+			bucket.Spec.Encryption = &miniov1beta1.BucketEncryption{Type: miniov1beta1.BucketEncryptionSSEKMS, KMSKeyID: "minio-key"}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a key without SSE-KMS", func() {
+			bucket.Spec.Encryption = &miniov1beta1.BucketEncryption{Type: miniov1beta1.BucketEncryptionSSES3, KMSKeyID: "minio-key"}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When publishing bucket events", func() {
+		It("should admit events published to a notification target", func() {
+			bucket.Spec.Notification = &miniov1beta1.BucketNotification{
+				Events:    []string{"s3:ObjectCreated:*"},
+				TargetRef: &miniov1beta1.NotificationTargetReference{Name: "audit-webhook"},
+			}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject events without destination", func() {
+			bucket.Spec.Notification = &miniov1beta1.BucketNotification{Events: []string{"s3:ObjectCreated:*"}}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a malformed ARN", func() {
+			queue := "minio:sqs::audit:webhook"
+			bucket.Spec.Notification = &miniov1beta1.BucketNotification{Events: []string{"s3:ObjectCreated:*"}, Queue: &queue}
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When tenant policies apply to the namespace", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&miniov1beta1.TenantPolicy{
+					ObjectMeta: metav1.ObjectMeta{Name: "prefix"},
+					Spec:       miniov1beta1.TenantPolicySpec{BucketPrefix: "{namespace}-"},
+				},
+			).WithIndex(&miniov1beta1.Bucket{}, tenant.BucketNameField, tenant.IndexBucketName).Build()
+		})
+
+		AfterEach(func() {
+			validator.Client = nil
+		})
+
+		It("should reject a bucket name without the tenant prefix", func() {
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+
+		It("should admit a bucket name with the tenant prefix", func() {
+			bucket.Spec.BucketName = "default-test-bucket"
+			_, err := validator.ValidateCreate(ctx, bucket)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should admit metadata changes of a bucket violating the policy", func() {
+			updated := bucket.DeepCopy()
+			updated.Finalizers = nil
+			_, err := validator.ValidateUpdate(ctx, bucket, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When updating a Bucket", func() {
+		It("should reject changing the bucket name", func() {
+			updated := bucket.DeepCopy()
+			updated.Spec.BucketName = "other-bucket"
+			_, err := validator.ValidateUpdate(ctx, bucket, updated)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject changing object locking", func() {
+			updated := bucket.DeepCopy()
+			updated.Spec.ObjectLocking = true
+			_, err := validator.ValidateUpdate(ctx, bucket, updated)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should admit changing mutable fields", func() {
+			updated := bucket.DeepCopy()
+			updated.Spec.Versioning = true
+			_, err := validator.ValidateUpdate(ctx, bucket, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When defaulting a Bucket", func() {
+		It("should default the secret key names of a url connection", func() {
+			url := "https://minio.example.com"
+			bucket.Spec.Connection = miniov1beta1.MinIOConnection{
+				URL:       &url,
+				SecretRef: &miniov1beta1.SecretReference{Name: "minio-credentials"},
+			}
+			Expect(defaulter.Default(ctx, bucket)).To(Succeed())
+			Expect(bucket.Spec.Connection.SecretRef.AccessKeyIDKey).To(Equal(miniov1beta1.DefaultAccessKeyIDKey))
+			Expect(bucket.Spec.Connection.SecretRef.SecretAccessKeyKey).To(Equal(miniov1beta1.DefaultSecretAccessKeyKey))
+		})
+	})
+})
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupBucketClaimWebhookWithManager registers the webhooks for BucketClaim in the manager
+func SetupBucketClaimWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.BucketClaim{}).
+		WithValidator(&BucketClaimCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&BucketClaimCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-bucketclaim,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=bucketclaims,verbs=create;update,versions=v1beta1,name=mbucketclaim-v1beta1.kb.io,admissionReviewVersions=v1
+
+// BucketClaimCustomDefaulter sets default values on BucketClaim resources
+type BucketClaimCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &BucketClaimCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *BucketClaimCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	claim, ok := obj.(*miniov1beta1.BucketClaim)
+	if !ok {
+		return fmt.Errorf("expected a BucketClaim object but got %T", obj)
+	}
+
+	// Objects created through templates may not carry their namespace yet
+	namespace := claim.Namespace
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Namespace != "" {
+		namespace = req.Namespace
+	}
+
+	defaultConnection(&claim.Spec.Connection)
+	if claim.Spec.BucketName == "" {
+		claim.Spec.BucketName = namespace + "-" + claim.Name
+	}
+	if claim.Spec.Username == "" {
+		claim.Spec.Username = claim.Spec.BucketName
+	}
+	if claim.Spec.Access == "" {
+		claim.Spec.Access = miniov1beta1.BucketAccessReadWrite
+	}
+	if claim.Spec.ConnectionSecretName == "" {
+		claim.Spec.ConnectionSecretName = claim.Name + "-connection"
+	}
+	if claim.Spec.DeletionPolicy == "" {
+		claim.Spec.DeletionPolicy = miniov1beta1.BucketClaimDeletionPolicyDelete
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-bucketclaim,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=bucketclaims,verbs=create;update,versions=v1beta1,name=vbucketclaim-v1beta1.kb.io,admissionReviewVersions=v1
+
+// BucketClaimCustomValidator validates BucketClaim resources
+type BucketClaimCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &BucketClaimCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *BucketClaimCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	claim, ok := obj.(*miniov1beta1.BucketClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a BucketClaim object but got %T", obj)
+	}
+
+	if err := invalid("BucketClaim", claim.Name, validateBucketClaim(claim)); err != nil {
+		return nil, err
+	}
+	return nil, validateTenantPolicies(ctx, v.Client, "bucketclaims", claim)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *BucketClaimCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldClaim, ok := oldObj.(*miniov1beta1.BucketClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a BucketClaim object but got %T", oldObj)
+	}
+	claim, ok := newObj.(*miniov1beta1.BucketClaim)
+	if !ok {
+		return nil, fmt.Errorf("expected a BucketClaim object but got %T", newObj)
+	}
+
+	// The bucket, user and secret are created once and keep their names
+	specPath := field.NewPath("spec")
+	allErrs := validateBucketClaim(claim)
+	allErrs = append(allErrs, validateImmutable(claim.Spec.BucketName, oldClaim.Spec.BucketName, specPath.Child("bucketName"))...)
+	allErrs = append(allErrs, validateImmutable(claim.Spec.Username, oldClaim.Spec.Username, specPath.Child("username"))...)
+	allErrs = append(allErrs, validateImmutable(claim.Spec.ConnectionSecretName, oldClaim.Spec.ConnectionSecretName, specPath.Child("connectionSecretName"))...)
+
+	if err := invalid("BucketClaim", claim.Name, allErrs); err != nil {
+		return nil, err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldClaim.Spec, claim.Spec) {
+		return nil, nil
+	}
+	return nil, validateTenantPolicies(ctx, v.Client, "bucketclaims", claim)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *BucketClaimCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateBucketClaim validates the spec of a BucketClaim
+func validateBucketClaim(claim *miniov1beta1.BucketClaim) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateConnection(claim.Spec.Connection, claim.Annotations, specPath.Child("connection"))
+	if claim.Spec.BucketName != "" {
+		allErrs = append(allErrs, validateBucketName(claim.Spec.BucketName, specPath.Child("bucketName"))...)
+	}
+
+	switch claim.Spec.Access {
+	case "", miniov1beta1.BucketAccessReadOnly, miniov1beta1.BucketAccessReadWrite, miniov1beta1.BucketAccessAdmin:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("access"), claim.Spec.Access,
+			[]string{string(miniov1beta1.BucketAccessReadOnly), string(miniov1beta1.BucketAccessReadWrite), string(miniov1beta1.BucketAccessAdmin)}))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("BucketClaim Webhook", func() {
+	var (
+		ctx       context.Context
+		claim     *miniov1beta1.BucketClaim
+		validator BucketClaimCustomValidator
+		defaulter BucketClaimCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		claim = &miniov1beta1.BucketClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-data", Namespace: "team-a"},
+			Spec: miniov1beta1.BucketClaimSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+			},
+		}
+	})
+
+	It("should default the bucket, user, access and connection secret", func() {
+		Expect(defaulter.Default(ctx, claim)).To(Succeed())
+		Expect(claim.Spec.BucketName).To(Equal("team-a-app-data"))
+		Expect(claim.Spec.Username).To(Equal("team-a-app-data"))
+		Expect(claim.Spec.Access).To(Equal(miniov1beta1.BucketAccessReadWrite))
+		Expect(claim.Spec.ConnectionSecretName).To(Equal("app-data-connection"))
+		Expect(claim.Spec.DeletionPolicy).To(Equal(miniov1beta1.BucketClaimDeletionPolicyDelete))
+	})
+
+	It("should keep explicitly set names", func() {
+		claim.Spec.BucketName = "shared-data"
+		claim.Spec.Username = "reader"
+		Expect(defaulter.Default(ctx, claim)).To(Succeed())
+		Expect(claim.Spec.BucketName).To(Equal("shared-data"))
+		Expect(claim.Spec.Username).To(Equal("reader"))
+	})
+
+	It("should admit a defaulted claim", func() {
+		Expect(defaulter.Default(ctx, claim)).To(Succeed())
+		warnings, err := validator.ValidateCreate(ctx, claim)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should reject an invalid bucket name", func() {
+		claim.Spec.BucketName = "Invalid_Bucket"
+		_, err := validator.ValidateCreate(ctx, claim)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an unknown access level", func() {
+		claim.Spec.Access = "WriteOnly"
+		_, err := validator.ValidateCreate(ctx, claim)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject changing the bucket name", func() {
+		Expect(defaulter.Default(ctx, claim)).To(Succeed())
+		updated := claim.DeepCopy()
+		updated.Spec.BucketName = "other-bucket"
+		_, err := validator.ValidateUpdate(ctx, claim, updated)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should admit changing the access level", func() {
+		Expect(defaulter.Default(ctx, claim)).To(Succeed())
+		updated := claim.DeepCopy()
+		updated.Spec.Access = miniov1beta1.BucketAccessReadOnly
+		_, err := validator.ValidateUpdate(ctx, claim, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupBucketReplicationWebhookWithManager registers the webhooks for BucketReplication in the manager
+func SetupBucketReplicationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.BucketReplication{}).
+		WithValidator(&BucketReplicationCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&BucketReplicationCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-bucketreplication,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=bucketreplications,verbs=create;update,versions=v1beta1,name=mbucketreplication-v1beta1.kb.io,admissionReviewVersions=v1
+
+// BucketReplicationCustomDefaulter sets default values on BucketReplication resources
+type BucketReplicationCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &BucketReplicationCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *BucketReplicationCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	bucketReplication, ok := obj.(*miniov1beta1.BucketReplication)
+	if !ok {
+		return fmt.Errorf("expected a BucketReplication object but got %T", obj)
+	}
+
+	defaultConnection(&bucketReplication.Spec.Destination.Connection)
+	for i := range bucketReplication.Spec.Rules {
+		if bucketReplication.Spec.Rules[i].Status == "" {
+			bucketReplication.Spec.Rules[i].Status = miniov1beta1.ReplicationRuleStatusEnabled
+		}
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-bucketreplication,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=bucketreplications,verbs=create;update,versions=v1beta1,name=vbucketreplication-v1beta1.kb.io,admissionReviewVersions=v1
+
+// BucketReplicationCustomValidator validates BucketReplication resources
+type BucketReplicationCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &BucketReplicationCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *BucketReplicationCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bucketReplication, ok := obj.(*miniov1beta1.BucketReplication)
+	if !ok {
+		return nil, fmt.Errorf("expected a BucketReplication object but got %T", obj)
+	}
+
+	if err := invalid("BucketReplication", bucketReplication.Name, validateBucketReplication(bucketReplication)); err != nil {
+		return nil, err
+	}
+	return nil, validateTenantPolicies(ctx, v.Client, "bucketreplications", bucketReplication)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *BucketReplicationCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldBucketReplication, ok := oldObj.(*miniov1beta1.BucketReplication)
+	if !ok {
+		return nil, fmt.Errorf("expected a BucketReplication object but got %T", oldObj)
+	}
+	bucketReplication, ok := newObj.(*miniov1beta1.BucketReplication)
+	if !ok {
+		return nil, fmt.Errorf("expected a BucketReplication object but got %T", newObj)
+	}
+
+	// The remote target and rules of the previous source or destination would be left behind
+	specPath := field.NewPath("spec")
+	allErrs := validateBucketReplication(bucketReplication)
+	allErrs = append(allErrs, validateImmutable(bucketReplication.Spec.BucketRef.Name, oldBucketReplication.Spec.BucketRef.Name, specPath.Child("bucketRef", "name"))...)
+	allErrs = append(allErrs, validateImmutable(bucketReplication.Spec.Destination.BucketName, oldBucketReplication.Spec.Destination.BucketName, specPath.Child("destination", "bucketName"))...)
+
+	if err := invalid("BucketReplication", bucketReplication.Name, allErrs); err != nil {
+		return nil, err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldBucketReplication.Spec, bucketReplication.Spec) {
+		return nil, nil
+	}
+	return nil, validateTenantPolicies(ctx, v.Client, "bucketreplications", bucketReplication)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *BucketReplicationCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateBucketReplication validates the spec of a BucketReplication
+func validateBucketReplication(bucketReplication *miniov1beta1.BucketReplication) field.ErrorList {
+	specPath := field.NewPath("spec")
+	var allErrs field.ErrorList
+
+	if bucketReplication.Spec.BucketRef.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("bucketRef", "name"), "source Bucket must be set"))
+	}
+
+	destinationPath := specPath.Child("destination")
+	allErrs = append(allErrs, validateConnection(bucketReplication.Spec.Destination.Connection, nil, destinationPath.Child("connection"))...)
+	allErrs = append(allErrs, validateBucketName(bucketReplication.Spec.Destination.BucketName, destinationPath.Child("bucketName"))...)
+
+	rulesPath := specPath.Child("rules")
+	if len(bucketReplication.Spec.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath, "at least one rule must be set"))
+	}
+
+	// MinIO rejects replication configurations with duplicate rule IDs or priorities
+	ruleIDs := map[string]bool{}
+	priorities := map[int]bool{}
+	for i, rule := range bucketReplication.Spec.Rules {
+		rulePath := rulesPath.Index(i)
+
+		switch {
+		case rule.ID == "":
+			allErrs = append(allErrs, field.Required(rulePath.Child("id"), "rule ID must be set"))
+		case len(rule.ID) > 255:
+			allErrs = append(allErrs, field.TooLong(rulePath.Child("id"), rule.ID, 255))
+		case ruleIDs[rule.ID]:
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("id"), rule.ID))
+		}
+		ruleIDs[rule.ID] = true
+
+		if priorities[rule.Priority] {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("priority"), rule.Priority))
+		}
+		priorities[rule.Priority] = true
+
+		switch rule.Status {
+		case "", miniov1beta1.ReplicationRuleStatusEnabled, miniov1beta1.ReplicationRuleStatusDisabled:
+		default:
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("status"), rule.Status,
+				[]string{string(miniov1beta1.ReplicationRuleStatusEnabled), string(miniov1beta1.ReplicationRuleStatusDisabled)}))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("BucketReplication Webhook", func() {
+	var (
+		ctx               context.Context
+		bucketReplication *miniov1beta1.BucketReplication
+		validator         BucketReplicationCustomValidator
+		defaulter         BucketReplicationCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		bucketReplication = &miniov1beta1.BucketReplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-replication", Namespace: "default"},
+			Spec: miniov1beta1.BucketReplicationSpec{
+				BucketRef: miniov1beta1.BucketReference{Name: "test-bucket"},
+				Destination: miniov1beta1.ReplicationDestination{
+					Connection: miniov1beta1.MinIOConnection{
+						AliasRef: &miniov1beta1.AliasReference{Name: "minio-dr"},
+					},
+					BucketName: "test-bucket-replica",
+				},
+				Rules: []miniov1beta1.ReplicationRule{
+					{ID: "documents", Priority: 2, Prefix: "documents/"},
+					{ID: "images", Priority: 1, Tags: map[string]string{"replicate": "true"}},
+				},
+			},
+		}
+	})
+
+	It("should admit rules with unique IDs and priorities", func() {
+		_, err := validator.ValidateCreate(ctx, bucketReplication)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject duplicate rule IDs", func() {
+		bucketReplication.Spec.Rules[1].ID = "documents"
+		_, err := validator.ValidateCreate(ctx, bucketReplication)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+	})
+
+	It("should reject duplicate priorities", func() {
+		bucketReplication.Spec.Rules[1].Priority = 2
+		_, err := validator.ValidateCreate(ctx, bucketReplication)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("priority"))
+	})
+
+	It("should reject a destination without connection", func() {
+		bucketReplication.Spec.Destination.Connection = miniov1beta1.MinIOConnection{}
+		_, err := validator.ValidateCreate(ctx, bucketReplication)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject changing the destination bucket", func() {
+		updated := bucketReplication.DeepCopy()
+		updated.Spec.Destination.BucketName = "other-bucket"
+		_, err := validator.ValidateUpdate(ctx, bucketReplication, updated)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should enable rules without a status", func() {
+		Expect(defaulter.Default(ctx, bucketReplication)).To(Succeed())
+		Expect(bucketReplication.Spec.Rules[0].Status).To(Equal(miniov1beta1.ReplicationRuleStatusEnabled))
+	})
+})
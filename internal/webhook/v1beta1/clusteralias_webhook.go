@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupClusterAliasWebhookWithManager registers the webhooks for ClusterAlias in the manager
+func SetupClusterAliasWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.ClusterAlias{}).
+		WithValidator(&ClusterAliasCustomValidator{}).
+		WithDefaulter(&ClusterAliasCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-clusteralias,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=clusteraliases,verbs=create;update,versions=v1beta1,name=mclusteralias-v1beta1.kb.io,admissionReviewVersions=v1
+
+// ClusterAliasCustomDefaulter sets default values on ClusterAlias resources
+type ClusterAliasCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ClusterAliasCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *ClusterAliasCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	alias, ok := obj.(*miniov1beta1.ClusterAlias)
+	if !ok {
+		return fmt.Errorf("expected a ClusterAlias object but got %T", obj)
+	}
+
+	if !usesSecretKeys(alias.Spec.Credentials) {
+		return nil
+	}
+	if alias.Spec.SecretRef.AccessKeyIDKey == "" {
+		alias.Spec.SecretRef.AccessKeyIDKey = miniov1beta1.DefaultAccessKeyIDKey
+	}
+	if alias.Spec.SecretRef.SecretAccessKeyKey == "" {
+		alias.Spec.SecretRef.SecretAccessKeyKey = miniov1beta1.DefaultSecretAccessKeyKey
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-clusteralias,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=clusteraliases,verbs=create;update,versions=v1beta1,name=vclusteralias-v1beta1.kb.io,admissionReviewVersions=v1
+
+// ClusterAliasCustomValidator validates ClusterAlias resources
+type ClusterAliasCustomValidator struct{}
+
+var _ webhook.CustomValidator = &ClusterAliasCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *ClusterAliasCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	alias, ok := obj.(*miniov1beta1.ClusterAlias)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterAlias object but got %T", obj)
+	}
+
+	return nil, invalid("ClusterAlias", alias.Name, validateClusterAlias(alias))
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *ClusterAliasCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	alias, ok := newObj.(*miniov1beta1.ClusterAlias)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterAlias object but got %T", newObj)
+	}
+
+	return nil, invalid("ClusterAlias", alias.Name, validateClusterAlias(alias))
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *ClusterAliasCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateClusterAlias validates the spec of an ClusterAlias
+func validateClusterAlias(alias *miniov1beta1.ClusterAlias) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateURL(alias.Spec.URL, specPath.Child("url"))
+	allErrs = append(allErrs, validateAliasCredentials(alias.Spec.Credentials, alias.Spec.SecretRef.Name, specPath)...)
+	if alias.Spec.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(alias.Spec.NamespaceSelector,
+			metav1validation.LabelSelectorValidationOptions{}, specPath.Child("namespaceSelector"))...)
+	}
+
+	return allErrs
+}
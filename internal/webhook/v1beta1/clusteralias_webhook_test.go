@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("ClusterAlias Webhook", func() {
+	var (
+		ctx       context.Context
+		alias     *miniov1beta1.ClusterAlias
+		validator ClusterAliasCustomValidator
+		defaulter ClusterAliasCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		alias = &miniov1beta1.ClusterAlias{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-minio"},
+			Spec: miniov1beta1.ClusterAliasSpec{
+				URL:       "https://minio.example.com",
+				SecretRef: miniov1beta1.ClusterSecretReference{Name: "minio-credentials"},
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"minio-access": "true"},
+				},
+			},
+		}
+	})
+
+	Context("When creating a ClusterAlias", func() {
+		It("should admit a valid cluster alias", func() {
+			_, err := validator.ValidateCreate(ctx, alias)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a url without a scheme", func() {
+			alias.Spec.URL = "minio.example.com"
+			_, err := validator.ValidateCreate(ctx, alias)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a missing secret name", func() {
+			alias.Spec.SecretRef.Name = ""
+			_, err := validator.ValidateCreate(ctx, alias)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should admit web identity credentials without a secret", func() {
+			alias.Spec.SecretRef = miniov1beta1.ClusterSecretReference{}
+			alias.Spec.Credentials = &miniov1beta1.AliasCredentials{
+				Source:  miniov1beta1.CredentialSourceWebIdentity,
+				RoleARN: "arn:minio:iam:::role/operator",
+			}
+			_, err := validator.ValidateCreate(ctx, alias)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject assuming a role without a secret", func() {
+			alias.Spec.SecretRef = miniov1beta1.ClusterSecretReference{}
+			alias.Spec.Credentials = &miniov1beta1.AliasCredentials{Source: miniov1beta1.CredentialSourceAssumeRole}
+			_, err := validator.ValidateCreate(ctx, alias)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject options the credential source does not use", func() {
+			stsEndpoint := "https://sts.example.com"
+			alias.Spec.Credentials = &miniov1beta1.AliasCredentials{
+				Source:      miniov1beta1.CredentialSourceAWS,
+				TokenPath:   "/var/run/secrets/token",
+				STSEndpoint: &stsEndpoint,
+			}
+			_, err := validator.ValidateCreate(ctx, alias)
+			Expect(err).To(MatchError(ContainSubstring("tokenPath")))
+			Expect(err).To(MatchError(ContainSubstring("stsEndpoint")))
+		})
+
+		It("should reject an invalid namespace selector", func() {
+			alias.Spec.NamespaceSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, alias)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When defaulting a ClusterAlias", func() {
+		It("should default the secret key names", func() {
+			Expect(defaulter.Default(ctx, alias)).To(Succeed())
+			Expect(alias.Spec.SecretRef.AccessKeyIDKey).To(Equal(miniov1beta1.DefaultAccessKeyIDKey))
+			Expect(alias.Spec.SecretRef.SecretAccessKeyKey).To(Equal(miniov1beta1.DefaultSecretAccessKeyKey))
+		})
+
+		It("should not default secret key names for the AWS credential chain", func() {
+			alias.Spec.SecretRef = miniov1beta1.ClusterSecretReference{}
+			alias.Spec.Credentials = &miniov1beta1.AliasCredentials{Source: miniov1beta1.CredentialSourceAWS}
+			Expect(defaulter.Default(ctx, alias)).To(Succeed())
+			Expect(alias.Spec.SecretRef).To(Equal(miniov1beta1.ClusterSecretReference{}))
+		})
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// identityProviderRequiredKeys are the configuration keys each identity provider type needs
+var identityProviderRequiredKeys = map[miniov1beta1.IdentityProviderType]string{
+	miniov1beta1.IdentityProviderTypeOpenID: "config_url",
+	miniov1beta1.IdentityProviderTypeLDAP:   "server_addr",
+}
+
+// identityProviderSecretKeys are the configuration keys holding credentials, which are only read from secrets
+var identityProviderSecretKeys = map[miniov1beta1.IdentityProviderType]string{
+	miniov1beta1.IdentityProviderTypeOpenID: "client_secret",
+	miniov1beta1.IdentityProviderTypeLDAP:   "lookup_bind_password",
+}
+
+// SetupIdentityProviderWebhookWithManager registers the webhooks for IdentityProvider in the manager
+func SetupIdentityProviderWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.IdentityProvider{}).
+		WithValidator(&IdentityProviderCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&IdentityProviderCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-identityprovider,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=identityproviders,verbs=create;update,versions=v1beta1,name=midentityprovider-v1beta1.kb.io,admissionReviewVersions=v1
+
+// IdentityProviderCustomDefaulter sets default values on IdentityProvider resources
+type IdentityProviderCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &IdentityProviderCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *IdentityProviderCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	provider, ok := obj.(*miniov1beta1.IdentityProvider)
+	if !ok {
+		return fmt.Errorf("expected an IdentityProvider object but got %T", obj)
+	}
+
+	defaultConnection(&provider.Spec.Connection)
+	if provider.Spec.RestartPolicy == "" {
+		provider.Spec.RestartPolicy = miniov1beta1.RestartPolicyIfRequired
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-identityprovider,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=identityproviders,verbs=create;update,versions=v1beta1,name=videntityprovider-v1beta1.kb.io,admissionReviewVersions=v1
+
+// IdentityProviderCustomValidator validates IdentityProvider resources
+type IdentityProviderCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &IdentityProviderCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *IdentityProviderCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	provider, ok := obj.(*miniov1beta1.IdentityProvider)
+	if !ok {
+		return nil, fmt.Errorf("expected an IdentityProvider object but got %T", obj)
+	}
+
+	if err := invalid("IdentityProvider", provider.Name, validateIdentityProvider(provider)); err != nil {
+		return connectionWarnings(provider.Annotations), err
+	}
+	return connectionWarnings(provider.Annotations), validateTenantPolicies(ctx, v.Client, "identityproviders", provider)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *IdentityProviderCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldProvider, ok := oldObj.(*miniov1beta1.IdentityProvider)
+	if !ok {
+		return nil, fmt.Errorf("expected an IdentityProvider object but got %T", oldObj)
+	}
+	provider, ok := newObj.(*miniov1beta1.IdentityProvider)
+	if !ok {
+		return nil, fmt.Errorf("expected an IdentityProvider object but got %T", newObj)
+	}
+
+	// Type and name identify the configuration in MinIO
+	specPath := field.NewPath("spec")
+	allErrs := validateIdentityProvider(provider)
+	allErrs = append(allErrs, validateImmutable(provider.Spec.Type, oldProvider.Spec.Type, specPath.Child("type"))...)
+	allErrs = append(allErrs, validateImmutable(provider.Spec.ConfigName, oldProvider.Spec.ConfigName, specPath.Child("configName"))...)
+
+	if err := invalid("IdentityProvider", provider.Name, allErrs); err != nil {
+		return connectionWarnings(provider.Annotations), err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldProvider.Spec, provider.Spec) {
+		return connectionWarnings(provider.Annotations), nil
+	}
+	return connectionWarnings(provider.Annotations), validateTenantPolicies(ctx, v.Client, "identityproviders", provider)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *IdentityProviderCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateIdentityProvider validates the spec of an IdentityProvider
+func validateIdentityProvider(provider *miniov1beta1.IdentityProvider) field.ErrorList {
+	specPath := field.NewPath("spec")
+	spec := provider.Spec
+
+	allErrs := validateConnection(spec.Connection, provider.Annotations, specPath.Child("connection"))
+	switch {
+	case spec.ConfigName == "":
+	case spec.Type == miniov1beta1.IdentityProviderTypeLDAP:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("configName"), "MinIO supports a single LDAP configuration only"))
+	case !targetNamePattern.MatchString(spec.ConfigName):
+		allErrs = append(allErrs, field.Invalid(specPath.Child("configName"), spec.ConfigName,
+			"configuration name must consist of letters, digits, '-' and '_'"))
+	}
+
+	configPath := specPath.Child("config")
+	if key, ok := identityProviderRequiredKeys[spec.Type]; ok && spec.Config[key] == "" {
+		allErrs = append(allErrs, field.Required(configPath.Key(key), fmt.Sprintf("%s identity providers need %s", spec.Type, key)))
+	}
+	if key, ok := identityProviderSecretKeys[spec.Type]; ok {
+		if _, plain := spec.Config[key]; plain {
+			allErrs = append(allErrs, field.Forbidden(configPath.Key(key), "credentials must be read from a secret with secretConfig"))
+		}
+	}
+	if _, ok := spec.Config["enable"]; ok {
+		allErrs = append(allErrs, field.Forbidden(configPath.Key("enable"), "the identity provider is enabled by the controller"))
+	}
+	// MinIO grants either the role policy or the policies named by the claim
+	if spec.Type == miniov1beta1.IdentityProviderTypeOpenID && spec.Config["role_policy"] != "" && spec.Config["claim_name"] != "" {
+		allErrs = append(allErrs, field.Forbidden(configPath.Key("claim_name"), "claim_name cannot be combined with role_policy"))
+	}
+	allErrs = append(allErrs, validateConfigKeys(spec.Config, configPath, spec.SecretConfig, specPath.Child("secretConfig"))...)
+	return allErrs
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("IdentityProvider Webhook", func() {
+	var (
+		ctx       context.Context
+		provider  *miniov1beta1.IdentityProvider
+		validator IdentityProviderCustomValidator
+		defaulter IdentityProviderCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		provider = &miniov1beta1.IdentityProvider{
+			ObjectMeta: metav1.ObjectMeta{Name: "keycloak", Namespace: "default"},
+			Spec: miniov1beta1.IdentityProviderSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				Type:       miniov1beta1.IdentityProviderTypeOpenID,
+				ConfigName: "keycloak",
+				Config: map[string]string{
+					"config_url": "https://keycloak.example.com/realms/apps/.well-known/openid-configuration",
+					"client_id":  "minio",
+					"claim_name": "groups",
+				},
+				SecretConfig: []miniov1beta1.ConfigSecretValue{{
+					Key:          "client_secret",
+					SecretKeyRef: miniov1beta1.SecretKeySelector{Name: "keycloak", Key: "client-secret"},
+				}},
+			},
+		}
+	})
+
+	Context("When creating an IdentityProvider", func() {
+		It("should admit a valid OpenID provider", func() {
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the discovery URL of OpenID providers", func() {
+			delete(provider.Spec.Config, "config_url")
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject plain client secrets", func() {
+			provider.Spec.SecretConfig = nil
+			provider.Spec.Config["client_secret"] = "plaintext"
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a claim name combined with a role policy", func() {
+			provider.Spec.Config["role_policy"] = "readonly"
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a value with a line break", func() {
+			provider.Spec.Config["client_id"] = "minio\nrole_policy=consoleAdmin"
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should admit a valid LDAP provider", func() {
+			provider.Spec.Type = miniov1beta1.IdentityProviderTypeLDAP
+			provider.Spec.ConfigName = ""
+			provider.Spec.Config = map[string]string{
+				"server_addr":            "ldap.example.com:636",
+				"lookup_bind_dn":         "cn=minio,ou=services,dc=example,dc=com",
+				"user_dn_search_base_dn": "ou=people,dc=example,dc=com",
+				"user_dn_search_filter":  "(uid=%s)",
+			}
+			provider.Spec.SecretConfig = []miniov1beta1.ConfigSecretValue{{
+				Key:          "lookup_bind_password",
+				SecretKeyRef: miniov1beta1.SecretKeySelector{Name: "ldap", Key: "password"},
+			}}
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject named LDAP configurations", func() {
+			provider.Spec.Type = miniov1beta1.IdentityProviderTypeLDAP
+			provider.Spec.Config = map[string]string{"server_addr": "ldap.example.com:636"}
+			provider.Spec.SecretConfig = nil
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When tenant policies apply to the namespace", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&miniov1beta1.TenantPolicy{ObjectMeta: metav1.ObjectMeta{Name: "tenants"}},
+			).Build()
+		})
+
+		AfterEach(func() {
+			validator.Client = nil
+		})
+
+		It("should admit a provider on an alias of the namespace", func() {
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a provider on a cluster alias", func() {
+			provider.Spec.Connection = miniov1beta1.MinIOConnection{ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "shared"}}
+			_, err := validator.ValidateCreate(ctx, provider)
+			Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		})
+	})
+
+	Context("When updating an IdentityProvider", func() {
+		It("should reject a changed configuration name", func() {
+			updated := provider.DeepCopy()
+			updated.Spec.ConfigName = "dex"
+			_, err := validator.ValidateUpdate(ctx, provider, updated)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When defaulting an IdentityProvider", func() {
+		It("should restart the server if required", func() {
+			Expect(defaulter.Default(ctx, provider)).To(Succeed())
+			Expect(provider.Spec.RestartPolicy).To(Equal(miniov1beta1.RestartPolicyIfRequired))
+		})
+	})
+})
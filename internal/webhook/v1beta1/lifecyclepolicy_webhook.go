@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupLifecyclePolicyWebhookWithManager registers the webhooks for LifecyclePolicy in the manager
+func SetupLifecyclePolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.LifecyclePolicy{}).
+		WithValidator(&LifecyclePolicyCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&LifecyclePolicyCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-lifecyclepolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=lifecyclepolicies,verbs=create;update,versions=v1beta1,name=mlifecyclepolicy-v1beta1.kb.io,admissionReviewVersions=v1
+
+// LifecyclePolicyCustomDefaulter sets default values on LifecyclePolicy resources
+type LifecyclePolicyCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &LifecyclePolicyCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *LifecyclePolicyCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	lifecyclePolicy, ok := obj.(*miniov1beta1.LifecyclePolicy)
+	if !ok {
+		return fmt.Errorf("expected a LifecyclePolicy object but got %T", obj)
+	}
+
+	defaultConnection(&lifecyclePolicy.Spec.Connection)
+	for i := range lifecyclePolicy.Spec.Rules {
+		if lifecyclePolicy.Spec.Rules[i].Status == "" {
+			lifecyclePolicy.Spec.Rules[i].Status = miniov1beta1.LifecycleRuleStatusEnabled
+		}
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-lifecyclepolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=lifecyclepolicies,verbs=create;update,versions=v1beta1,name=vlifecyclepolicy-v1beta1.kb.io,admissionReviewVersions=v1
+
+// LifecyclePolicyCustomValidator validates LifecyclePolicy resources
+type LifecyclePolicyCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &LifecyclePolicyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *LifecyclePolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	lifecyclePolicy, ok := obj.(*miniov1beta1.LifecyclePolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a LifecyclePolicy object but got %T", obj)
+	}
+
+	warnings := append(connectionWarnings(lifecyclePolicy.Annotations), tierWarnings(ctx, v.Client, lifecyclePolicy)...)
+	if err := invalid("LifecyclePolicy", lifecyclePolicy.Name, validateLifecyclePolicy(lifecyclePolicy)); err != nil {
+		return warnings, err
+	}
+	return warnings, validateTenantPolicies(ctx, v.Client, "lifecyclepolicies", lifecyclePolicy)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *LifecyclePolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldLifecyclePolicy, ok := oldObj.(*miniov1beta1.LifecyclePolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a LifecyclePolicy object but got %T", oldObj)
+	}
+	lifecyclePolicy, ok := newObj.(*miniov1beta1.LifecyclePolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a LifecyclePolicy object but got %T", newObj)
+	}
+
+	allErrs := validateLifecyclePolicy(lifecyclePolicy)
+	allErrs = append(allErrs, validateImmutable(lifecyclePolicy.Spec.BucketName, oldLifecyclePolicy.Spec.BucketName, field.NewPath("spec", "bucketName"))...)
+
+	warnings := connectionWarnings(lifecyclePolicy.Annotations)
+	if err := invalid("LifecyclePolicy", lifecyclePolicy.Name, allErrs); err != nil {
+		return warnings, err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldLifecyclePolicy.Spec, lifecyclePolicy.Spec) {
+		return warnings, nil
+	}
+	warnings = append(warnings, tierWarnings(ctx, v.Client, lifecyclePolicy)...)
+	return warnings, validateTenantPolicies(ctx, v.Client, "lifecyclepolicies", lifecyclePolicy)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *LifecyclePolicyCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateLifecyclePolicy validates the spec of a LifecyclePolicy
+func validateLifecyclePolicy(lifecyclePolicy *miniov1beta1.LifecyclePolicy) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateConnection(lifecyclePolicy.Spec.Connection, lifecyclePolicy.Annotations, specPath.Child("connection"))
+	allErrs = append(allErrs, validateBucketName(lifecyclePolicy.Spec.BucketName, specPath.Child("bucketName"))...)
+
+	rulesPath := specPath.Child("rules")
+	if len(lifecyclePolicy.Spec.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(rulesPath, "at least one rule must be set"))
+	}
+
+	ruleIDs := map[string]bool{}
+	for i, rule := range lifecyclePolicy.Spec.Rules {
+		rulePath := rulesPath.Index(i)
+
+		switch {
+		case rule.ID == "":
+			allErrs = append(allErrs, field.Required(rulePath.Child("id"), "rule ID must be set"))
+		case len(rule.ID) > 255:
+			allErrs = append(allErrs, field.TooLong(rulePath.Child("id"), rule.ID, 255))
+		case ruleIDs[rule.ID]:
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("id"), rule.ID))
+		}
+		ruleIDs[rule.ID] = true
+
+		// MinIO keeps a single transition of current and of noncurrent versions per rule, and has no
+		// default tier: a transition without a storage class is rejected when the rules are put to the bucket
+		if len(rule.Transitions) > 1 {
+			allErrs = append(allErrs, field.TooMany(rulePath.Child("transitions"), len(rule.Transitions), 1))
+		}
+		if len(rule.NoncurrentVersionTransitions) > 1 {
+			allErrs = append(allErrs, field.TooMany(rulePath.Child("noncurrentVersionTransitions"), len(rule.NoncurrentVersionTransitions), 1))
+		}
+		for j, transition := range rule.Transitions {
+			if transition.StorageClass == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("transitions").Index(j).Child("storageClass"), "tier to transition to must be set"))
+			}
+		}
+		for j, transition := range rule.NoncurrentVersionTransitions {
+			if transition.StorageClass == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("noncurrentVersionTransitions").Index(j).Child("storageClass"), "tier to transition to must be set"))
+			}
+		}
+
+		switch rule.Status {
+		case "", miniov1beta1.LifecycleRuleStatusEnabled, miniov1beta1.LifecycleRuleStatusDisabled:
+		default:
+			allErrs = append(allErrs, field.NotSupported(rulePath.Child("status"), rule.Status,
+				[]string{string(miniov1beta1.LifecycleRuleStatusEnabled), string(miniov1beta1.LifecycleRuleStatusDisabled)}))
+		}
+	}
+
+	return allErrs
+}
+
+// tierWarnings warns about transitions to tiers that no Tier in the namespace of the LifecyclePolicy
+// creates. Tiers may also be added to MinIO without a Tier resource, so the transitions are not rejected.
+func tierWarnings(ctx context.Context, c client.Reader, lifecyclePolicy *miniov1beta1.LifecyclePolicy) admission.Warnings {
+	if c == nil {
+		return nil
+	}
+
+	var storageClasses []string
+	for _, rule := range lifecyclePolicy.Spec.Rules {
+		for _, transition := range rule.Transitions {
+			storageClasses = append(storageClasses, transition.StorageClass)
+		}
+		for _, transition := range rule.NoncurrentVersionTransitions {
+			storageClasses = append(storageClasses, transition.StorageClass)
+		}
+	}
+	if len(storageClasses) == 0 {
+		return nil
+	}
+
+	tiers := &miniov1beta1.TierList{}
+	if err := c.List(ctx, tiers, client.InNamespace(lifecyclePolicy.Namespace)); err != nil {
+		return admission.Warnings{fmt.Sprintf("failed to check the tiers of the transitions: %v", err)}
+	}
+	known := map[string]bool{}
+	for _, tier := range tiers.Items {
+		known[tier.Spec.TierName] = true
+	}
+
+	var warnings admission.Warnings
+	for _, storageClass := range storageClasses {
+		if storageClass != "" && !known[storageClass] {
+			warnings = append(warnings, fmt.Sprintf("transitions to %s fail unless the tier exists in MinIO, no Tier in namespace %s creates it",
+				storageClass, lifecyclePolicy.Namespace))
+			known[storageClass] = true
+		}
+	}
+	return warnings
+}
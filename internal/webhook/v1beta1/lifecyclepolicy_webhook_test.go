@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("LifecyclePolicy Webhook", func() {
+	var (
+		ctx             context.Context
+		lifecyclePolicy *miniov1beta1.LifecyclePolicy
+		validator       LifecyclePolicyCustomValidator
+		defaulter       LifecyclePolicyCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		days := 30
+		lifecyclePolicy = &miniov1beta1.LifecyclePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-lifecycle", Namespace: "default"},
+			Spec: miniov1beta1.LifecyclePolicySpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				BucketName: "test-bucket",
+				Rules: []miniov1beta1.LifecycleRule{
+					{ID: "expire-logs", Expiration: &miniov1beta1.LifecycleExpiration{Days: &days}},
+					{ID: "expire-tmp", Status: miniov1beta1.LifecycleRuleStatusDisabled, Expiration: &miniov1beta1.LifecycleExpiration{Days: &days}},
+				},
+			},
+		}
+	})
+
+	It("should admit rules with unique IDs", func() {
+		_, err := validator.ValidateCreate(ctx, lifecyclePolicy)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject duplicate rule IDs", func() {
+		lifecyclePolicy.Spec.Rules[1].ID = "expire-logs"
+		_, err := validator.ValidateCreate(ctx, lifecyclePolicy)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Duplicate value"))
+	})
+
+	It("should reject empty rule IDs", func() {
+		lifecyclePolicy.Spec.Rules[0].ID = ""
+		_, err := validator.ValidateCreate(ctx, lifecyclePolicy)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject changing the bucket name", func() {
+		updated := lifecyclePolicy.DeepCopy()
+		updated.Spec.BucketName = "other-bucket"
+		_, err := validator.ValidateUpdate(ctx, lifecyclePolicy, updated)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should enable rules without a status", func() {
+		Expect(defaulter.Default(ctx, lifecyclePolicy)).To(Succeed())
+		Expect(lifecyclePolicy.Spec.Rules[0].Status).To(Equal(miniov1beta1.LifecycleRuleStatusEnabled))
+		Expect(lifecyclePolicy.Spec.Rules[1].Status).To(Equal(miniov1beta1.LifecycleRuleStatusDisabled))
+	})
+
+	Context("When rules transition objects to a tier", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(miniov1beta1.AddToScheme(scheme)).To(Succeed())
+			validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&miniov1beta1.Tier{
+					ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: "default"},
+					Spec:       miniov1beta1.TierSpec{TierName: "WARM", Type: miniov1beta1.TierTypeMinIO},
+				},
+			).Build()
+		})
+
+		AfterEach(func() {
+			validator.Client = nil
+		})
+
+		It("should admit a transition to a tier of the namespace without warnings", func() {
+			days := 7
+			lifecyclePolicy.Spec.Rules[0].Transitions = []miniov1beta1.LifecycleTransition{{Days: &days, StorageClass: "WARM"}}
+			warnings, err := validator.ValidateCreate(ctx, lifecyclePolicy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should warn about a transition to an unknown tier", func() {
+			lifecyclePolicy.Spec.Rules[0].NoncurrentVersionTransitions = []miniov1beta1.NoncurrentVersionTransition{{NoncurrentDays: 7, StorageClass: "COLD"}}
+			warnings, err := validator.ValidateCreate(ctx, lifecyclePolicy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("COLD")))
+		})
+
+		It("should reject a transition without tier", func() {
+			days := 7
+			lifecyclePolicy.Spec.Rules[0].Transitions = []miniov1beta1.LifecycleTransition{{Days: &days}}
+			_, err := validator.ValidateCreate(ctx, lifecyclePolicy)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject more than one transition per rule", func() {
+			days, later := 7, 30
+			lifecyclePolicy.Spec.Rules[0].Transitions = []miniov1beta1.LifecycleTransition{
+				{Days: &days, StorageClass: "WARM"},
+				{Days: &later, StorageClass: "WARM"},
+			}
+			_, err := validator.ValidateCreate(ctx, lifecyclePolicy)
+			Expect(err).To(MatchError(ContainSubstring("spec.rules[0].transitions")))
+		})
+	})
+})
@@ -0,0 +1,178 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var (
+	// targetNamePattern matches the identifiers of notification targets, which become part of their ARN
+	targetNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// configKeyPattern matches the keys of the MinIO configuration
+	configKeyPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// SetupNotificationTargetWebhookWithManager registers the webhooks for NotificationTarget in the manager
+func SetupNotificationTargetWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.NotificationTarget{}).
+		WithValidator(&NotificationTargetCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&NotificationTargetCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-notificationtarget,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=notificationtargets,verbs=create;update,versions=v1beta1,name=mnotificationtarget-v1beta1.kb.io,admissionReviewVersions=v1
+
+// NotificationTargetCustomDefaulter sets default values on NotificationTarget resources
+type NotificationTargetCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &NotificationTargetCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *NotificationTargetCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	target, ok := obj.(*miniov1beta1.NotificationTarget)
+	if !ok {
+		return fmt.Errorf("expected a NotificationTarget object but got %T", obj)
+	}
+
+	defaultConnection(&target.Spec.Connection)
+	if target.Spec.RestartPolicy == "" {
+		target.Spec.RestartPolicy = miniov1beta1.RestartPolicyIfRequired
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-notificationtarget,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=notificationtargets,verbs=create;update,versions=v1beta1,name=vnotificationtarget-v1beta1.kb.io,admissionReviewVersions=v1
+
+// NotificationTargetCustomValidator validates NotificationTarget resources
+type NotificationTargetCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &NotificationTargetCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *NotificationTargetCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	target, ok := obj.(*miniov1beta1.NotificationTarget)
+	if !ok {
+		return nil, fmt.Errorf("expected a NotificationTarget object but got %T", obj)
+	}
+
+	if err := invalid("NotificationTarget", target.Name, validateNotificationTarget(target)); err != nil {
+		return connectionWarnings(target.Annotations), err
+	}
+	return connectionWarnings(target.Annotations), validateTenantPolicies(ctx, v.Client, "notificationtargets", target)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *NotificationTargetCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldTarget, ok := oldObj.(*miniov1beta1.NotificationTarget)
+	if !ok {
+		return nil, fmt.Errorf("expected a NotificationTarget object but got %T", oldObj)
+	}
+	target, ok := newObj.(*miniov1beta1.NotificationTarget)
+	if !ok {
+		return nil, fmt.Errorf("expected a NotificationTarget object but got %T", newObj)
+	}
+
+	// Type and name make up the configuration key and the ARN buckets publish to
+	specPath := field.NewPath("spec")
+	allErrs := validateNotificationTarget(target)
+	allErrs = append(allErrs, validateImmutable(target.Spec.Type, oldTarget.Spec.Type, specPath.Child("type"))...)
+	allErrs = append(allErrs, validateImmutable(target.Spec.TargetName, oldTarget.Spec.TargetName, specPath.Child("targetName"))...)
+
+	if err := invalid("NotificationTarget", target.Name, allErrs); err != nil {
+		return connectionWarnings(target.Annotations), err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldTarget.Spec, target.Spec) {
+		return connectionWarnings(target.Annotations), nil
+	}
+	return connectionWarnings(target.Annotations), validateTenantPolicies(ctx, v.Client, "notificationtargets", target)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *NotificationTargetCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateNotificationTarget validates the spec of a NotificationTarget
+func validateNotificationTarget(target *miniov1beta1.NotificationTarget) field.ErrorList {
+	specPath := field.NewPath("spec")
+	spec := target.Spec
+
+	allErrs := validateConnection(spec.Connection, target.Annotations, specPath.Child("connection"))
+	if !targetNamePattern.MatchString(spec.TargetName) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("targetName"), spec.TargetName,
+			"target name must consist of letters, digits, '-' and '_'"))
+	}
+	if _, ok := spec.Config["enable"]; ok {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("config").Key("enable"), "the target is enabled by the controller"))
+	}
+	allErrs = append(allErrs, validateConfigKeys(spec.Config, specPath.Child("config"), spec.SecretConfig, specPath.Child("secretConfig"))...)
+	return allErrs
+}
+
+// validateConfigKeys checks the keys of a MinIO configuration, which must be set either as plain or as secret value.
+// Plain values must not contain control characters, which could start another configuration line.
+func validateConfigKeys(config map[string]string, configPath *field.Path, secretConfig []miniov1beta1.ConfigSecretValue, secretConfigPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, key := range slices.Sorted(maps.Keys(config)) {
+		if !configKeyPattern.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(configPath.Key(key), key, "configuration keys must consist of lower case letters, digits and '_'"))
+		}
+		if strings.ContainsFunc(config[key], unicode.IsControl) {
+			allErrs = append(allErrs, field.Invalid(configPath.Key(key), config[key], "configuration values must not contain control characters"))
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, value := range secretConfig {
+		valuePath := secretConfigPath.Index(i)
+		switch _, plain := config[value.Key]; {
+		case !configKeyPattern.MatchString(value.Key):
+			allErrs = append(allErrs, field.Invalid(valuePath.Child("key"), value.Key, "configuration keys must consist of lower case letters, digits and '_'"))
+		case plain || seen[value.Key]:
+			allErrs = append(allErrs, field.Duplicate(valuePath.Child("key"), value.Key))
+		}
+		seen[value.Key] = true
+		if value.SecretKeyRef.Name == "" {
+			allErrs = append(allErrs, field.Required(valuePath.Child("secretKeyRef", "name"), "secret name must be set"))
+		}
+		if value.SecretKeyRef.Key == "" {
+			allErrs = append(allErrs, field.Required(valuePath.Child("secretKeyRef", "key"), "secret key must be set"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("NotificationTarget Webhook", func() {
+	var (
+		ctx       context.Context
+		target    *miniov1beta1.NotificationTarget
+		validator NotificationTargetCustomValidator
+		defaulter NotificationTargetCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		target = &miniov1beta1.NotificationTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "audit-webhook", Namespace: "default"},
+			Spec: miniov1beta1.NotificationTargetSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				Type:       miniov1beta1.NotificationTargetTypeWebhook,
+				TargetName: "audit",
+				Config:     map[string]string{"endpoint": "https://events.example.com/minio"},
+				SecretConfig: []miniov1beta1.ConfigSecretValue{{
+					Key:          "auth_token",
+					SecretKeyRef: miniov1beta1.SecretKeySelector{Name: "audit-webhook", Key: "token"},
+				}},
+			},
+		}
+	})
+
+	Context("When creating a NotificationTarget", func() {
+		It("should admit a valid webhook target", func() {
+			_, err := validator.ValidateCreate(ctx, target)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a target name that cannot be part of an ARN", func() {
+			target.Spec.TargetName = "audit:events"
+			_, err := validator.ValidateCreate(ctx, target)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject setting the enable key", func() {
+			target.Spec.Config["enable"] = "off"
+			_, err := validator.ValidateCreate(ctx, target)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a key set both plainly and from a secret", func() {
+			target.Spec.Config["auth_token"] = "plaintext"
+			_, err := validator.ValidateCreate(ctx, target)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a value with a line break", func() {
+			target.Spec.Config["endpoint"] = "https://events.example.com/minio\nnotify_webhook:other enable=on"
+			_, err := validator.ValidateCreate(ctx, target)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a secret value without key", func() {
+			target.Spec.SecretConfig[0].SecretKeyRef.Key = ""
+			_, err := validator.ValidateCreate(ctx, target)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When updating a NotificationTarget", func() {
+		It("should admit changing the configuration", func() {
+			updated := target.DeepCopy()
+			updated.Spec.Config["endpoint"] = "https://events-v2.example.com/minio"
+			_, err := validator.ValidateUpdate(ctx, target, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject renaming the target", func() {
+			updated := target.DeepCopy()
+			updated.Spec.TargetName = "audit-v2"
+			_, err := validator.ValidateUpdate(ctx, target, updated)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When defaulting a NotificationTarget", func() {
+		It("should restart the server only if required", func() {
+			Expect(defaulter.Default(ctx, target)).To(Succeed())
+			Expect(target.Spec.RestartPolicy).To(Equal(miniov1beta1.RestartPolicyIfRequired))
+		})
+	})
+})
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// policyVersions are the IAM policy language versions accepted by MinIO
+var policyVersions = []string{"2012-10-17", "2008-10-17"}
+
+// policyDocument is the structure of an IAM policy document
+type policyDocument struct {
+	Version   string            `json:"Version"`
+	ID        string            `json:"Id,omitempty"`
+	Statement []policyStatement `json:"Statement"`
+}
+
+// policyStatement is a single statement of an IAM policy document
+type policyStatement struct {
+	Sid          string          `json:"Sid,omitempty"`
+	Effect       string          `json:"Effect"`
+	Principal    json.RawMessage `json:"Principal,omitempty"`
+	NotPrincipal json.RawMessage `json:"NotPrincipal,omitempty"`
+	Action       stringList      `json:"Action,omitempty"`
+	NotAction    stringList      `json:"NotAction,omitempty"`
+	Resource     stringList      `json:"Resource,omitempty"`
+	NotResource  stringList      `json:"NotResource,omitempty"`
+	Condition    json.RawMessage `json:"Condition,omitempty"`
+}
+
+// stringList is a JSON value that is either a single string or a list of strings
+type stringList []string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = stringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("expected a string or a list of strings")
+	}
+	*l = list
+	return nil
+}
+
+// validatePolicyDocument checks that document is a valid IAM policy
+func validatePolicyDocument(document []byte, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(bytes.TrimSpace(document)) == 0 {
+		return append(allErrs, field.Required(fldPath, "policy document must be set"))
+	}
+
+	var policy policyDocument
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policy); err != nil {
+		return append(allErrs, field.Invalid(fldPath, string(document), fmt.Sprintf("policy is not a valid IAM policy document: %v", err)))
+	}
+
+	if !slices.Contains(policyVersions, policy.Version) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("Version"), policy.Version, policyVersions))
+	}
+	if len(policy.Statement) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("Statement"), "policy must contain at least one statement"))
+	}
+
+	for i, statement := range policy.Statement {
+		statementPath := fldPath.Child("Statement").Index(i)
+
+		if statement.Effect != "Allow" && statement.Effect != "Deny" {
+			allErrs = append(allErrs, field.NotSupported(statementPath.Child("Effect"), statement.Effect, []string{"Allow", "Deny"}))
+		}
+
+		switch {
+		case len(statement.Action) == 0 && len(statement.NotAction) == 0:
+			allErrs = append(allErrs, field.Required(statementPath.Child("Action"), "statement must contain Action or NotAction"))
+		case len(statement.Action) > 0 && len(statement.NotAction) > 0:
+			allErrs = append(allErrs, field.Invalid(statementPath, "", "statement must not contain both Action and NotAction"))
+		}
+		for j, action := range slices.Concat(statement.Action, statement.NotAction) {
+			if action != "*" && !strings.Contains(action, ":") {
+				allErrs = append(allErrs, field.Invalid(statementPath.Child("Action").Index(j), action, "action must have the form <service>:<action>"))
+			}
+		}
+
+		if len(statement.Resource) > 0 && len(statement.NotResource) > 0 {
+			allErrs = append(allErrs, field.Invalid(statementPath, "", "statement must not contain both Resource and NotResource"))
+		}
+		for j, resource := range statement.Resource {
+			if resource != "*" && !strings.HasPrefix(resource, "arn:") {
+				allErrs = append(allErrs, field.Invalid(statementPath.Child("Resource").Index(j), resource, "resource must be an ARN"))
+			}
+		}
+		for j, resource := range statement.NotResource {
+			if resource != "*" && !strings.HasPrefix(resource, "arn:") {
+				allErrs = append(allErrs, field.Invalid(statementPath.Child("NotResource").Index(j), resource, "resource must be an ARN"))
+			}
+		}
+	}
+
+	return allErrs
+}
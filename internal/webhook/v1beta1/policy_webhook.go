@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupPolicyWebhookWithManager registers the webhooks for Policy in the manager
+func SetupPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.Policy{}).
+		WithValidator(&PolicyCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&PolicyCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-policy,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=policies,verbs=create;update,versions=v1beta1,name=mpolicy-v1beta1.kb.io,admissionReviewVersions=v1
+
+// PolicyCustomDefaulter sets default values on Policy resources
+type PolicyCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &PolicyCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *PolicyCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*miniov1beta1.Policy)
+	if !ok {
+		return fmt.Errorf("expected a Policy object but got %T", obj)
+	}
+
+	defaultConnection(&policy.Spec.Connection)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-policy,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=policies,verbs=create;update,versions=v1beta1,name=vpolicy-v1beta1.kb.io,admissionReviewVersions=v1
+
+// PolicyCustomValidator validates Policy resources
+type PolicyCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &PolicyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *PolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*miniov1beta1.Policy)
+	if !ok {
+		return nil, fmt.Errorf("expected a Policy object but got %T", obj)
+	}
+
+	if err := invalid("Policy", policy.Name, validatePolicy(policy)); err != nil {
+		return connectionWarnings(policy.Annotations), err
+	}
+	return connectionWarnings(policy.Annotations), validateTenantPolicies(ctx, v.Client, "policies", policy)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *PolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldPolicy, ok := oldObj.(*miniov1beta1.Policy)
+	if !ok {
+		return nil, fmt.Errorf("expected a Policy object but got %T", oldObj)
+	}
+	policy, ok := newObj.(*miniov1beta1.Policy)
+	if !ok {
+		return nil, fmt.Errorf("expected a Policy object but got %T", newObj)
+	}
+
+	allErrs := validatePolicy(policy)
+	allErrs = append(allErrs, validateImmutable(policy.Spec.PolicyName, oldPolicy.Spec.PolicyName, field.NewPath("spec", "policyName"))...)
+
+	if err := invalid("Policy", policy.Name, allErrs); err != nil {
+		return connectionWarnings(policy.Annotations), err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldPolicy.Spec, policy.Spec) {
+		return connectionWarnings(policy.Annotations), nil
+	}
+	return connectionWarnings(policy.Annotations), validateTenantPolicies(ctx, v.Client, "policies", policy)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *PolicyCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validatePolicy validates the spec of a Policy
+func validatePolicy(policy *miniov1beta1.Policy) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateConnection(policy.Spec.Connection, policy.Annotations, specPath.Child("connection"))
+	if policy.Spec.PolicyName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("policyName"), "policy name must be set"))
+	}
+	allErrs = append(allErrs, validatePolicyDocument(policy.Spec.Policy, specPath.Child("policy"))...)
+
+	return allErrs
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Policy Webhook", func() {
+	var (
+		ctx       context.Context
+		policy    *miniov1beta1.Policy
+		validator PolicyCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		policy = &miniov1beta1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: "default"},
+			Spec: miniov1beta1.PolicySpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				PolicyName: "readonly",
+				Policy: []byte(`{
+					"Version": "2012-10-17",
+					"Statement": [{
+						"Effect": "Allow",
+						"Action": ["s3:GetObject", "s3:ListBucket"],
+						"Resource": "arn:aws:s3:::test-bucket/*"
+					}]
+				}`),
+			},
+		}
+	})
+
+	It("should admit a valid policy document", func() {
+		_, err := validator.ValidateCreate(ctx, policy)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("should reject invalid policy documents",
+		func(document string) {
+			policy.Spec.Policy = []byte(document)
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty document", ``),
+		Entry("malformed JSON", `{"Version": "2012-10-17",`),
+		Entry("unknown version", `{"Version": "2020-01-01", "Statement": [{"Effect": "Allow", "Action": "s3:*"}]}`),
+		Entry("no statements", `{"Version": "2012-10-17", "Statement": []}`),
+		Entry("invalid effect", `{"Version": "2012-10-17", "Statement": [{"Effect": "Permit", "Action": "s3:*"}]}`),
+		Entry("missing action", `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Resource": "arn:aws:s3:::*"}]}`),
+		Entry("unknown field", `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Actions": "s3:*"}]}`),
+		Entry("invalid resource", `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": "s3:*", "Resource": "test-bucket"}]}`),
+	)
+
+	It("should reject changing the policy name", func() {
+		updated := policy.DeepCopy()
+		updated.Spec.PolicyName = "readwrite"
+		_, err := validator.ValidateUpdate(ctx, policy, updated)
+		Expect(err).To(HaveOccurred())
+	})
+})
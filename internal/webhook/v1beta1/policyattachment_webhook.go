@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupPolicyAttachmentWebhookWithManager registers the webhooks for PolicyAttachment in the manager
+func SetupPolicyAttachmentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.PolicyAttachment{}).
+		WithValidator(&PolicyAttachmentCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&PolicyAttachmentCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-policyattachment,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=policyattachments,verbs=create;update,versions=v1beta1,name=mpolicyattachment-v1beta1.kb.io,admissionReviewVersions=v1
+
+// PolicyAttachmentCustomDefaulter sets default values on PolicyAttachment resources
+type PolicyAttachmentCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &PolicyAttachmentCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *PolicyAttachmentCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	attachment, ok := obj.(*miniov1beta1.PolicyAttachment)
+	if !ok {
+		return fmt.Errorf("expected a PolicyAttachment object but got %T", obj)
+	}
+
+	defaultConnection(&attachment.Spec.Connection)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-policyattachment,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=policyattachments,verbs=create;update,versions=v1beta1,name=vpolicyattachment-v1beta1.kb.io,admissionReviewVersions=v1
+
+// PolicyAttachmentCustomValidator validates PolicyAttachment resources
+type PolicyAttachmentCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &PolicyAttachmentCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *PolicyAttachmentCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	attachment, ok := obj.(*miniov1beta1.PolicyAttachment)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyAttachment object but got %T", obj)
+	}
+
+	if err := invalid("PolicyAttachment", attachment.Name, validatePolicyAttachment(attachment)); err != nil {
+		return connectionWarnings(attachment.Annotations), err
+	}
+	return connectionWarnings(attachment.Annotations), validateTenantPolicies(ctx, v.Client, "policyattachments", attachment)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *PolicyAttachmentCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldAttachment, ok := oldObj.(*miniov1beta1.PolicyAttachment)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyAttachment object but got %T", oldObj)
+	}
+	attachment, ok := newObj.(*miniov1beta1.PolicyAttachment)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicyAttachment object but got %T", newObj)
+	}
+
+	if err := invalid("PolicyAttachment", attachment.Name, validatePolicyAttachment(attachment)); err != nil {
+		return connectionWarnings(attachment.Annotations), err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldAttachment.Spec, attachment.Spec) {
+		return connectionWarnings(attachment.Annotations), nil
+	}
+	return connectionWarnings(attachment.Annotations), validateTenantPolicies(ctx, v.Client, "policyattachments", attachment)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *PolicyAttachmentCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validatePolicyAttachment validates the spec of a PolicyAttachment
+func validatePolicyAttachment(attachment *miniov1beta1.PolicyAttachment) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateConnection(attachment.Spec.Connection, attachment.Annotations, specPath.Child("connection"))
+	if attachment.Spec.PolicyName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("policyName"), "policy name must be set"))
+	}
+
+	target := attachment.Spec.Target
+	targetPath := specPath.Child("target")
+	targets := 0
+	for _, name := range []*string{target.User, target.Group, target.ServiceAccount, target.LDAPUser, target.LDAPGroup} {
+		if name != nil && *name != "" {
+			targets++
+		}
+	}
+	if target.OpenIDClaim != nil {
+		targets++
+		if target.OpenIDClaim.IdentityProviderRef.Name == "" {
+			allErrs = append(allErrs, field.Required(targetPath.Child("openIDClaim", "identityProviderRef", "name"), "identity provider name must be set"))
+		}
+	}
+	if targets != 1 {
+		allErrs = append(allErrs, field.Invalid(targetPath, "", "exactly one of user, group, serviceAccount, ldapUser, ldapGroup or openIDClaim must be set"))
+	}
+	allErrs = append(allErrs, validateDN(target.LDAPUser, targetPath.Child("ldapUser"))...)
+	allErrs = append(allErrs, validateDN(target.LDAPGroup, targetPath.Child("ldapGroup"))...)
+
+	return allErrs
+}
+
+// validateDN checks that an LDAP distinguished name consists of attribute=value pairs, e.g.
+// cn=developers,ou=groups,dc=example,dc=com
+func validateDN(dn *string, fldPath *field.Path) field.ErrorList {
+	if dn == nil || *dn == "" {
+		return nil
+	}
+	// Commas escaped with a backslash are part of a value
+	escaped := false
+	rdn := ""
+	for _, r := range *dn + "," {
+		switch {
+		case escaped:
+			escaped = false
+			rdn += string(r)
+		case r == '\\':
+			escaped = true
+			rdn += string(r)
+		case r == ',':
+			attribute, value, ok := strings.Cut(rdn, "=")
+			if !ok || strings.TrimSpace(attribute) == "" || strings.TrimSpace(value) == "" {
+				return field.ErrorList{field.Invalid(fldPath, *dn, fmt.Sprintf("invalid relative distinguished name %q, expected attribute=value", rdn))}
+			}
+			rdn = ""
+		default:
+			rdn += string(r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("PolicyAttachment Webhook", func() {
+	var (
+		ctx        context.Context
+		attachment *miniov1beta1.PolicyAttachment
+		validator  PolicyAttachmentCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		attachment = &miniov1beta1.PolicyAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "developers-readwrite", Namespace: "default"},
+			Spec: miniov1beta1.PolicyAttachmentSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				PolicyName: "readwrite",
+			},
+		}
+	})
+
+	Context("When attaching a policy to an LDAP principal", func() {
+		It("should admit a distinguished name", func() {
+			dn := `cn=Doe\, Jane,ou=people,dc=example,dc=com`
+			attachment.Spec.Target.LDAPUser = &dn
+			_, err := validator.ValidateCreate(ctx, attachment)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a malformed distinguished name", func() {
+			dn := "developers"
+			attachment.Spec.Target.LDAPGroup = &dn
+			_, err := validator.ValidateCreate(ctx, attachment)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a second target", func() {
+			user, dn := "app", "cn=developers,ou=groups,dc=example,dc=com"
+			attachment.Spec.Target.User = &user
+			attachment.Spec.Target.LDAPGroup = &dn
+			_, err := validator.ValidateCreate(ctx, attachment)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When mapping an OpenID claim to a policy", func() {
+		It("should admit a claim of an identity provider", func() {
+			attachment.Spec.Target.OpenIDClaim = &miniov1beta1.OpenIDClaimTarget{
+				IdentityProviderRef: miniov1beta1.IdentityProviderReference{Name: "keycloak"},
+				ClaimValue:          "developers",
+			}
+			_, err := validator.ValidateCreate(ctx, attachment)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should require the identity provider", func() {
+			attachment.Spec.Target.OpenIDClaim = &miniov1beta1.OpenIDClaimTarget{ClaimValue: "developers"}
+			_, err := validator.ValidateCreate(ctx, attachment)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// subSystemPattern matches configuration subsystems, optionally qualified with a target
+var subSystemPattern = regexp.MustCompile(`^[a-z_]+(:[A-Za-z0-9_-]+)?$`)
+
+// SetupServerConfigWebhookWithManager registers the webhooks for ServerConfig in the manager
+func SetupServerConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.ServerConfig{}).
+		WithValidator(&ServerConfigCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&ServerConfigCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-serverconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=serverconfigs,verbs=create;update,versions=v1beta1,name=mserverconfig-v1beta1.kb.io,admissionReviewVersions=v1
+
+// ServerConfigCustomDefaulter sets default values on ServerConfig resources
+type ServerConfigCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ServerConfigCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *ServerConfigCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	serverConfig, ok := obj.(*miniov1beta1.ServerConfig)
+	if !ok {
+		return fmt.Errorf("expected a ServerConfig object but got %T", obj)
+	}
+
+	defaultConnection(&serverConfig.Spec.Connection)
+	if serverConfig.Spec.RestartPolicy == "" {
+		serverConfig.Spec.RestartPolicy = miniov1beta1.RestartPolicyNever
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-serverconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=serverconfigs,verbs=create;update,versions=v1beta1,name=vserverconfig-v1beta1.kb.io,admissionReviewVersions=v1
+
+// ServerConfigCustomValidator validates ServerConfig resources
+type ServerConfigCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ServerConfigCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *ServerConfigCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	serverConfig, ok := obj.(*miniov1beta1.ServerConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerConfig object but got %T", obj)
+	}
+
+	warnings := append(connectionWarnings(serverConfig.Annotations), serverConfigWarnings(serverConfig)...)
+	if err := invalid("ServerConfig", serverConfig.Name, validateServerConfig(serverConfig)); err != nil {
+		return warnings, err
+	}
+	return warnings, validateTenantPolicies(ctx, v.Client, "serverconfigs", serverConfig)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *ServerConfigCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldServerConfig, ok := oldObj.(*miniov1beta1.ServerConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerConfig object but got %T", oldObj)
+	}
+	serverConfig, ok := newObj.(*miniov1beta1.ServerConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a ServerConfig object but got %T", newObj)
+	}
+
+	warnings := append(connectionWarnings(serverConfig.Annotations), serverConfigWarnings(serverConfig)...)
+	if err := invalid("ServerConfig", serverConfig.Name, validateServerConfig(serverConfig)); err != nil {
+		return warnings, err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldServerConfig.Spec, serverConfig.Spec) {
+		return warnings, nil
+	}
+	return warnings, validateTenantPolicies(ctx, v.Client, "serverconfigs", serverConfig)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *ServerConfigCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateServerConfig validates the spec of a ServerConfig
+func validateServerConfig(serverConfig *miniov1beta1.ServerConfig) field.ErrorList {
+	specPath := field.NewPath("spec")
+	spec := serverConfig.Spec
+
+	allErrs := validateConnection(spec.Connection, serverConfig.Annotations, specPath.Child("connection"))
+	if len(spec.Config) == 0 && len(spec.SecretConfig) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("config"), "at least one setting must be configured"))
+	}
+	subSystems := slices.Collect(maps.Keys(spec.Config))
+	subSystems = append(subSystems, slices.Collect(maps.Keys(spec.SecretConfig))...)
+	slices.Sort(subSystems)
+	for _, key := range slices.Compact(subSystems) {
+		configPath, secretConfigPath := specPath.Child("config").Key(key), specPath.Child("secretConfig").Key(key)
+		if !subSystemPattern.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(configPath, key,
+				"subsystems must consist of lower case letters and '_', optionally followed by ':' and a target name"))
+			continue
+		}
+		allErrs = append(allErrs, validateConfigKeys(spec.Config[key], configPath, spec.SecretConfig[key], secretConfigPath)...)
+	}
+
+	if window := spec.MaintenanceWindow; window != nil {
+		windowPath := specPath.Child("maintenanceWindow")
+		if spec.RestartPolicy == miniov1beta1.RestartPolicyNever {
+			allErrs = append(allErrs, field.Forbidden(windowPath, "a maintenance window requires restartPolicy IfRequired"))
+		}
+		if _, err := time.Parse("15:04", window.Start); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("start"), window.Start, "start must be a time of day in HH:MM"))
+		}
+		if window.Duration.Duration <= 0 || window.Duration.Duration > 24*time.Hour {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("duration"), window.Duration.String(), "duration must be positive and at most 24h"))
+		}
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("timeZone"), window.TimeZone, "unknown time zone"))
+		}
+	}
+	return allErrs
+}
+
+// serverConfigWarnings warns about subsystems that are managed by dedicated kinds
+func serverConfigWarnings(serverConfig *miniov1beta1.ServerConfig) admission.Warnings {
+	var warnings admission.Warnings
+	for _, key := range slices.Sorted(maps.Keys(serverConfig.Spec.Config)) {
+		if strings.HasPrefix(key, "notify_") {
+			warnings = append(warnings, fmt.Sprintf("%s configures a notification target, which NotificationTargets manage with their ARN", key))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("ServerConfig Webhook", func() {
+	var (
+		ctx          context.Context
+		serverConfig *miniov1beta1.ServerConfig
+		validator    ServerConfigCustomValidator
+		defaulter    ServerConfigCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		serverConfig = &miniov1beta1.ServerConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "tuning", Namespace: "default"},
+			Spec: miniov1beta1.ServerConfigSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				Config: map[string]map[string]string{
+					"api":                  {"requests_max": "1600"},
+					"scanner":              {"speed": "slow"},
+					"audit_webhook:splunk": {"enable": "on", "endpoint": "https://splunk.example.com/minio"},
+				},
+				SecretConfig: map[string][]miniov1beta1.ConfigSecretValue{
+					"audit_webhook:splunk": {{
+						Key:          "auth_token",
+						SecretKeyRef: miniov1beta1.SecretKeySelector{Name: "splunk", Key: "token"},
+					}},
+				},
+				RestartPolicy: miniov1beta1.RestartPolicyIfRequired,
+				MaintenanceWindow: &miniov1beta1.MaintenanceWindow{
+					Days:     []string{"Sunday"},
+					Start:    "02:00",
+					Duration: metav1.Duration{Duration: 2 * time.Hour},
+					TimeZone: "Europe/Berlin",
+				},
+			},
+		}
+	})
+
+	Context("When creating a ServerConfig", func() {
+		It("should admit settings of subsystems and targets", func() {
+			warnings, err := validator.ValidateCreate(ctx, serverConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("should reject a malformed subsystem", func() {
+			serverConfig.Spec.Config["API"] = map[string]string{"requests_max": "1600"}
+			_, err := validator.ValidateCreate(ctx, serverConfig)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a key set both plainly and from a secret", func() {
+			serverConfig.Spec.Config["audit_webhook:splunk"]["auth_token"] = "plaintext"
+			_, err := validator.ValidateCreate(ctx, serverConfig)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a value with control characters", func() {
+			serverConfig.Spec.Config["scanner"]["speed"] = "slow\r\napi requests_max=1"
+			_, err := validator.ValidateCreate(ctx, serverConfig)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a maintenance window without restarts", func() {
+			serverConfig.Spec.RestartPolicy = miniov1beta1.RestartPolicyNever
+			_, err := validator.ValidateCreate(ctx, serverConfig)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject an unknown time zone", func() {
+			serverConfig.Spec.MaintenanceWindow.TimeZone = "Mars/Olympus_Mons"
+			_, err := validator.ValidateCreate(ctx, serverConfig)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should warn about notification targets", func() {
+			serverConfig.Spec.Config["notify_webhook:audit"] = map[string]string{"endpoint": "https://events.example.com"}
+			warnings, err := validator.ValidateCreate(ctx, serverConfig)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("NotificationTarget")))
+		})
+	})
+
+	Context("When defaulting a ServerConfig", func() {
+		It("should only report required restarts", func() {
+			serverConfig.Spec.RestartPolicy = ""
+			Expect(defaulter.Default(ctx, serverConfig)).To(Succeed())
+			Expect(serverConfig.Spec.RestartPolicy).To(Equal(miniov1beta1.RestartPolicyNever))
+		})
+	})
+})
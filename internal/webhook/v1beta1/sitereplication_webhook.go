@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupSiteReplicationWebhookWithManager registers the webhooks for SiteReplication in the manager
+func SetupSiteReplicationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.SiteReplication{}).
+		WithValidator(&SiteReplicationCustomValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-sitereplication,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=sitereplications,verbs=create;update,versions=v1beta1,name=vsitereplication-v1beta1.kb.io,admissionReviewVersions=v1
+
+// SiteReplicationCustomValidator validates SiteReplication resources
+type SiteReplicationCustomValidator struct{}
+
+var _ webhook.CustomValidator = &SiteReplicationCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *SiteReplicationCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	siteReplication, ok := obj.(*miniov1beta1.SiteReplication)
+	if !ok {
+		return nil, fmt.Errorf("expected a SiteReplication object but got %T", obj)
+	}
+
+	return nil, invalid("SiteReplication", siteReplication.Name, validateSiteReplication(siteReplication))
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *SiteReplicationCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	siteReplication, ok := newObj.(*miniov1beta1.SiteReplication)
+	if !ok {
+		return nil, fmt.Errorf("expected a SiteReplication object but got %T", newObj)
+	}
+
+	return nil, invalid("SiteReplication", siteReplication.Name, validateSiteReplication(siteReplication))
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *SiteReplicationCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSiteReplication validates the spec of a SiteReplication
+func validateSiteReplication(siteReplication *miniov1beta1.SiteReplication) field.ErrorList {
+	var allErrs field.ErrorList
+	sitesPath := field.NewPath("spec", "sites")
+
+	if len(siteReplication.Spec.Sites) < 2 {
+		allErrs = append(allErrs, field.Invalid(sitesPath, len(siteReplication.Spec.Sites), "at least two sites are required"))
+	}
+
+	names := map[string]bool{}
+	aliases := map[string]bool{}
+	for i, site := range siteReplication.Spec.Sites {
+		sitePath := sitesPath.Index(i)
+
+		if site.Name == "" {
+			allErrs = append(allErrs, field.Required(sitePath.Child("name"), "site name must be set"))
+		} else if names[site.Name] {
+			allErrs = append(allErrs, field.Duplicate(sitePath.Child("name"), site.Name))
+		}
+		names[site.Name] = true
+
+		// Sites are cluster-wide, so Aliases must name their namespace
+		var alias string
+		switch {
+		case site.AliasRef != nil && site.ClusterAliasRef != nil:
+			allErrs = append(allErrs, field.Invalid(sitePath, site.Name, "only one of aliasRef or clusterAliasRef can be specified"))
+		case site.AliasRef != nil:
+			if site.AliasRef.Name == "" {
+				allErrs = append(allErrs, field.Required(sitePath.Child("aliasRef", "name"), "alias name must be set"))
+			}
+			if site.AliasRef.Namespace == nil || *site.AliasRef.Namespace == "" {
+				allErrs = append(allErrs, field.Required(sitePath.Child("aliasRef", "namespace"), "alias namespace must be set for a cluster-scoped site replication"))
+			} else {
+				alias = "Alias " + *site.AliasRef.Namespace + "/" + site.AliasRef.Name
+			}
+		case site.ClusterAliasRef != nil:
+			if site.ClusterAliasRef.Name == "" {
+				allErrs = append(allErrs, field.Required(sitePath.Child("clusterAliasRef", "name"), "cluster alias name must be set"))
+			}
+			alias = "ClusterAlias " + site.ClusterAliasRef.Name
+		default:
+			allErrs = append(allErrs, field.Required(sitePath, "one of aliasRef or clusterAliasRef must be specified"))
+		}
+		if alias != "" && aliases[alias] {
+			allErrs = append(allErrs, field.Duplicate(sitePath, alias))
+		}
+		aliases[alias] = true
+
+		if site.Endpoint != "" {
+			allErrs = append(allErrs, validateURL(site.Endpoint, sitePath.Child("endpoint"))...)
+		}
+	}
+
+	return allErrs
+}
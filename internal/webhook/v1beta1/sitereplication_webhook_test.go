@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("SiteReplication Webhook", func() {
+	var (
+		ctx             context.Context
+		siteReplication *miniov1beta1.SiteReplication
+		validator       SiteReplicationCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace := "minio-us"
+		siteReplication = &miniov1beta1.SiteReplication{
+			ObjectMeta: metav1.ObjectMeta{Name: "global"},
+			Spec: miniov1beta1.SiteReplicationSpec{
+				Sites: []miniov1beta1.ReplicationSite{
+					{Name: "eu", ClusterAliasRef: &miniov1beta1.ClusterAliasReference{Name: "minio-eu"}},
+					{Name: "us", AliasRef: &miniov1beta1.AliasReference{Name: "minio", Namespace: &namespace}},
+				},
+			},
+		}
+	})
+
+	Context("When creating a SiteReplication", func() {
+		It("should admit a valid site replication", func() {
+			_, err := validator.ValidateCreate(ctx, siteReplication)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a single site", func() {
+			siteReplication.Spec.Sites = siteReplication.Spec.Sites[:1]
+			_, err := validator.ValidateCreate(ctx, siteReplication)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject duplicate site names", func() {
+			siteReplication.Spec.Sites[1].Name = "eu"
+			_, err := validator.ValidateCreate(ctx, siteReplication)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject the same alias for two sites", func() {
+			siteReplication.Spec.Sites[1].AliasRef = nil
+			siteReplication.Spec.Sites[1].ClusterAliasRef = &miniov1beta1.ClusterAliasReference{Name: "minio-eu"}
+			_, err := validator.ValidateCreate(ctx, siteReplication)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject an alias without namespace", func() {
+			siteReplication.Spec.Sites[1].AliasRef.Namespace = nil
+			_, err := validator.ValidateCreate(ctx, siteReplication)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a site without alias", func() {
+			siteReplication.Spec.Sites[0].ClusterAliasRef = nil
+			_, err := validator.ValidateCreate(ctx, siteReplication)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject an endpoint without scheme", func() {
+			siteReplication.Spec.Sites[0].Endpoint = "minio-eu.example.com"
+			_, err := validator.ValidateCreate(ctx, siteReplication)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
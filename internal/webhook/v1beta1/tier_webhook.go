@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// tierNamePattern matches the upper case tier names MinIO accepts
+var tierNamePattern = regexp.MustCompile(`^[A-Z0-9_-]+$`)
+
+// SetupTierWebhookWithManager registers the webhooks for Tier in the manager
+func SetupTierWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.Tier{}).
+		WithValidator(&TierCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&TierCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-tier,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=tiers,verbs=create;update,versions=v1beta1,name=mtier-v1beta1.kb.io,admissionReviewVersions=v1
+
+// TierCustomDefaulter sets default values on Tier resources
+type TierCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &TierCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *TierCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	tier, ok := obj.(*miniov1beta1.Tier)
+	if !ok {
+		return fmt.Errorf("expected a Tier object but got %T", obj)
+	}
+
+	defaultConnection(&tier.Spec.Connection)
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-tier,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=tiers,verbs=create;update,versions=v1beta1,name=vtier-v1beta1.kb.io,admissionReviewVersions=v1
+
+// TierCustomValidator validates Tier resources
+type TierCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &TierCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *TierCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	tier, ok := obj.(*miniov1beta1.Tier)
+	if !ok {
+		return nil, fmt.Errorf("expected a Tier object but got %T", obj)
+	}
+
+	if err := invalid("Tier", tier.Name, validateTier(tier)); err != nil {
+		return connectionWarnings(tier.Annotations), err
+	}
+	return connectionWarnings(tier.Annotations), validateTenantPolicies(ctx, v.Client, "tiers", tier)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *TierCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldTier, ok := oldObj.(*miniov1beta1.Tier)
+	if !ok {
+		return nil, fmt.Errorf("expected a Tier object but got %T", oldObj)
+	}
+	tier, ok := newObj.(*miniov1beta1.Tier)
+	if !ok {
+		return nil, fmt.Errorf("expected a Tier object but got %T", newObj)
+	}
+
+	// MinIO only changes the credentials of an existing tier
+	specPath := field.NewPath("spec")
+	allErrs := validateTier(tier)
+	allErrs = append(allErrs, validateImmutable(tier.Spec.TierName, oldTier.Spec.TierName, specPath.Child("tierName"))...)
+	allErrs = append(allErrs, validateImmutable(tier.Spec.Type, oldTier.Spec.Type, specPath.Child("type"))...)
+	allErrs = append(allErrs, validateImmutable(tier.Spec.Endpoint, oldTier.Spec.Endpoint, specPath.Child("endpoint"))...)
+	allErrs = append(allErrs, validateImmutable(tier.Spec.Bucket, oldTier.Spec.Bucket, specPath.Child("bucket"))...)
+	allErrs = append(allErrs, validateImmutable(tier.Spec.Prefix, oldTier.Spec.Prefix, specPath.Child("prefix"))...)
+	allErrs = append(allErrs, validateImmutable(tier.Spec.Region, oldTier.Spec.Region, specPath.Child("region"))...)
+	allErrs = append(allErrs, validateImmutable(tier.Spec.StorageClass, oldTier.Spec.StorageClass, specPath.Child("storageClass"))...)
+
+	if err := invalid("Tier", tier.Name, allErrs); err != nil {
+		return connectionWarnings(tier.Annotations), err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldTier.Spec, tier.Spec) {
+		return connectionWarnings(tier.Annotations), nil
+	}
+	return connectionWarnings(tier.Annotations), validateTenantPolicies(ctx, v.Client, "tiers", tier)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *TierCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateTier validates the spec of a Tier
+func validateTier(tier *miniov1beta1.Tier) field.ErrorList {
+	specPath := field.NewPath("spec")
+	spec := tier.Spec
+
+	allErrs := validateConnection(spec.Connection, tier.Annotations, specPath.Child("connection"))
+	if !tierNamePattern.MatchString(spec.TierName) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("tierName"), spec.TierName,
+			"tier name must consist of upper case letters, digits, '-' and '_'"))
+	}
+	if spec.Bucket == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("bucket"), "remote bucket must be set"))
+	}
+	if spec.CredentialsSecretRef.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("credentialsSecretRef", "name"), "secret name must be set"))
+	}
+
+	switch spec.Type {
+	case miniov1beta1.TierTypeMinIO:
+		if spec.Endpoint == "" {
+			allErrs = append(allErrs, field.Required(specPath.Child("endpoint"), "endpoint must be set for MinIO tiers"))
+		}
+		if spec.StorageClass != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("storageClass"), "storage class is not supported for MinIO tiers"))
+		}
+	case miniov1beta1.TierTypeGCS:
+		if spec.Endpoint != "" {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("endpoint"), "custom endpoints are not supported for GCS tiers"))
+		}
+	case miniov1beta1.TierTypeS3, miniov1beta1.TierTypeAzure:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("type"), spec.Type, []string{
+			string(miniov1beta1.TierTypeMinIO), string(miniov1beta1.TierTypeS3),
+			string(miniov1beta1.TierTypeAzure), string(miniov1beta1.TierTypeGCS),
+		}))
+	}
+	if spec.Endpoint != "" {
+		allErrs = append(allErrs, validateURL(spec.Endpoint, specPath.Child("endpoint"))...)
+	}
+
+	// GCS authenticates with a service account key, all other backends with a key pair
+	secretPath := specPath.Child("credentialsSecretRef")
+	if spec.Type == miniov1beta1.TierTypeGCS {
+		if spec.CredentialsSecretRef.AccessKeyIDKey != "" || spec.CredentialsSecretRef.SecretAccessKeyKey != "" {
+			allErrs = append(allErrs, field.Forbidden(secretPath, "GCS tiers only use credentialsKey"))
+		}
+	} else if spec.CredentialsSecretRef.CredentialsKey != "" {
+		allErrs = append(allErrs, field.Forbidden(secretPath.Child("credentialsKey"), "credentialsKey is only used for GCS tiers"))
+	}
+
+	return allErrs
+}
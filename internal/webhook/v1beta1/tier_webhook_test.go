@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("Tier Webhook", func() {
+	var (
+		ctx       context.Context
+		tier      *miniov1beta1.Tier
+		validator TierCustomValidator
+		defaulter TierCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tier = &miniov1beta1.Tier{
+			ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: "default"},
+			Spec: miniov1beta1.TierSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				TierName:             "WARM",
+				Type:                 miniov1beta1.TierTypeMinIO,
+				Endpoint:             "https://minio-warm.example.com",
+				Bucket:               "transitions",
+				CredentialsSecretRef: miniov1beta1.TierCredentialsReference{Name: "warm-credentials"},
+			},
+		}
+	})
+
+	Context("When creating a Tier", func() {
+		It("should admit a valid MinIO tier", func() {
+			_, err := validator.ValidateCreate(ctx, tier)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a lower case tier name", func() {
+			tier.Spec.TierName = "warm"
+			_, err := validator.ValidateCreate(ctx, tier)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a MinIO tier without endpoint", func() {
+			tier.Spec.Endpoint = ""
+			_, err := validator.ValidateCreate(ctx, tier)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should admit an S3 tier with the default endpoint", func() {
+			tier.Spec.Type = miniov1beta1.TierTypeS3
+			tier.Spec.Endpoint = ""
+			tier.Spec.StorageClass = "GLACIER"
+			_, err := validator.ValidateCreate(ctx, tier)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject key pair settings for a GCS tier", func() {
+			tier.Spec.Type = miniov1beta1.TierTypeGCS
+			tier.Spec.Endpoint = ""
+			tier.Spec.CredentialsSecretRef.AccessKeyIDKey = "accessKeyID"
+			_, err := validator.ValidateCreate(ctx, tier)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject a missing remote bucket", func() {
+			tier.Spec.Bucket = ""
+			_, err := validator.ValidateCreate(ctx, tier)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When updating a Tier", func() {
+		It("should admit changing the credentials secret", func() {
+			updated := tier.DeepCopy()
+			updated.Spec.CredentialsSecretRef.Name = "rotated-credentials"
+			_, err := validator.ValidateUpdate(ctx, tier, updated)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject changing the remote bucket", func() {
+			updated := tier.DeepCopy()
+			updated.Spec.Bucket = "other-transitions"
+			_, err := validator.ValidateUpdate(ctx, tier, updated)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When defaulting a Tier", func() {
+		It("should default the alias namespace", func() {
+			Expect(defaulter.Default(ctx, tier)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+// SetupUserWebhookWithManager registers the webhooks for User in the manager
+func SetupUserWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&miniov1beta1.User{}).
+		WithValidator(&UserCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&UserCustomDefaulter{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-mc-controller-mxcd-de-v1beta1-user,mutating=true,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=users,verbs=create;update,versions=v1beta1,name=muser-v1beta1.kb.io,admissionReviewVersions=v1
+
+// UserCustomDefaulter sets default values on User resources
+type UserCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &UserCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *UserCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	user, ok := obj.(*miniov1beta1.User)
+	if !ok {
+		return fmt.Errorf("expected a User object but got %T", obj)
+	}
+
+	defaultConnection(&user.Spec.Connection)
+	if user.Spec.SecretRef != nil && user.Spec.SecretRef.PasswordKey == "" {
+		user.Spec.SecretRef.PasswordKey = miniov1beta1.DefaultPasswordKey
+	}
+	if user.Spec.Status == "" {
+		user.Spec.Status = miniov1beta1.UserStatusEnabled
+	}
+	return nil
+}
+
+//+kubebuilder:webhook:path=/validate-mc-controller-mxcd-de-v1beta1-user,mutating=false,failurePolicy=fail,sideEffects=None,groups=mc-controller.mxcd.de,resources=users,verbs=create;update,versions=v1beta1,name=vuser-v1beta1.kb.io,admissionReviewVersions=v1
+
+// UserCustomValidator validates User resources
+type UserCustomValidator struct {
+	// Client reads the tenant policies. Tenant policies are not enforced if it is nil.
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &UserCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *UserCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	user, ok := obj.(*miniov1beta1.User)
+	if !ok {
+		return nil, fmt.Errorf("expected a User object but got %T", obj)
+	}
+
+	allErrs := validateUser(user)
+	allErrs = append(allErrs, validateLegacyPassword(ctx, user, nil)...)
+	if err := invalid("User", user.Name, allErrs); err != nil {
+		return userWarnings(user), err
+	}
+	return userWarnings(user), validateTenantPolicies(ctx, v.Client, "users", user)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *UserCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldUser, ok := oldObj.(*miniov1beta1.User)
+	if !ok {
+		return nil, fmt.Errorf("expected a User object but got %T", oldObj)
+	}
+	user, ok := newObj.(*miniov1beta1.User)
+	if !ok {
+		return nil, fmt.Errorf("expected a User object but got %T", newObj)
+	}
+
+	allErrs := validateUser(user)
+	allErrs = append(allErrs, validateLegacyPassword(ctx, user, oldUser)...)
+	allErrs = append(allErrs, validateImmutable(user.Spec.Username, oldUser.Spec.Username, field.NewPath("spec", "username"))...)
+
+	if err := invalid("User", user.Name, allErrs); err != nil {
+		return userWarnings(user), err
+	}
+	// Tenant policies are only enforced on spec changes, so that metadata like finalizers can always be updated
+	if equality.Semantic.DeepEqual(oldUser.Spec, user.Spec) {
+		return userWarnings(user), nil
+	}
+	return userWarnings(user), validateTenantPolicies(ctx, v.Client, "users", user)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *UserCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateUser validates the spec of a User
+func validateUser(user *miniov1beta1.User) field.ErrorList {
+	specPath := field.NewPath("spec")
+
+	allErrs := validateConnection(user.Spec.Connection, user.Annotations, specPath.Child("connection"))
+	if user.Spec.Username == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("username"), "username must be set"))
+	}
+
+	// A password set through v1alpha1 is moved into a secret by the controller
+	_, hasLegacyPassword := user.Annotations[miniov1beta1.LegacyPasswordAnnotation]
+	switch {
+	case user.Spec.SecretRef == nil && !hasLegacyPassword:
+		allErrs = append(allErrs, field.Required(specPath.Child("secretRef"), "secretRef must be set"))
+	case user.Spec.SecretRef != nil && user.Spec.SecretRef.Name == "":
+		allErrs = append(allErrs, field.Required(specPath.Child("secretRef", "name"), "secret name must be set"))
+	}
+
+	switch user.Spec.Status {
+	case "", miniov1beta1.UserStatusEnabled, miniov1beta1.UserStatusDisabled:
+	default:
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("status"), user.Spec.Status,
+			[]string{string(miniov1beta1.UserStatusEnabled), string(miniov1beta1.UserStatusDisabled)}))
+	}
+
+	return allErrs
+}
+
+// validateLegacyPassword only admits the plaintext password annotation on Users converted from a
+// v1alpha1 request, and unchanged on Users whose password the controller has not moved yet
+func validateLegacyPassword(ctx context.Context, user, oldUser *miniov1beta1.User) field.ErrorList {
+	password, ok := user.Annotations[miniov1beta1.LegacyPasswordAnnotation]
+	if !ok {
+		return nil
+	}
+	if oldUser != nil {
+		if oldPassword, ok := oldUser.Annotations[miniov1beta1.LegacyPasswordAnnotation]; ok && oldPassword == password {
+			return nil
+		}
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.RequestKind != nil && req.RequestKind.Version == "v1alpha1" {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("metadata", "annotations").Key(miniov1beta1.LegacyPasswordAnnotation),
+		"is only set by the conversion of v1alpha1 Users, use spec.secretRef instead")}
+}
+
+// userWarnings returns warnings for discouraged settings of a User
+func userWarnings(user *miniov1beta1.User) admission.Warnings {
+	warnings := connectionWarnings(user.Annotations)
+	if _, ok := user.Annotations[miniov1beta1.LegacyPasswordAnnotation]; ok {
+		warnings = append(warnings, "spec.password is deprecated and will be moved into a secret, use spec.secretRef instead")
+	}
+	return warnings
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+)
+
+var _ = Describe("User Webhook", func() {
+	var (
+		ctx       context.Context
+		user      *miniov1beta1.User
+		validator UserCustomValidator
+		defaulter UserCustomDefaulter
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		user = &miniov1beta1.User{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-user", Namespace: "default"},
+			Spec: miniov1beta1.UserSpec{
+				Connection: miniov1beta1.MinIOConnection{
+					AliasRef: &miniov1beta1.AliasReference{Name: "minio"},
+				},
+				Username:  "test-user",
+				SecretRef: &miniov1beta1.UserSecretReference{Name: "test-user-password"},
+			},
+		}
+	})
+
+	It("should admit a user with a password secret", func() {
+		warnings, err := validator.ValidateCreate(ctx, user)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("should admit and warn about a password carried over from v1alpha1", func() {
+		user.Spec.SecretRef = nil
+		user.Annotations = map[string]string{miniov1beta1.LegacyPasswordAnnotation: "secret"}
+		ctx = admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			RequestKind: &metav1.GroupVersionKind{Group: miniov1beta1.GroupVersion.Group, Version: "v1alpha1", Kind: "User"},
+		}})
+		warnings, err := validator.ValidateCreate(ctx, user)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+	})
+
+	It("should reject a password annotation set on a v1beta1 user", func() {
+		user.Annotations = map[string]string{miniov1beta1.LegacyPasswordAnnotation: "secret"}
+		_, err := validator.ValidateCreate(ctx, user)
+		Expect(err).To(HaveOccurred())
+
+		updated := user.DeepCopy()
+		user.Annotations = nil
+		_, err = validator.ValidateUpdate(ctx, user, updated)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should admit updates keeping a password that was not moved yet", func() {
+		user.Spec.SecretRef = nil
+		user.Annotations = map[string]string{miniov1beta1.LegacyPasswordAnnotation: "secret"}
+		updated := user.DeepCopy()
+		updated.Finalizers = []string{miniov1beta1.UserFinalizer}
+		_, err := validator.ValidateUpdate(ctx, user, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject a user without secretRef", func() {
+		user.Spec.SecretRef = nil
+		_, err := validator.ValidateCreate(ctx, user)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject changing the username", func() {
+		updated := user.DeepCopy()
+		updated.Spec.Username = "other-user"
+		_, err := validator.ValidateUpdate(ctx, user, updated)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should default the status and the password key", func() {
+		Expect(defaulter.Default(ctx, user)).To(Succeed())
+		Expect(user.Spec.Status).To(Equal(miniov1beta1.UserStatusEnabled))
+		Expect(user.Spec.SecretRef.PasswordKey).To(Equal(miniov1beta1.DefaultPasswordKey))
+	})
+})
@@ -0,0 +1,248 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta1 contains the admission webhooks for the v1beta1 API
+package v1beta1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
+	"github.com/mxcd/mc-controller/internal/tenant"
+)
+
+// bucketNamePattern matches the characters allowed in S3 bucket names
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+
+// invalid converts a list of field errors to an API error for the given kind
+func invalid(kind, name string, allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(schema.GroupKind{Group: miniov1beta1.GroupVersion.Group, Kind: kind}, name, allErrs)
+}
+
+// validateConnection checks that exactly one connection method is configured. An endpointRef
+// carried over from v1alpha1 in the annotations counts as a connection method.
+func validateConnection(conn miniov1beta1.MinIOConnection, annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	methods := 0
+	if conn.AliasRef != nil {
+		methods++
+		if conn.AliasRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("aliasRef", "name"), "alias name must be set"))
+		}
+	}
+	if conn.ClusterAliasRef != nil {
+		methods++
+		if conn.ClusterAliasRef.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("clusterAliasRef", "name"), "cluster alias name must be set"))
+		}
+	}
+	if conn.URL != nil {
+		methods++
+		allErrs = append(allErrs, validateURL(*conn.URL, fldPath.Child("url"))...)
+		if conn.SecretRef == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("secretRef"), "secretRef must be set when using url"))
+		}
+	}
+	if _, ok := annotations[miniov1beta1.LegacyEndpointRefAnnotation]; ok {
+		methods++
+	}
+
+	switch {
+	case methods == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "exactly one of aliasRef, clusterAliasRef or url must be set"))
+	case methods > 1:
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "exactly one of aliasRef, clusterAliasRef or url must be set"))
+	}
+
+	if conn.URL == nil {
+		if conn.SecretRef != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("secretRef"), "secretRef is only used with url"))
+		}
+		if conn.TLS != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("tls"), "tls is only used with url"))
+		}
+	}
+	if conn.SecretRef != nil {
+		allErrs = append(allErrs, validateSecretReference(*conn.SecretRef, fldPath.Child("secretRef"))...)
+	}
+
+	return allErrs
+}
+
+// connectionWarnings returns warnings for deprecated connection settings
+func connectionWarnings(annotations map[string]string) admission.Warnings {
+	if _, ok := annotations[miniov1beta1.LegacyEndpointRefAnnotation]; ok {
+		return admission.Warnings{"spec.connection.endpointRef is deprecated, use spec.connection.aliasRef instead"}
+	}
+	return nil
+}
+
+// validateTenantPolicies rejects obj if it is not allowed by the tenant policies of its namespace
+func validateTenantPolicies(ctx context.Context, c client.Reader, resource string, obj client.Object) error {
+	if c == nil {
+		return nil
+	}
+
+	err := tenant.Check(ctx, c, obj)
+	if err == nil {
+		return nil
+	}
+	var tenantErr *tenant.Error
+	if errors.As(err, &tenantErr) {
+		return apierrors.NewForbidden(miniov1beta1.GroupVersion.WithResource(resource).GroupResource(), obj.GetName(), err)
+	}
+	return apierrors.NewInternalError(err)
+}
+
+// validateSecretReference checks that a secret reference names a secret
+func validateSecretReference(ref miniov1beta1.SecretReference, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ref.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "secret name must be set"))
+	}
+	return allErrs
+}
+
+// credentialSource returns the credential source of an alias, which defaults to the secret reference
+func credentialSource(creds *miniov1beta1.AliasCredentials) miniov1beta1.CredentialSource {
+	if creds == nil || creds.Source == "" {
+		return miniov1beta1.CredentialSourceSecret
+	}
+	return creds.Source
+}
+
+// usesSecretKeys reports whether an alias authenticates with the keys of its secret reference
+func usesSecretKeys(creds *miniov1beta1.AliasCredentials) bool {
+	source := credentialSource(creds)
+	return source == miniov1beta1.CredentialSourceSecret || source == miniov1beta1.CredentialSourceAssumeRole
+}
+
+// validateAliasCredentials checks that the secret reference is set when the credential source needs keys and
+// that only the options of the credential source are set
+func validateAliasCredentials(creds *miniov1beta1.AliasCredentials, secretName string, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	source := credentialSource(creds)
+	if usesSecretKeys(creds) && secretName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("secretRef", "name"), fmt.Sprintf("secret name must be set for %s credentials", source)))
+	}
+	if creds == nil {
+		return allErrs
+	}
+
+	fldPath := specPath.Child("credentials")
+	if creds.TokenPath != "" && source != miniov1beta1.CredentialSourceWebIdentity {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("tokenPath"), "tokenPath is only used with WebIdentity credentials"))
+	}
+	if source == miniov1beta1.CredentialSourceSecret || source == miniov1beta1.CredentialSourceAWS {
+		if creds.RoleARN != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("roleArn"), fmt.Sprintf("roleArn is not used with %s credentials", source)))
+		}
+		if creds.DurationSeconds != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("durationSeconds"), fmt.Sprintf("durationSeconds is not used with %s credentials", source)))
+		}
+		if creds.STSEndpoint != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("stsEndpoint"), fmt.Sprintf("stsEndpoint is not used with %s credentials", source)))
+		}
+	}
+	if creds.STSEndpoint != nil {
+		allErrs = append(allErrs, validateURL(*creds.STSEndpoint, fldPath.Child("stsEndpoint"))...)
+	}
+	return allErrs
+}
+
+// validateURL checks that rawURL is an absolute http or https URL
+func validateURL(rawURL string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, rawURL, err.Error()))
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		allErrs = append(allErrs, field.Invalid(fldPath, rawURL, "url must use the http or https scheme"))
+	}
+	if parsed.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, rawURL, "url must contain a host"))
+	}
+
+	return allErrs
+}
+
+// validateBucketName checks name against the S3 bucket naming rules
+func validateBucketName(name string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch {
+	case len(name) < 3 || len(name) > 63:
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "bucket name must be between 3 and 63 characters long"))
+	case !bucketNamePattern.MatchString(name):
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "bucket name must consist of lowercase letters, numbers, dots and hyphens, and must begin and end with a letter or number"))
+	case strings.Contains(name, ".."):
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "bucket name must not contain two adjacent periods"))
+	case strings.Contains(name, ".-") || strings.Contains(name, "-."):
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "bucket name must not contain a period next to a hyphen"))
+	case net.ParseIP(name) != nil:
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "bucket name must not be formatted as an IP address"))
+	case strings.HasPrefix(name, "xn--") || strings.HasPrefix(name, "sthree-"):
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "bucket name must not start with a reserved prefix"))
+	case strings.HasSuffix(name, "-s3alias") || strings.HasSuffix(name, "--ol-s3"):
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "bucket name must not end with a reserved suffix"))
+	}
+
+	return allErrs
+}
+
+// validateImmutable rejects changes to a field that must not be updated
+func validateImmutable[T comparable](newValue, oldValue T, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if newValue != oldValue {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "field is immutable"))
+	}
+	return allErrs
+}
+
+// defaultSecretReference fills in the default keys of a credentials secret reference
+func defaultSecretReference(ref *miniov1beta1.SecretReference) {
+	if ref.AccessKeyIDKey == "" {
+		ref.AccessKeyIDKey = miniov1beta1.DefaultAccessKeyIDKey
+	}
+	if ref.SecretAccessKeyKey == "" {
+		ref.SecretAccessKeyKey = miniov1beta1.DefaultSecretAccessKeyKey
+	}
+}
+
+// defaultConnection fills in the defaults of a connection
+func defaultConnection(conn *miniov1beta1.MinIOConnection) {
+	if conn.URL != nil && conn.SecretRef != nil {
+		defaultSecretReference(conn.SecretRef)
+	}
+}
@@ -37,7 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
-	miniov1alpha1 "github.com/mxcd/mc-controller/api/v1alpha1"
+	miniov1beta1 "github.com/mxcd/mc-controller/api/v1beta1"
 	"github.com/mxcd/mc-controller/test/utils"
 )
 
@@ -197,7 +197,7 @@ func setupTestEnvironment() {
 
 	// Create scheme with our CRDs
 	scheme := runtime.NewScheme()
-	err = miniov1alpha1.AddToScheme(scheme)
+	err = miniov1beta1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
 	err = corev1.AddToScheme(scheme)
 	Expect(err).NotTo(HaveOccurred())
@@ -364,7 +364,7 @@ func waitForControllerReady() {
 	// Since controller is running locally, just check if CRDs are installed and accessible
 	Eventually(func() error {
 		// Try to list aliases to check if CRDs and controller are working
-		aliases := &miniov1alpha1.AliasList{}
+		aliases := &miniov1beta1.AliasList{}
 		err := k8sClient.List(context.Background(), aliases, client.InNamespace(testNamespace))
 		if err != nil {
 			return fmt.Errorf("controller not ready - cannot list aliases: %v", err)
@@ -398,17 +398,17 @@ func testAliasCRD() {
 	aliasName := "test-alias"
 
 	By("creating an Alias")
-	alias := &miniov1alpha1.Alias{
+	alias := &miniov1beta1.Alias{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      aliasName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.AliasSpec{
+		Spec: miniov1beta1.AliasSpec{
 			URL: fmt.Sprintf("http://%s", minioURL),
-			SecretRef: miniov1alpha1.SecretReference{
+			SecretRef: miniov1beta1.SecretReference{
 				Name: "minio-credentials",
 			},
-			HealthCheck: &miniov1alpha1.AliasHealthCheck{
+			HealthCheck: &miniov1beta1.AliasHealthCheck{
 				Enabled:         true,
 				IntervalSeconds: &[]int32{30}[0],
 			},
@@ -441,14 +441,14 @@ func testBucketCRD() {
 	bucketCRDName := "test-bucket-crd"
 
 	By("creating an Alias for bucket test")
-	alias := &miniov1alpha1.Alias{
+	alias := &miniov1beta1.Alias{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      aliasName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.AliasSpec{
+		Spec: miniov1beta1.AliasSpec{
 			URL: fmt.Sprintf("http://%s", minioURL),
-			SecretRef: miniov1alpha1.SecretReference{
+			SecretRef: miniov1beta1.SecretReference{
 				Name: "minio-credentials",
 			},
 		},
@@ -466,14 +466,14 @@ func testBucketCRD() {
 	}, timeout, interval).Should(BeTrue())
 
 	By("creating a Bucket")
-	bucket := &miniov1alpha1.Bucket{
+	bucket := &miniov1beta1.Bucket{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      bucketCRDName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.BucketSpec{
-			Connection: miniov1alpha1.MinIOConnection{
-				AliasRef: &miniov1alpha1.AliasReference{
+		Spec: miniov1beta1.BucketSpec{
+			Connection: miniov1beta1.MinIOConnection{
+				AliasRef: &miniov1beta1.AliasReference{
 					Name: aliasName,
 				},
 			},
@@ -540,14 +540,14 @@ func testUserCRD() {
 	Expect(err).NotTo(HaveOccurred())
 
 	By("creating an Alias for user test")
-	alias := &miniov1alpha1.Alias{
+	alias := &miniov1beta1.Alias{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      aliasName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.AliasSpec{
+		Spec: miniov1beta1.AliasSpec{
 			URL: fmt.Sprintf("http://%s", minioURL),
-			SecretRef: miniov1alpha1.SecretReference{
+			SecretRef: miniov1beta1.SecretReference{
 				Name: "minio-credentials",
 			},
 		},
@@ -565,23 +565,23 @@ func testUserCRD() {
 	}, timeout, interval).Should(BeTrue())
 
 	By("creating a User")
-	user := &miniov1alpha1.User{
+	user := &miniov1beta1.User{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      userCRDName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.UserSpec{
-			Connection: miniov1alpha1.MinIOConnection{
-				AliasRef: &miniov1alpha1.AliasReference{
+		Spec: miniov1beta1.UserSpec{
+			Connection: miniov1beta1.MinIOConnection{
+				AliasRef: &miniov1beta1.AliasReference{
 					Name: aliasName,
 				},
 			},
 			Username: userName,
-			SecretRef: &miniov1alpha1.SecretReference{
-				Name:               "test-user-password",
-				SecretAccessKeyKey: "password",
+			SecretRef: &miniov1beta1.UserSecretReference{
+				Name:        "test-user-password",
+				PasswordKey: "password",
 			},
-			Status: miniov1alpha1.UserStatusEnabled,
+			Status: miniov1beta1.UserStatusEnabled,
 		},
 	}
 	err = k8sClient.Create(context.Background(), user)
@@ -622,14 +622,14 @@ func testPolicyCRD() {
 	policyCRDName := "test-policy-crd"
 
 	By("creating an Alias for policy test")
-	alias := &miniov1alpha1.Alias{
+	alias := &miniov1beta1.Alias{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      aliasName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.AliasSpec{
+		Spec: miniov1beta1.AliasSpec{
 			URL: fmt.Sprintf("http://%s", minioURL),
-			SecretRef: miniov1alpha1.SecretReference{
+			SecretRef: miniov1beta1.SecretReference{
 				Name: "minio-credentials",
 			},
 		},
@@ -658,14 +658,14 @@ func testPolicyCRD() {
 		]
 	}`
 
-	policy := &miniov1alpha1.Policy{
+	policy := &miniov1beta1.Policy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      policyCRDName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.PolicySpec{
-			Connection: miniov1alpha1.MinIOConnection{
-				AliasRef: &miniov1alpha1.AliasReference{
+		Spec: miniov1beta1.PolicySpec{
+			Connection: miniov1beta1.MinIOConnection{
+				AliasRef: &miniov1beta1.AliasReference{
 					Name: aliasName,
 				},
 			},
@@ -709,14 +709,14 @@ func testPolicyAttachmentCRD() {
 	userSecretName := "attach-user-secret"
 
 	By("creating an Alias for policy attachment test")
-	alias := &miniov1alpha1.Alias{
+	alias := &miniov1beta1.Alias{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      aliasName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.AliasSpec{
+		Spec: miniov1beta1.AliasSpec{
 			URL: fmt.Sprintf("http://%s", minioURL),
-			SecretRef: miniov1alpha1.SecretReference{
+			SecretRef: miniov1beta1.SecretReference{
 				Name: "minio-credentials",
 			},
 		},
@@ -744,14 +744,14 @@ func testPolicyAttachmentCRD() {
 			}
 		]
 	}`
-	policy := &miniov1alpha1.Policy{
+	policy := &miniov1beta1.Policy{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      policyCRDName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.PolicySpec{
-			Connection: miniov1alpha1.MinIOConnection{
-				AliasRef: &miniov1alpha1.AliasReference{
+		Spec: miniov1beta1.PolicySpec{
+			Connection: miniov1beta1.MinIOConnection{
+				AliasRef: &miniov1beta1.AliasReference{
 					Name: aliasName,
 				},
 			},
@@ -785,23 +785,23 @@ func testPolicyAttachmentCRD() {
 	Expect(err).NotTo(HaveOccurred())
 
 	By("creating a User to attach the policy to")
-	user := &miniov1alpha1.User{
+	user := &miniov1beta1.User{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      userCRDName,
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.UserSpec{
-			Connection: miniov1alpha1.MinIOConnection{
-				AliasRef: &miniov1alpha1.AliasReference{
+		Spec: miniov1beta1.UserSpec{
+			Connection: miniov1beta1.MinIOConnection{
+				AliasRef: &miniov1beta1.AliasReference{
 					Name: aliasName,
 				},
 			},
 			Username: userName,
-			SecretRef: &miniov1alpha1.SecretReference{
-				Name:               userSecretName,
-				SecretAccessKeyKey: "password",
+			SecretRef: &miniov1beta1.UserSecretReference{
+				Name:        userSecretName,
+				PasswordKey: "password",
 			},
-			Status: miniov1alpha1.UserStatusEnabled,
+			Status: miniov1beta1.UserStatusEnabled,
 		},
 	}
 	err = k8sClient.Create(context.Background(), user)
@@ -818,19 +818,19 @@ func testPolicyAttachmentCRD() {
 
 	By("creating a PolicyAttachment")
 	targetUser := userName
-	attachment := &miniov1alpha1.PolicyAttachment{
+	attachment := &miniov1beta1.PolicyAttachment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "attach-policyattachment",
 			Namespace: testNamespace,
 		},
-		Spec: miniov1alpha1.PolicyAttachmentSpec{
-			Connection: miniov1alpha1.MinIOConnection{
-				AliasRef: &miniov1alpha1.AliasReference{
+		Spec: miniov1beta1.PolicyAttachmentSpec{
+			Connection: miniov1beta1.MinIOConnection{
+				AliasRef: &miniov1beta1.AliasReference{
 					Name: aliasName,
 				},
 			},
 			PolicyName: policyName,
-			Target: miniov1alpha1.PolicyAttachmentTarget{
+			Target: miniov1beta1.PolicyAttachmentTarget{
 				User: &targetUser,
 			},
 		},